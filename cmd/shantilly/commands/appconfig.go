@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/helton/shantilly/internal/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// validationOptions holds the --app-config flag of form, layout and tabs,
+// whose validation.component settings drive the real-time validation,
+// validation.schema the JSON Schema check and, for forms,
+// validation.business the business validator.
+type validationOptions struct {
+	appConfig string
+}
+
+// addFlags registers --app-config on cmd.
+func (o *validationOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.appConfig, "app-config", "", "configuração da aplicação com as opções de validação (seção validation)")
+}
+
+// load returns the application configuration of --app-config decoded over
+// the defaults, so partial files keep the default of every missing setting
+// while explicit zero values, such as real_time: false, are honored. serve
+// reads its security settings the same way.
+func (o *validationOptions) load() (*config.Config, error) {
+	cfg := config.DefaultConfig()
+	if o.appConfig == "" {
+		return cfg, nil
+	}
+	data, err := os.ReadFile(o.appConfig)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar configuração da aplicação: %w", err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("erro ao carregar configuração da aplicação: %w", err)
+	}
+	return cfg, nil
+}
+
+// componentValidation returns the real-time validation settings: the
+// defaults, overridden by the settings of --app-config when given.
+func (o *validationOptions) componentValidation() (config.ComponentValidation, error) {
+	cfg, err := o.load()
+	if err != nil {
+		return config.ComponentValidation{}, err
+	}
+	component := cfg.Validation.Component
+	if component.DebounceMs < 0 {
+		return component, fmt.Errorf("validation.component.debounce_ms deve ser maior ou igual a zero, recebido: %d", component.DebounceMs)
+	}
+	return component, nil
+}
+
+// businessValidation returns the business validation settings of
+// --app-config. The custom_validator expression is parsed here, so mistakes
+// are reported with their position before the form opens.
+func (o *validationOptions) businessValidation() (config.BusinessValidation, error) {
+	cfg, err := o.load()
+	if err != nil {
+		return config.BusinessValidation{}, err
+	}
+	business := cfg.Validation.Business
+	if err := business.Validate(); err != nil {
+		return business, fmt.Errorf("validation.business.%w", err)
+	}
+	return business, nil
+}
+
+// schemaValidation returns the JSON Schema check settings of --app-config,
+// overridden by the --schema and --strict-schema flags when given.
+func (o *validationOptions) schemaValidation(flags *schemaValidationOptions) (config.SchemaValidation, error) {
+	cfg, err := o.load()
+	if err != nil {
+		return config.SchemaValidation{}, err
+	}
+	schema := cfg.Validation.Schema
+	flags.override(&schema)
+	return schema, nil
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationOptions_SchemaValidation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`validation:
+  schema:
+    enabled: true
+    schema_path: contract.json
+    strict_schema: true
+`), 0o600))
+	live := validationOptions{appConfig: path}
+
+	// The application configuration alone enables the check
+	cfg, err := live.schemaValidation(&schemaValidationOptions{})
+	require.NoError(t, err)
+	assert.True(t, cfg.Enabled)
+	assert.Equal(t, "contract.json", cfg.SchemaPath)
+	assert.True(t, cfg.StrictSchema)
+
+	// --schema takes precedence
+	cfg, err = live.schemaValidation(&schemaValidationOptions{path: "other.json"})
+	require.NoError(t, err)
+	assert.Equal(t, "other.json", cfg.SchemaPath)
+
+	// Without either, the check stays off
+	cfg, err = (&validationOptions{}).schemaValidation(&schemaValidationOptions{})
+	require.NoError(t, err)
+	assert.False(t, cfg.Enabled)
+}
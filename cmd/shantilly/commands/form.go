@@ -3,39 +3,113 @@ package commands
 import (
 	"fmt"
 	"log"
-	"os"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/helton/shantilly/internal/config"
 	"github.com/helton/shantilly/internal/models"
+	"github.com/helton/shantilly/internal/schema"
 	"github.com/helton/shantilly/internal/styles"
 	"github.com/spf13/cobra"
 )
 
 var formCmd = &cobra.Command{
-	Use:   "form [config.yaml]",
+	Use:   "form [config.yaml | --from-schema schema.json]",
 	Short: "Executa uma TUI de formulário interativo",
 	Long: `Carrega um arquivo de configuração YAML e executa uma TUI de formulário
-interativo. O resultado é serializado em JSON.`,
-	Args: cobra.ExactArgs(1),
+interativo. O resultado é serializado em JSON, ou no formato escolhido com
+--format (json, yaml, xml, csv, env, shell).
+
+Com --schema, os valores submetidos precisam atender ao JSON Schema indicado;
+violações são exibidas nos campos e impedem a submissão.
+
+Com --from-schema, o formulário é gerado a partir de um JSON Schema, sem
+arquivo YAML, e o resultado segue a estrutura do schema.`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runForm,
 }
 
+var (
+	formOutput  outputOptions
+	formValues  valueOptions
+	formTimeout timeoutOptions
+	formDisplay displayOptions
+	formSchema  schemaValidationOptions
+	formLive    validationOptions
+
+	formFromSchema string
+)
+
+func init() {
+	formOutput.addFlags(formCmd)
+	formValues.addFlags(formCmd)
+	formDisplay.addFlags(formCmd)
+	formTimeout.addFlags(formCmd)
+	formSchema.addFlags(formCmd)
+	formLive.addFlags(formCmd)
+	formCmd.Flags().StringVar(&formFromSchema, "from-schema", "", "gera o formulário a partir de um JSON Schema")
+}
+
+// shapedForm presents the values of a form generated with --from-schema in
+// the structure of its JSON Schema. Without a generated form, the values are
+// returned as is.
+type shapedForm struct {
+	*models.FormModel
+	form *schema.Form
+}
+
+// ToMap returns the values shaped as the schema document.
+func (s shapedForm) ToMap() map[string]interface{} {
+	values := s.FormModel.ToMap()
+	if s.form == nil {
+		return values
+	}
+	return s.form.Shape(values)
+}
+
+// loadFormConfig loads the form from the YAML file in args or generates it
+// from --from-schema. The generated form is returned to shape the result.
+func loadFormConfig(args []string) (*config.FormConfig, *schema.Form, error) {
+	switch {
+	case formFromSchema != "" && len(args) > 0:
+		return nil, nil, fmt.Errorf("use um arquivo de configuração ou --from-schema, não ambos")
+	case formFromSchema != "":
+		generated, err := schema.FormFromFile(formFromSchema)
+		if err != nil {
+			return nil, nil, err
+		}
+		return generated.Config, generated, nil
+	case len(args) == 0:
+		return nil, nil, fmt.Errorf("informe o arquivo de configuração ou --from-schema")
+	}
+
+	cfg, err := config.LoadFormConfig(args[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+	return cfg, nil, nil
+}
+
 func runForm(cmd *cobra.Command, args []string) error {
 	start := time.Now()
-	log.Printf("[DEBUG] Iniciando execução do comando form - arquivo: %s", args[0])
+	log.Printf("[DEBUG] Iniciando execução do comando form - argumentos: %v", args)
 
-	configPath := args[0]
+	if err := formOutput.validate(); err != nil {
+		return err
+	}
+	if err := formValues.load(); err != nil {
+		return err
+	}
 
 	// Load configuration with explicit error handling
-	log.Printf("[DEBUG] Carregando configuração do arquivo: %s", configPath)
-	cfg, err := config.LoadFormConfig(configPath)
+	log.Printf("[DEBUG] Carregando configuração do formulário")
+	cfg, generated, err := loadFormConfig(args)
 	if err != nil {
 		log.Printf("[ERROR] Falha ao carregar configuração após %v: %v", time.Since(start), err)
-		return fmt.Errorf("erro ao carregar configuração: %w", err)
+		return err
 	}
 	log.Printf("[DEBUG] Configuração carregada com sucesso em %v", time.Since(start))
+	formTimeout.override(&cfg.Timeout, &cfg.OnTimeout)
 
 	// Create theme
 	log.Printf("[DEBUG] Criando tema padrão")
@@ -51,29 +125,51 @@ func runForm(cmd *cobra.Command, args []string) error {
 	}
 	log.Printf("[DEBUG] Modelo criado com sucesso em %v", time.Since(start))
 
-	// Create and run tea program
-	log.Printf("[DEBUG] Criando programa tea.NewProgram")
+	// Contract of the submitted values, checked before the form exits
+	schemaValidation, err := formLive.schemaValidation(&formSchema)
+	if err != nil {
+		return err
+	}
+	if err := model.SetSchemaValidation(schemaValidation); err != nil {
+		return err
+	}
+	if generated != nil {
+		model.SetSchemaDocument(generated.Shape)
+	}
 
-	// Configure program options based on environment
-	var opts []tea.ProgramOption
-	opts = append(opts, tea.WithAltScreen())
+	// Real-time validation settings of the application configuration
+	componentValidation, err := formLive.componentValidation()
+	if err != nil {
+		return err
+	}
+	model.SetComponentValidation(componentValidation)
+
+	// Business rule the submitted values must satisfy
+	businessValidation, err := formLive.businessValidation()
+	if err != nil {
+		return err
+	}
+	if err := model.SetBusinessValidation(businessValidation); err != nil {
+		return err
+	}
 
-	// Check if we're in a non-TTY environment (CI, tests, etc.)
-	// Use environment variables commonly set in CI environments
-	isCI := os.Getenv("CI") != "" || os.Getenv("GITHUB_ACTIONS") != "" ||
-		os.Getenv("GITLAB_CI") != "" || os.Getenv("TRAVIS") != "" ||
-		os.Getenv("CIRCLECI") != "" || os.Getenv("JENKINS_URL") != ""
+	// Preset values from --values, the environment and --set
+	if err := formValues.apply(model); err != nil {
+		return err
+	}
+	if !formValues.interactive() {
+		return runHeadless(cmd, shapedForm{FormModel: model, form: generated}, &formOutput)
+	}
 
-	// Also check for common test environment indicators
-	isTestEnv := os.Getenv("GO_TEST_ENVIRONMENT") != "" ||
-		os.Getenv("SHANTILLY_TEST") != "" ||
-		len(os.Args) > 1 && (os.Args[1] == "-test.v" || os.Args[1] == "test")
+	// Create and run tea program
+	log.Printf("[DEBUG] Criando programa tea.NewProgram")
 
-	if isCI || isTestEnv {
-		log.Printf("[DEBUG] Ambiente CI/teste detectado, configurando window size padrão")
-		opts = append(opts, tea.WithWindowSize(80, 24))
-		log.Printf("[DEBUG] Window size definido para 80x24 para ambiente: CI=%s, Test=%s", isCI, isTestEnv)
+	// Configure program options based on environment
+	opts, closeTTY, err := programOptions(formDisplay.inlineFor(cfg.Display))
+	if err != nil {
+		return err
 	}
+	defer closeTTY()
 
 	p := tea.NewProgram(model, opts...)
 	log.Printf("[DEBUG] Programa criado em %v, iniciando execução", time.Since(start))
@@ -95,24 +191,11 @@ func runForm(cmd *cobra.Command, args []string) error {
 
 	log.Printf("[DEBUG] Modelo verificado, status de submissão: %v (tempo: %v)", formModel.Submitted(), time.Since(start))
 
-	if formModel.Submitted() {
-		// Serialize to JSON
-		log.Printf("[DEBUG] Serializando dados para JSON")
-		jsonData, err := formModel.ToJSON()
-		if err != nil {
-			log.Printf("[ERROR] Falha na serialização após %v: %v", time.Since(start), err)
-			return fmt.Errorf("erro ao serializar dados: %w", err)
-		}
-		log.Printf("[DEBUG] Dados serializados em %v", time.Since(start))
-
-		// Write to stdout
-		log.Printf("[DEBUG] Escrevendo saída JSON no stdout")
-		if _, err := fmt.Fprintln(os.Stdout, string(jsonData)); err != nil {
-			log.Printf("[ERROR] Falha na escrita do stdout após %v: %v", time.Since(start), err)
-			return fmt.Errorf("erro ao escrever saída JSON no stdout: %w", err)
-		}
-		log.Printf("[DEBUG] Comando form concluído com sucesso em %v", time.Since(start))
+	if err := finishRun(cmd, &formOutput, shapedForm{FormModel: formModel, form: generated}); err != nil {
+		log.Printf("[DEBUG] Comando form finalizado sem resultado após %v: %v", time.Since(start), err)
+		return err
 	}
+	log.Printf("[DEBUG] Comando form concluído com sucesso em %v", time.Since(start))
 
 	return nil
 }
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/helton/shantilly/internal/components"
+	"github.com/helton/shantilly/internal/errors"
+	"github.com/spf13/cobra"
+)
+
+// headlessModel is implemented by models that can be validated and
+// serialized without drawing a TUI.
+type headlessModel interface {
+	Validate() []components.ValidationError
+	ToMap() map[string]interface{}
+}
+
+// fieldError is one entry of the validation report printed in non-interactive mode.
+type fieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// validationFailedError is returned when non-interactive values don't pass validation.
+type validationFailedError struct {
+	Errors []fieldError `json:"errors"`
+}
+
+func (e *validationFailedError) Error() string {
+	return fmt.Sprintf("validação falhou: %d erro(s)", len(e.Errors))
+}
+
+// ExitCode implements errors.ExitCoder.
+func (e *validationFailedError) ExitCode() int {
+	return errors.ErrValidationFailed.ExitCode()
+}
+
+// hasTerminal reports whether a user can answer the TUI: stdin is a terminal
+// or the controlling terminal can be opened.
+func hasTerminal() bool {
+	if term.IsTerminal(os.Stdin.Fd()) {
+		return true
+	}
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		return false
+	}
+	tty.Close()
+	return true
+}
+
+// runHeadless validates model with the preset values and writes the result,
+// or prints the validation errors as JSON on stderr.
+func runHeadless(cmd *cobra.Command, model headlessModel, out *outputOptions) error {
+	log.Printf("[DEBUG] Executando em modo não interativo")
+
+	// Invalid answers are not a usage mistake
+	cmd.SilenceUsage = true
+
+	if errs := model.Validate(); len(errs) > 0 {
+		failure := &validationFailedError{Errors: make([]fieldError, len(errs))}
+		for i, e := range errs {
+			failure.Errors[i] = fieldError{Field: e.Field, Code: e.Code, Message: e.Message}
+		}
+
+		report, err := json.MarshalIndent(failure, "", "  ")
+		if err != nil {
+			return fmt.Errorf("erro ao serializar erros de validação: %w", err)
+		}
+		fmt.Fprintln(os.Stderr, string(report))
+
+		if err := out.writeStatus(statusInvalid, map[string]interface{}{"errors": failure.Errors}); err != nil {
+			return err
+		}
+		return failure
+	}
+
+	return out.write(model.ToMap())
+}
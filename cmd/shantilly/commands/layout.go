@@ -3,7 +3,6 @@ package commands
 import (
 	"fmt"
 	"log"
-	"os"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
@@ -17,15 +16,43 @@ var layoutCmd = &cobra.Command{
 	Use:   "layout [config.yaml]",
 	Short: "Executa uma TUI com layout estruturado",
 	Long: `Carrega um arquivo de configuração YAML e executa uma TUI com layout
-horizontal ou vertical.`,
+horizontal ou vertical. Ao submeter com Enter, os valores coletados são
+serializados em JSON, ou no formato escolhido com --format.
+
+Com --schema, os valores submetidos precisam atender ao JSON Schema indicado.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runLayout,
 }
 
+var (
+	layoutOutput  outputOptions
+	layoutValues  valueOptions
+	layoutTimeout timeoutOptions
+	layoutDisplay displayOptions
+	layoutSchema  schemaValidationOptions
+	layoutLive    validationOptions
+)
+
+func init() {
+	layoutOutput.addFlags(layoutCmd)
+	layoutValues.addFlags(layoutCmd)
+	layoutDisplay.addFlags(layoutCmd)
+	layoutTimeout.addFlags(layoutCmd)
+	layoutSchema.addFlags(layoutCmd)
+	layoutLive.addFlags(layoutCmd)
+}
+
 func runLayout(cmd *cobra.Command, args []string) error {
 	start := time.Now()
 	log.Printf("[DEBUG] Iniciando execução do comando layout - arquivo: %s", args[0])
 
+	if err := layoutOutput.validate(); err != nil {
+		return err
+	}
+	if err := layoutValues.load(); err != nil {
+		return err
+	}
+
 	configPath := args[0]
 
 	// Load configuration with explicit error handling
@@ -36,6 +63,7 @@ func runLayout(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("erro ao carregar configuração: %w", err)
 	}
 	log.Printf("[DEBUG] Configuração do layout carregada em %v", time.Since(start))
+	layoutTimeout.override(&cfg.Timeout, &cfg.OnTimeout)
 
 	// Create theme
 	log.Printf("[DEBUG] Criando tema padrão")
@@ -51,37 +79,61 @@ func runLayout(cmd *cobra.Command, args []string) error {
 	}
 	log.Printf("[DEBUG] Modelo do layout criado em %v", time.Since(start))
 
+	// Contract of the submitted values, checked before the layout exits
+	schemaValidation, err := layoutLive.schemaValidation(&layoutSchema)
+	if err != nil {
+		return err
+	}
+	if err := model.SetSchemaValidation(schemaValidation); err != nil {
+		return err
+	}
+
+	// Real-time validation settings of the application configuration
+	componentValidation, err := layoutLive.componentValidation()
+	if err != nil {
+		return err
+	}
+	model.SetComponentValidation(componentValidation)
+
+	// Preset values from --values, the environment and --set
+	if err := layoutValues.apply(model); err != nil {
+		return err
+	}
+	if !layoutValues.interactive() {
+		return runHeadless(cmd, model, &layoutOutput)
+	}
+
 	// Create and run tea program
 	log.Printf("[DEBUG] Criando programa tea.NewProgram para layout")
 
 	// Configure program options based on environment
-	var opts []tea.ProgramOption
-	opts = append(opts, tea.WithAltScreen())
-
-	// Check if we're in a non-TTY environment (CI, tests, etc.)
-	// Use environment variables commonly set in CI environments
-	isCI := os.Getenv("CI") != "" || os.Getenv("GITHUB_ACTIONS") != "" ||
-		os.Getenv("GITLAB_CI") != "" || os.Getenv("TRAVIS") != "" ||
-		os.Getenv("CIRCLECI") != "" || os.Getenv("JENKINS_URL") != ""
-
-	// Also check for common test environment indicators
-	isTestEnv := os.Getenv("GO_TEST_ENVIRONMENT") != "" ||
-		os.Getenv("SHANTILLY_TEST") != "" ||
-		len(os.Args) > 1 && (os.Args[1] == "-test.v" || os.Args[1] == "test")
-
-	if isCI || isTestEnv {
-		log.Printf("[DEBUG] Ambiente CI/teste detectado, configurando window size padrão")
-		opts = append(opts, tea.WithWindowSize(80, 24))
-		log.Printf("[DEBUG] Window size definido para 80x24 para ambiente: CI=%s, Test=%s", isCI, isTestEnv)
+	opts, closeTTY, err := programOptions(layoutDisplay.inlineFor(cfg.Display))
+	if err != nil {
+		return err
 	}
+	defer closeTTY()
 
 	p := tea.NewProgram(model, opts...)
 	log.Printf("[DEBUG] Programa criado em %v, iniciando execução", time.Since(start))
 
-	if _, err := p.Run(); err != nil {
+	finalModel, err := p.Run()
+	if err != nil {
 		log.Printf("[ERROR] Falha na execução da TUI de layout após %v: %v", time.Since(start), err)
 		return fmt.Errorf("erro ao executar TUI de layout: %w", err)
 	}
+
+	layoutModel, ok := finalModel.(*models.LayoutModel)
+	if !ok {
+		log.Printf("[ERROR] Tipo de modelo inválido após %v", time.Since(start))
+		return fmt.Errorf("erro interno: tipo de modelo inválido")
+	}
+
+	log.Printf("[DEBUG] Modelo verificado, status de submissão: %v (tempo: %v)", layoutModel.Submitted(), time.Since(start))
+
+	if err := finishRun(cmd, &layoutOutput, layoutModel); err != nil {
+		log.Printf("[DEBUG] Comando layout finalizado sem resultado após %v: %v", time.Since(start), err)
+		return err
+	}
 	log.Printf("[DEBUG] Comando layout concluído com sucesso em %v", time.Since(start))
 
 	return nil
@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/models"
+	"github.com/helton/shantilly/internal/styles"
+	"github.com/spf13/cobra"
+)
+
+var menuCmd = &cobra.Command{
+	Use:   "menu [config.yaml]",
+	Short: "Executa uma TUI de menu de seleção",
+	Long: `Carrega um arquivo de configuração YAML e executa uma TUI de menu com
+filtro por busca aproximada e paginação. Com multi_select habilitado,
+vários itens podem ser marcados. O resultado é serializado em JSON, ou no
+formato escolhido com --format.
+
+Linhas recebidas pelo stdin são adicionadas como itens, e o arquivo de
+configuração passa a ser opcional. O stdin é lido até o fim antes de a TUI
+abrir, então o comando que o alimenta precisa terminar:
+
+  git branch --format='%(refname:short)' | shantilly menu`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMenu,
+}
+
+var (
+	menuOutput  outputOptions
+	menuDisplay displayOptions
+)
+
+func init() {
+	menuOutput.addFlags(menuCmd)
+	menuDisplay.addFlags(menuCmd)
+}
+
+func runMenu(cmd *cobra.Command, args []string) error {
+	start := time.Now()
+
+	var configPath string
+	if len(args) > 0 {
+		configPath = args[0]
+	}
+	log.Printf("[DEBUG] Iniciando execução do comando menu - arquivo: %s", configPath)
+
+	if err := menuOutput.validate(); err != nil {
+		return err
+	}
+
+	// Piped lines become items; the keyboard still comes from /dev/tty
+	items, err := readStdinLines()
+	if err != nil {
+		return err
+	}
+	if configPath == "" && len(items) == 0 {
+		return fmt.Errorf("informe um arquivo de configuração ou envie os itens pelo stdin")
+	}
+
+	// Load configuration with explicit error handling
+	log.Printf("[DEBUG] Carregando configuração do menu: %s (%d item(ns) do stdin)", configPath, len(items))
+	cfg, err := config.LoadMenuConfigWithItems(configPath, items)
+	if err != nil {
+		log.Printf("[ERROR] Falha ao carregar configuração do menu após %v: %v", time.Since(start), err)
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+	log.Printf("[DEBUG] Configuração do menu carregada em %v", time.Since(start))
+
+	// Create theme
+	theme := styles.DefaultTheme()
+
+	// Create menu model
+	log.Printf("[DEBUG] Criando modelo do menu")
+	model, err := models.NewMenuModel(cfg, theme)
+	if err != nil {
+		log.Printf("[ERROR] Falha ao criar modelo do menu após %v: %v", time.Since(start), err)
+		return fmt.Errorf("erro ao criar modelo do menu: %w", err)
+	}
+
+	// Configure program options based on environment
+	opts, closeTTY, err := programOptions(menuDisplay.inlineFor(cfg.Display))
+	if err != nil {
+		return err
+	}
+	defer closeTTY()
+
+	p := tea.NewProgram(model, opts...)
+	log.Printf("[DEBUG] Programa criado em %v, iniciando execução", time.Since(start))
+
+	finalModel, err := p.Run()
+	if err != nil {
+		log.Printf("[ERROR] Falha na execução da TUI de menu após %v: %v", time.Since(start), err)
+		return fmt.Errorf("erro ao executar TUI de menu: %w", err)
+	}
+
+	menuModel, ok := finalModel.(*models.MenuModel)
+	if !ok {
+		log.Printf("[ERROR] Tipo de modelo inválido após %v", time.Since(start))
+		return fmt.Errorf("erro interno: tipo de modelo inválido")
+	}
+
+	log.Printf("[DEBUG] Modelo verificado, status de submissão: %v (tempo: %v)", menuModel.Submitted(), time.Since(start))
+
+	if err := finishRun(cmd, &menuOutput, menuModel); err != nil {
+		log.Printf("[DEBUG] Comando menu finalizado sem resultado após %v: %v", time.Since(start), err)
+		return err
+	}
+	log.Printf("[DEBUG] Comando menu concluído com sucesso em %v", time.Since(start))
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/helton/shantilly/internal/components"
+	"github.com/helton/shantilly/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// outputOptions holds the --format, --template, --output and --print-status
+// flags shared by runner commands.
+type outputOptions struct {
+	format         string
+	path           string
+	templatePath   string
+	templateString string
+	printStatus    bool
+
+	// raw makes formatRaw the default, for one-shot commands with a single value
+	raw bool
+
+	tmpl   *template.Template // Parsed by validate when a template was given
+	stdout io.Writer          // Nil for os.Stdout
+}
+
+// formatRaw prints the single value of a one-shot command as plain text.
+const formatRaw = "raw"
+
+// addFlags registers the output flags on cmd.
+func (o *outputOptions) addFlags(cmd *cobra.Command) {
+	var names []string
+	defaultFormat := string(components.FormatJSON)
+	if o.raw {
+		names = append(names, formatRaw)
+		defaultFormat = formatRaw
+	}
+	for _, f := range output.Formats {
+		names = append(names, string(f))
+	}
+
+	cmd.Flags().StringVarP(&o.format, "format", "f", defaultFormat,
+		"formato do resultado: "+strings.Join(names, ", "))
+	cmd.Flags().StringVarP(&o.path, "output", "o", "", "grava o resultado no arquivo em vez do stdout")
+	cmd.Flags().StringVar(&o.templatePath, "template", "", "renderiza o resultado com um arquivo text/template")
+	cmd.Flags().StringVar(&o.templateString, "template-string", "", "renderiza o resultado com um template informado na linha de comando")
+	cmd.Flags().BoolVar(&o.printStatus, "print-status", false,
+		`imprime {"status":"..."} em JSON quando não há resultado (cancelado, inválido, tempo esgotado)`)
+	cmd.MarkFlagsMutuallyExclusive("format", "template", "template-string")
+}
+
+// validate checks the flags before the TUI starts, so a typo doesn't cost a filled form.
+func (o *outputOptions) validate() error {
+	var err error
+	switch {
+	case o.templatePath != "":
+		o.tmpl, err = output.LoadTemplate(o.templatePath)
+	case o.templateString != "":
+		o.tmpl, err = output.ParseTemplate("template-string", o.templateString)
+	case o.raw && o.format == formatRaw:
+		// Nothing to parse
+	default:
+		_, err = output.ParseFormat(o.format)
+	}
+	return err
+}
+
+// write encodes data with the template or in the selected format and sends
+// it to stdout or --output. Template output is written verbatim.
+func (o *outputOptions) write(data map[string]interface{}) error {
+	encoded, err := o.encode(data)
+	if err != nil {
+		return err
+	}
+	return o.writeBytes(encoded)
+}
+
+// writeStatus prints {"status": status, ...fields} as JSON when --print-status
+// is set. The status always goes to stdout: --output only ever holds results.
+func (o *outputOptions) writeStatus(status string, fields map[string]interface{}) error {
+	if !o.printStatus {
+		return nil
+	}
+
+	report := map[string]interface{}{"status": status}
+	for k, v := range fields {
+		report[k] = v
+	}
+
+	encoded, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar status: %w", err)
+	}
+	if _, err := o.out().Write(append(encoded, '\n')); err != nil {
+		return fmt.Errorf("erro ao escrever status no stdout: %w", err)
+	}
+	return nil
+}
+
+// writeBytes sends encoded output to --output or stdout.
+func (o *outputOptions) writeBytes(encoded []byte) error {
+	if o.path != "" {
+		// Results may hold secrets, keep them private to the user
+		if err := os.WriteFile(o.path, encoded, 0o600); err != nil {
+			return fmt.Errorf("erro ao gravar resultado em %s: %w", o.path, err)
+		}
+		log.Printf("[DEBUG] Resultado gravado em %s", o.path)
+		return nil
+	}
+
+	if _, err := o.out().Write(encoded); err != nil {
+		return fmt.Errorf("erro ao escrever resultado no stdout: %w", err)
+	}
+	return nil
+}
+
+// out returns the writer standing for stdout.
+func (o *outputOptions) out() io.Writer {
+	if o.stdout == nil {
+		return os.Stdout
+	}
+	return o.stdout
+}
+
+// encode renders data with the parsed template or the selected format.
+func (o *outputOptions) encode(data map[string]interface{}) ([]byte, error) {
+	if o.tmpl != nil {
+		return output.Render(o.tmpl, data)
+	}
+
+	// One-shot commands hold a single value
+	if o.raw && o.format == formatRaw {
+		for _, value := range data {
+			return output.EncodeRaw(value), nil
+		}
+		return nil, nil
+	}
+
+	format, err := output.ParseFormat(o.format)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := output.Encode(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao serializar dados: %w", err)
+	}
+	if len(encoded) > 0 && encoded[len(encoded)-1] != '\n' {
+		encoded = append(encoded, '\n')
+	}
+	return encoded, nil
+}
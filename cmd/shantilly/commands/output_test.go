@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeResult is a finished model with fixed values.
+type fakeResult struct {
+	submitted bool
+}
+
+func (r fakeResult) Submitted() bool { return r.submitted }
+
+func (r fakeResult) ToMap() map[string]interface{} {
+	return map[string]interface{}{"name": "ana"}
+}
+
+func TestFinishRun_PrintStatusWithOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "result.json")
+	var stdout bytes.Buffer
+	out := &outputOptions{format: "json", path: path, printStatus: true, stdout: &stdout}
+
+	// A cancel reports its status on stdout and leaves the results file alone
+	err := finishRun(&cobra.Command{}, out, fakeResult{})
+	require.Error(t, err)
+	assert.JSONEq(t, `{"status":"cancelled"}`, stdout.String())
+	assert.NoFileExists(t, path)
+
+	// A result goes to the file only
+	stdout.Reset()
+	require.NoError(t, finishRun(&cobra.Command{}, out, fakeResult{submitted: true}))
+	assert.Empty(t, stdout.String())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"ana"}`, string(data))
+}
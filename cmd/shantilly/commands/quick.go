@@ -0,0 +1,333 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/models"
+	"github.com/helton/shantilly/internal/styles"
+	"github.com/spf13/cobra"
+)
+
+// quickOptions holds the flags shared by the one-shot subcommands.
+type quickOptions struct {
+	title    string
+	label    string
+	required bool
+
+	output  outputOptions
+	display displayOptions
+}
+
+// newQuickOptions returns options whose output defaults to the raw value.
+func newQuickOptions() *quickOptions {
+	return &quickOptions{output: outputOptions{raw: true}}
+}
+
+// addFlags registers the shared flags on cmd.
+func (q *quickOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&q.title, "title", "", "título exibido acima da pergunta")
+	cmd.Flags().StringVar(&q.label, "label", "", "rótulo da pergunta")
+	cmd.Flags().BoolVar(&q.required, "required", false, "exige uma resposta para submeter")
+	q.output.addFlags(cmd)
+	q.display.addFlags(cmd)
+}
+
+// runQuick shows a form with the single component described by comp and
+// writes its value, raw by default.
+func runQuick(cmd *cobra.Command, q *quickOptions, comp config.ComponentConfig) error {
+	start := time.Now()
+	log.Printf("[DEBUG] Iniciando execução do comando %s", comp.Name)
+
+	if err := q.output.validate(); err != nil {
+		return err
+	}
+
+	if comp.Label == "" {
+		comp.Label = q.label
+	}
+	comp.Required = comp.Required || q.required
+
+	cfg := &config.FormConfig{
+		Title:      q.title,
+		Components: []config.ComponentConfig{comp},
+	}
+
+	model, err := models.NewFormModel(cfg, styles.DefaultTheme())
+	if err != nil {
+		return fmt.Errorf("erro ao criar modelo do formulário: %w", err)
+	}
+
+	opts, closeTTY, err := programOptions(q.display.inline)
+	if err != nil {
+		return err
+	}
+	defer closeTTY()
+
+	finalModel, err := tea.NewProgram(model, opts...).Run()
+	if err != nil {
+		log.Printf("[ERROR] Falha na execução da TUI após %v: %v", time.Since(start), err)
+		return fmt.Errorf("erro ao executar TUI: %w", err)
+	}
+
+	formModel, ok := finalModel.(*models.FormModel)
+	if !ok {
+		return fmt.Errorf("erro interno: tipo de modelo inválido")
+	}
+
+	return finishRun(cmd, &q.output, formModel)
+}
+
+var (
+	inputOptions = newQuickOptions()
+	inputFlags   struct {
+		placeholder string
+		value       string
+		pattern     string
+		minLength   int
+		maxLength   int
+		validate    []string
+		mask        string
+		raw         bool
+	}
+)
+
+var inputCmd = &cobra.Command{
+	Use:   "input",
+	Short: "Pergunta uma linha de texto",
+	Long: `Exibe um único campo de texto e imprime o valor digitado.
+
+  nome=$(shantilly input --label "Seu nome" --required)`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		comp := config.ComponentConfig{
+			Type:        config.TypeTextInput,
+			Name:        "input",
+			Placeholder: inputFlags.placeholder,
+			Options:     map[string]interface{}{},
+		}
+		if inputFlags.value != "" {
+			comp.Default = inputFlags.value
+		}
+		if inputFlags.pattern != "" {
+			comp.Options["pattern"] = inputFlags.pattern
+		}
+		if inputFlags.minLength > 0 {
+			comp.Options["min_length"] = inputFlags.minLength
+		}
+		if inputFlags.maxLength > 0 {
+			comp.Options["max_length"] = inputFlags.maxLength
+		}
+		if len(inputFlags.validate) > 0 {
+			comp.Options["validate"] = inputFlags.validate
+		}
+		if inputFlags.mask != "" {
+			comp.Options["mask"] = inputFlags.mask
+		}
+		if inputFlags.raw {
+			comp.Options["mask_output"] = config.MaskOutputRaw
+		}
+		return runQuick(cmd, inputOptions, comp)
+	},
+}
+
+var (
+	confirmOptions = newQuickOptions()
+	confirmDefault bool
+)
+
+var confirmCmd = &cobra.Command{
+	Use:   "confirm [pergunta]",
+	Short: "Pergunta sim ou não",
+	Long: `Exibe uma caixa de seleção com a pergunta e imprime true ou false.
+Espaço alterna a resposta e Enter confirma.
+
+  [ "$(shantilly confirm "Fazer deploy?")" = true ] && make deploy`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runQuick(cmd, confirmOptions, config.ComponentConfig{
+			Type:    config.TypeCheckbox,
+			Name:    "confirm",
+			Label:   args[0],
+			Default: confirmDefault,
+		})
+	},
+}
+
+var (
+	chooseOptions  = newQuickOptions()
+	chooseSelected string
+)
+
+var chooseCmd = &cobra.Command{
+	Use:   "choose [opção...]",
+	Short: "Escolhe uma opção de uma lista",
+	Long: `Exibe as opções como botões de rádio e imprime a escolhida.
+Espaço seleciona a opção destacada e Enter confirma.
+Sem argumentos, as opções são lidas do stdin, uma por linha, quando ele é
+um pipe ou arquivo; a TUI só abre depois que a entrada termina.
+
+  shantilly choose dev staging production
+  ls | shantilly choose`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		choices := args
+		if len(choices) == 0 {
+			lines, err := readStdinLines()
+			if err != nil {
+				return err
+			}
+			choices = lines
+		}
+		if len(choices) == 0 {
+			return fmt.Errorf("informe as opções como argumentos ou pelo stdin")
+		}
+
+		items := make([]interface{}, len(choices))
+		for i, choice := range choices {
+			items[i] = map[string]interface{}{"id": choice, "label": choice}
+		}
+
+		comp := config.ComponentConfig{
+			Type:     config.TypeRadioGroup,
+			Name:     "choose",
+			Required: true,
+			Options:  map[string]interface{}{"items": items},
+		}
+		if chooseSelected != "" {
+			comp.Default = chooseSelected
+		}
+		return runQuick(cmd, chooseOptions, comp)
+	},
+}
+
+var (
+	writeOptions = newQuickOptions()
+	writeFlags   struct {
+		placeholder string
+		value       string
+		validate    []string
+	}
+)
+
+var writeCmd = &cobra.Command{
+	Use:   "write",
+	Short: "Pede um texto de várias linhas",
+	Long: `Exibe uma área de texto e imprime o conteúdo digitado.
+Ctrl+J insere uma nova linha e Enter confirma.
+
+  shantilly write --label "Mensagem do commit" | git commit -F -`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		comp := config.ComponentConfig{
+			Type:        config.TypeTextArea,
+			Name:        "write",
+			Placeholder: writeFlags.placeholder,
+		}
+		if writeFlags.value != "" {
+			comp.Default = writeFlags.value
+		}
+		if len(writeFlags.validate) > 0 {
+			comp.Options = map[string]interface{}{"validate": writeFlags.validate}
+		}
+		return runQuick(cmd, writeOptions, comp)
+	},
+}
+
+var (
+	fileOptions = newQuickOptions()
+	fileFlags   struct {
+		filter     string
+		showHidden bool
+	}
+)
+
+var fileCmd = &cobra.Command{
+	Use:   "file [diretório]",
+	Short: "Seleciona um arquivo",
+	Long: `Exibe um seletor de arquivos a partir do diretório indicado (padrão:
+diretório atual) e imprime o caminho escolhido.
+
+  shantilly file --filter '*.go' ./cmd`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		comp := config.ComponentConfig{
+			Type:    config.TypeFilePicker,
+			Name:    "file",
+			Options: map[string]interface{}{"show_hidden": fileFlags.showHidden},
+		}
+		if len(args) > 0 {
+			comp.Default = args[0]
+		}
+		if fileFlags.filter != "" {
+			comp.Options["filter"] = fileFlags.filter
+		}
+		return runQuick(cmd, fileOptions, comp)
+	},
+}
+
+var (
+	sliderOptions = newQuickOptions()
+	sliderFlags   struct {
+		min, max, step, value float64
+	}
+)
+
+var sliderCmd = &cobra.Command{
+	Use:   "slider",
+	Short: "Escolhe um número em um intervalo",
+	Long: `Exibe um controle deslizante e imprime o valor escolhido.
+
+  shantilly slider --label "Réplicas" --min 1 --max 10 --value 3`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		comp := config.ComponentConfig{
+			Type: config.TypeSlider,
+			Name: "slider",
+			Options: map[string]interface{}{
+				"min":  sliderFlags.min,
+				"max":  sliderFlags.max,
+				"step": sliderFlags.step,
+			},
+		}
+		if cmd.Flags().Changed("value") {
+			comp.Default = sliderFlags.value
+		}
+		return runQuick(cmd, sliderOptions, comp)
+	},
+}
+
+func init() {
+	inputOptions.addFlags(inputCmd)
+	inputCmd.Flags().StringVar(&inputFlags.placeholder, "placeholder", "", "texto de exemplo exibido no campo vazio")
+	inputCmd.Flags().StringVar(&inputFlags.value, "value", "", "valor inicial")
+	inputCmd.Flags().StringVar(&inputFlags.pattern, "pattern", "", "expressão regular que o valor deve satisfazer")
+	inputCmd.Flags().IntVar(&inputFlags.minLength, "min-length", 0, "número mínimo de caracteres")
+	inputCmd.Flags().IntVar(&inputFlags.maxLength, "max-length", 0, "número máximo de caracteres")
+	inputCmd.Flags().StringSliceVar(&inputFlags.validate, "validate", nil, "validadores do valor, por exemplo email ou int:1..10")
+	inputCmd.Flags().StringVar(&inputFlags.mask, "mask", "", "máscara aplicada ao digitar, por exemplo ###.###.###-## ou cpf")
+	inputCmd.Flags().BoolVar(&inputFlags.raw, "raw", false, "imprime só os caracteres digitados, sem a máscara")
+
+	confirmOptions.addFlags(confirmCmd)
+	confirmCmd.Flags().BoolVar(&confirmDefault, "default", false, "resposta inicial")
+
+	chooseOptions.addFlags(chooseCmd)
+	chooseCmd.Flags().StringVar(&chooseSelected, "selected", "", "opção selecionada inicialmente")
+
+	writeOptions.addFlags(writeCmd)
+	writeCmd.Flags().StringVar(&writeFlags.placeholder, "placeholder", "", "texto de exemplo exibido na área vazia")
+	writeCmd.Flags().StringVar(&writeFlags.value, "value", "", "texto inicial")
+	writeCmd.Flags().StringSliceVar(&writeFlags.validate, "validate", nil, "validadores do texto, por exemplo json")
+
+	fileOptions.addFlags(fileCmd)
+	fileCmd.Flags().StringVar(&fileFlags.filter, "filter", "", "padrão dos arquivos exibidos (ex.: '*.go')")
+	fileCmd.Flags().BoolVar(&fileFlags.showHidden, "show-hidden", false, "exibe arquivos ocultos")
+
+	sliderOptions.addFlags(sliderCmd)
+	sliderCmd.Flags().Float64Var(&sliderFlags.min, "min", 0, "valor mínimo")
+	sliderCmd.Flags().Float64Var(&sliderFlags.max, "max", 100, "valor máximo")
+	sliderCmd.Flags().Float64Var(&sliderFlags.step, "step", 1, "incremento")
+	sliderCmd.Flags().Float64Var(&sliderFlags.value, "value", 0, "valor inicial")
+}
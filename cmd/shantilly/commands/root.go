@@ -2,12 +2,20 @@ package commands
 
 import (
 	"fmt"
+	"io"
+	"log"
+	"os"
 
 	"github.com/spf13/cobra"
 )
 
 const version = "0.1.0"
 
+// debugEnv enables the diagnostics of --debug from the environment.
+const debugEnv = "SHANTILLY_DEBUG"
+
+var debug bool
+
 var rootCmd = &cobra.Command{
 	Use:   "shantilly",
 	Short: "Construtor de TUI declarativo via YAML",
@@ -17,6 +25,11 @@ declarativa, utilizando arquivos de configuração YAML.
 
 Construído sobre o ecossistema Charm (Bubble Tea, Lip Gloss, Bubbles).`,
 	Version: version,
+
+	// main reports errors and picks the exit code, see errors.ExitCodeOf
+	SilenceErrors: true,
+
+	PersistentPreRun: setupLog,
 }
 
 var versionCmd = &cobra.Command{
@@ -28,10 +41,36 @@ var versionCmd = &cobra.Command{
 }
 
 func init() {
+	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "exibe mensagens de diagnóstico no stderr (o mesmo que "+debugEnv+"=1)")
+
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(formCmd)
 	rootCmd.AddCommand(layoutCmd)
-	// TODO: Add menu, tabs, serve commands
+	rootCmd.AddCommand(menuCmd)
+	rootCmd.AddCommand(tabsCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(schemaCmd)
+
+	// One-shot commands that need no YAML file
+	rootCmd.AddCommand(inputCmd)
+	rootCmd.AddCommand(confirmCmd)
+	rootCmd.AddCommand(chooseCmd)
+	rootCmd.AddCommand(writeCmd)
+	rootCmd.AddCommand(fileCmd)
+	rootCmd.AddCommand(sliderCmd)
+}
+
+// setupLog discards the [DEBUG] and [ERROR] diagnostics of the log package
+// unless --debug or SHANTILLY_DEBUG is set, so they neither mix with the
+// TUI nor clutter the stderr of scripts; errors still reach the user through
+// the error returned by the command.
+func setupLog(cmd *cobra.Command, args []string) {
+	if debug || os.Getenv(debugEnv) != "" {
+		log.SetOutput(os.Stderr)
+		return
+	}
+	log.SetOutput(io.Discard)
 }
 
 // Execute runs the root command.
@@ -0,0 +1,22 @@
+package commands
+
+import (
+	"io"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetupLog(t *testing.T) {
+	defer log.SetOutput(log.Writer())
+	t.Setenv(debugEnv, "")
+
+	setupLog(rootCmd, nil)
+	assert.Equal(t, io.Discard, log.Writer(), "quiet by default")
+
+	t.Setenv(debugEnv, "1")
+	setupLog(rootCmd, nil)
+	assert.Equal(t, os.Stderr, log.Writer())
+}
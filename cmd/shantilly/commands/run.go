@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/term"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// displayOptions holds the --inline flag shared by runner commands.
+type displayOptions struct {
+	inline bool
+}
+
+// addFlags registers the display flags on cmd.
+func (d *displayOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&d.inline, "inline", false,
+		"desenha abaixo do prompt em vez da tela alternativa, deixando um resumo no histórico")
+}
+
+// inlineFor reports whether to render inline, by flag or by the display: key.
+func (d *displayOptions) inlineFor(display string) bool {
+	return d.inline || display == config.DisplayInline
+}
+
+// programOptions returns the tea.ProgramOption set shared by every runner
+// command and a function that releases the terminal opened for it.
+// Inline programs skip the alternate screen, so their last view (the
+// summary of a submit) stays in the scrollback.
+//
+// When stdin or stdout is redirected, as in x=$(shantilly form f.yaml) or
+// shantilly menu m.yaml | jq, the TUI is drawn on /dev/tty instead so that
+// stdout carries only the result and stdin stays free for piped data.
+func programOptions(inline bool) ([]tea.ProgramOption, func(), error) {
+	var opts []tea.ProgramOption
+	if !inline {
+		opts = append(opts, tea.WithAltScreen())
+	}
+
+	closeTTY := func() {}
+	if !term.IsTerminal(os.Stdin.Fd()) || !term.IsTerminal(os.Stdout.Fd()) {
+		tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+		if err != nil {
+			return nil, nil, fmt.Errorf("erro ao abrir o terminal: %w", err)
+		}
+		log.Printf("[DEBUG] stdin/stdout redirecionado, usando /dev/tty para a TUI")
+		opts = append(opts, tea.WithInput(tty), tea.WithOutput(tty))
+		closeTTY = func() { tty.Close() }
+	}
+
+	// Check if we're in a non-TTY environment (CI, tests, etc.)
+	// Use environment variables commonly set in CI environments
+	isCI := os.Getenv("CI") != "" || os.Getenv("GITHUB_ACTIONS") != "" ||
+		os.Getenv("GITLAB_CI") != "" || os.Getenv("TRAVIS") != "" ||
+		os.Getenv("CIRCLECI") != "" || os.Getenv("JENKINS_URL") != ""
+
+	// Also check for common test environment indicators
+	isTestEnv := os.Getenv("GO_TEST_ENVIRONMENT") != "" ||
+		os.Getenv("SHANTILLY_TEST") != "" ||
+		len(os.Args) > 1 && (os.Args[1] == "-test.v" || os.Args[1] == "test")
+
+	if isCI || isTestEnv {
+		log.Printf("[DEBUG] Ambiente CI/teste detectado, configurando window size padrão")
+		opts = append(opts, tea.WithWindowSize(80, 24))
+		log.Printf("[DEBUG] Window size definido para 80x24 para ambiente: CI=%v, Test=%v", isCI, isTestEnv)
+	}
+
+	return opts, closeTTY, nil
+}
+
+// readStdinLines returns the non-empty lines piped or redirected on stdin.
+// It returns nil when stdin is a terminal or a device such as /dev/null,
+// so it never blocks waiting for the keyboard. Pipes are read to the end
+// before the TUI starts: a producer that never closes its output, such as
+// tail -f, keeps the command waiting.
+func readStdinLines() ([]string, error) {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return nil, nil
+	}
+	if info.Mode()&os.ModeNamedPipe == 0 && !info.Mode().IsRegular() {
+		return nil, nil
+	}
+	log.Printf("[DEBUG] Lendo linhas do stdin até o fim da entrada")
+
+	var lines []string
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao ler o stdin: %w", err)
+	}
+	return lines, nil
+}
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/schema"
+	"github.com/spf13/cobra"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema [form|layout|tabs|menu|app]",
+	Short: "Emite o JSON Schema dos arquivos de configuração",
+	Long: `Emite o JSON Schema (draft 2020-12) do tipo de configuração escolhido
+(padrão: form), incluindo os componentes e as opções aceitas por cada tipo.
+Editores com yaml-language-server podem usá-lo para autocompletar e validar
+arquivos do Shantilly:
+
+  shantilly schema form > form.schema.json
+  # yaml-language-server: $schema=./form.schema.json`,
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: schema.Kinds(),
+	RunE:      runSchema,
+}
+
+func runSchema(cmd *cobra.Command, args []string) error {
+	kind := config.KindForm
+	if len(args) > 0 {
+		kind = args[0]
+	}
+	log.Printf("[DEBUG] Gerando JSON Schema para: %s", kind)
+
+	s, err := schema.Generate(kind)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao serializar schema: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	return nil
+}
+
+// schemaValidationOptions holds the flags that enable the JSON Schema check
+// of the submitted values.
+type schemaValidationOptions struct {
+	path   string
+	strict bool
+}
+
+// addFlags registers --schema and --strict-schema on cmd.
+func (o *schemaValidationOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.path, "schema", "", "JSON Schema que os valores submetidos devem atender, substitui validation.schema da configuração da aplicação")
+	cmd.Flags().BoolVar(&o.strict, "strict-schema", false, "rejeita campos não declarados e palavras-chave não suportadas no schema")
+}
+
+// override replaces the settings of validation.schema with the flags that
+// were given: --schema enables the check with its schema.
+func (o *schemaValidationOptions) override(cfg *config.SchemaValidation) {
+	if o.path != "" {
+		cfg.Enabled = true
+		cfg.SchemaPath = o.path
+	}
+	if o.strict {
+		cfg.StrictSchema = true
+	}
+}
@@ -0,0 +1,336 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/colorprofile"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/errors"
+	"github.com/helton/shantilly/internal/models"
+	"github.com/helton/shantilly/internal/server"
+	"github.com/helton/shantilly/internal/styles"
+	"github.com/spf13/cobra"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve uma TUI via SSH",
+	Long: `Inicia um servidor SSH que executa a TUI descrita em --config para cada
+sessão, com programa e perfil de cores próprios. O JSON submetido em cada
+sessão é gravado em um arquivo por sessão (--output-dir) ou enviado ao stdin
+de um comando local (--hook).
+
+O servidor escuta apenas em 127.0.0.1 por padrão e exige --authorized-keys;
+para aceitar qualquer cliente sem autenticação, informe --no-auth.
+
+Configurações com validate_command são recusadas: cada cliente SSH poderia
+executar o validador local com um valor escolhido por ele, inclusive valores
+iniciados por "-" lidos como opções do programa. Use --allow-validate-command
+apenas com validadores que tratam qualquer argumento com segurança.
+
+Exemplo:
+  shantilly serve --config form.yaml --authorized-keys ~/.ssh/authorized_keys --output-dir respostas/
+  ssh -p 2222 localhost`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+var (
+	serveListen         string
+	serveConfigPath     string
+	serveType           string
+	serveHostKey        string
+	serveAuthorizedKeys string
+	serveNoAuth         bool
+	serveOutputDir      string
+	serveHook           string
+	serveAppConfig      string
+	serveAllowCommands  bool
+)
+
+func init() {
+	serveCmd.Flags().StringVar(&serveListen, "listen", "127.0.0.1:2222", "endereço de escuta do servidor SSH")
+	serveCmd.Flags().StringVar(&serveConfigPath, "config", "", "arquivo YAML da TUI servida (obrigatório)")
+	serveCmd.Flags().StringVar(&serveType, "type", "form", "tipo de configuração: form, layout, tabs ou menu")
+	serveCmd.Flags().StringVar(&serveHostKey, "host-key", ".shantilly/ssh_host_ed25519", "chave do host SSH (gerada se não existir)")
+	serveCmd.Flags().StringVar(&serveAuthorizedKeys, "authorized-keys", "", "arquivo authorized_keys com as chaves permitidas")
+	serveCmd.Flags().BoolVar(&serveNoAuth, "no-auth", false, "aceita qualquer cliente SSH sem autenticação")
+	serveCmd.Flags().StringVar(&serveOutputDir, "output-dir", "", "diretório onde gravar o JSON de cada sessão")
+	serveCmd.Flags().StringVar(&serveHook, "hook", "", "comando local que recebe o JSON de cada sessão no stdin")
+	serveCmd.Flags().BoolVar(&serveAllowCommands, "allow-validate-command", false, "permite validate_command, executado localmente com valores enviados pelos clientes")
+	serveCmd.Flags().StringVar(&serveAppConfig, "app-config", "", "configuração da aplicação com as opções de segurança (rate_limit)")
+	_ = serveCmd.MarkFlagRequired("config")
+	serveCmd.MarkFlagsMutuallyExclusive("authorized-keys", "no-auth")
+}
+
+// sessionModel is the subset of the orchestration models served over SSH.
+type sessionModel interface {
+	tea.Model
+	Submitted() bool
+	ToJSON() ([]byte, error)
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	// The log of the server is its output: sessions are always reported
+	log.SetOutput(os.Stderr)
+	log.Printf("[DEBUG] Iniciando execução do comando serve - config: %s", serveConfigPath)
+
+	if serveOutputDir == "" && serveHook == "" {
+		return fmt.Errorf("informe --output-dir ou --hook para receber as respostas das sessões")
+	}
+	if serveAuthorizedKeys == "" && !serveNoAuth {
+		return fmt.Errorf("informe --authorized-keys, ou --no-auth para aceitar clientes sem autenticação")
+	}
+
+	// Build one model up front so configuration errors surface before listening
+	newModel, err := sessionModelFactory(serveType, serveConfigPath)
+	if err != nil {
+		return err
+	}
+	if _, err := newModel(); err != nil {
+		return fmt.Errorf("erro ao criar modelo: %w", err)
+	}
+
+	// Read like form does, so explicit zero values such as rate_limit: 0 apply
+	appCfg, err := (&validationOptions{appConfig: serveAppConfig}).load()
+	if err != nil {
+		return err
+	}
+	security := appCfg.Security
+
+	if serveOutputDir != "" {
+		if err := os.MkdirAll(serveOutputDir, 0o700); err != nil {
+			return fmt.Errorf("erro ao criar diretório de saída: %w", err)
+		}
+	}
+
+	hostKey, err := server.LoadOrCreateHostKey(serveHostKey)
+	if err != nil {
+		return err
+	}
+
+	var authorized *server.AuthorizedKeys
+	if serveAuthorizedKeys != "" {
+		authorized, err = server.LoadAuthorizedKeys(serveAuthorizedKeys)
+		if err != nil {
+			return err
+		}
+		log.Printf("[INFO] %d chave(s) autorizada(s) carregada(s) de %s", authorized.Len(), serveAuthorizedKeys)
+	} else {
+		log.Printf("[WARN] --no-auth informado: qualquer cliente SSH poderá se conectar")
+	}
+
+	srv, err := server.New(server.Options{
+		HostKey:        hostKey,
+		AuthorizedKeys: authorized,
+		NoClientAuth:   serveNoAuth,
+		RateLimit:      security.RateLimit,
+		Handler:        sessionHandler(newModel),
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao criar servidor SSH: %w", err)
+	}
+
+	ln, err := net.Listen("tcp", serveListen)
+	if err != nil {
+		return fmt.Errorf("erro ao escutar em %s: %w", serveListen, err)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("[INFO] Servidor SSH escutando em %s (limite: %d conexões/min por host)", ln.Addr(), security.RateLimit)
+	if err := srv.Serve(ctx, ln); err != nil {
+		return fmt.Errorf("erro no servidor SSH: %w", err)
+	}
+	log.Printf("[INFO] Servidor SSH encerrado")
+	return nil
+}
+
+// sessionModelFactory loads the configuration once and returns a constructor
+// that builds a fresh model for every session.
+func sessionModelFactory(kind, path string) (func() (sessionModel, error), error) {
+	theme := styles.DefaultTheme()
+
+	switch kind {
+	case "form":
+		cfg, err := config.LoadFormConfig(path)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao carregar configuração: %w", err)
+		}
+		if err := checkValidateCommands(cfg.Components); err != nil {
+			return nil, err
+		}
+		return func() (sessionModel, error) { return models.NewFormModel(cfg, theme) }, nil
+
+	case "layout":
+		cfg, err := config.LoadLayoutConfig(path)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao carregar configuração: %w", err)
+		}
+		if err := checkValidateCommands(cfg.Components); err != nil {
+			return nil, err
+		}
+		return func() (sessionModel, error) { return models.NewLayoutModel(cfg, theme) }, nil
+
+	case "tabs":
+		cfg, err := config.LoadTabsConfig(path)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao carregar configuração: %w", err)
+		}
+		for _, tab := range cfg.Tabs {
+			if err := checkValidateCommands(tab.Components); err != nil {
+				return nil, err
+			}
+		}
+		return func() (sessionModel, error) {
+			model, err := models.NewTabsModel(cfg, theme)
+			if err != nil {
+				return nil, err
+			}
+			model.SetStandalone(true)
+			return model, nil
+		}, nil
+
+	case "menu":
+		cfg, err := config.LoadMenuConfig(path)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao carregar configuração: %w", err)
+		}
+		return func() (sessionModel, error) { return models.NewMenuModel(cfg, theme) }, nil
+
+	default:
+		return nil, fmt.Errorf("tipo de configuração não suportado: %s (use form, layout, tabs ou menu)", kind)
+	}
+}
+
+// checkValidateCommands refuses components with validate_command unless
+// --allow-validate-command is set, since any SSH client could run the
+// validator with a value of its choosing.
+func checkValidateCommands(comps []config.ComponentConfig) error {
+	if serveAllowCommands {
+		return nil
+	}
+	commands, err := config.CollectValidateCommands(comps)
+	if err != nil {
+		return err
+	}
+	for _, comp := range comps {
+		if commands[comp.Name] != nil {
+			return fmt.Errorf("componente %s: validate_command executaria um programa local com valores dos clientes SSH; informe --allow-validate-command para permitir", comp.Name)
+		}
+	}
+	return nil
+}
+
+// sessionHandler runs a Bubble Tea program on each SSH session and delivers its result.
+func sessionHandler(newModel func() (sessionModel, error)) server.Handler {
+	return func(ctx context.Context, s *server.Session) int {
+		model, err := newModel()
+		if err != nil {
+			log.Printf("[ERROR] Sessão %s: falha ao criar modelo: %v", s.ID, err)
+			fmt.Fprintf(s, "Erro: %v\r\n", err)
+			return errors.ExitConfigError
+		}
+
+		// Some clients report a zero-sized PTY; fall back to a classic terminal
+		width, height := s.Window.Width, s.Window.Height
+		if width == 0 || height == 0 {
+			width, height = 80, 24
+		}
+
+		// Each session gets its own program and color profile, detected from
+		// the TERM and variables the client sent rather than the server's own
+		p := tea.NewProgram(model,
+			tea.WithContext(ctx),
+			tea.WithInput(s),
+			tea.WithOutput(s),
+			tea.WithEnvironment(s.Environ),
+			tea.WithColorProfile(colorprofile.Env(s.Environ)),
+			tea.WithWindowSize(width, height),
+			tea.WithAltScreen(),
+			tea.WithoutSignalHandler(),
+		)
+
+		resizeCtx, stopResize := context.WithCancel(ctx)
+		defer stopResize()
+		go func() {
+			for {
+				select {
+				case win := <-s.Resize():
+					p.Send(tea.WindowSizeMsg{Width: win.Width, Height: win.Height})
+				case <-resizeCtx.Done():
+					return
+				}
+			}
+		}()
+
+		finalModel, err := p.Run()
+		if err != nil {
+			log.Printf("[ERROR] Sessão %s: falha na execução da TUI: %v", s.ID, err)
+			return errors.ExitConfigError
+		}
+
+		result, ok := finalModel.(sessionModel)
+		if !ok || !result.Submitted() {
+			log.Printf("[INFO] Sessão %s encerrada sem submissão", s.ID)
+			return errors.ExitCancelled
+		}
+
+		jsonData, err := result.ToJSON()
+		if err != nil {
+			log.Printf("[ERROR] Sessão %s: falha na serialização: %v", s.ID, err)
+			return errors.ExitConfigError
+		}
+
+		if err := deliverSessionResult(s, jsonData); err != nil {
+			log.Printf("[ERROR] Sessão %s: %v", s.ID, err)
+			fmt.Fprint(s, "Não foi possível registrar sua resposta.\r\n")
+			return errors.ExitConfigError
+		}
+
+		log.Printf("[INFO] Sessão %s submetida por %s@%s", s.ID, s.User, s.RemoteAddr)
+		fmt.Fprint(s, "Resposta registrada. Obrigado!\r\n")
+		return errors.ExitSubmitted
+	}
+}
+
+// deliverSessionResult writes the session JSON to --output-dir and/or pipes it to --hook.
+func deliverSessionResult(s *server.Session, jsonData []byte) error {
+	if serveOutputDir != "" {
+		name := fmt.Sprintf("%s-%s.json", time.Now().Format("20060102-150405"), s.ID)
+		path := filepath.Join(serveOutputDir, name)
+		if err := os.WriteFile(path, append(jsonData, '\n'), 0o600); err != nil {
+			return fmt.Errorf("erro ao gravar resposta em %s: %w", path, err)
+		}
+		log.Printf("[DEBUG] Sessão %s gravada em %s", s.ID, path)
+	}
+
+	if serveHook != "" {
+		hook := exec.Command("sh", "-c", serveHook)
+		hook.Stdin = bytes.NewReader(append(jsonData, '\n'))
+		hook.Stdout = os.Stderr
+		hook.Stderr = os.Stderr
+		hook.Env = append(os.Environ(),
+			"SHANTILLY_SESSION_ID="+s.ID,
+			"SHANTILLY_SSH_USER="+s.User,
+			"SHANTILLY_SSH_REMOTE_ADDR="+s.RemoteAddr.String(),
+		)
+		if err := hook.Run(); err != nil {
+			return fmt.Errorf("erro ao executar hook: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"github.com/helton/shantilly/internal/errors"
+	"github.com/spf13/cobra"
+)
+
+// Outcomes reported by --print-status.
+const (
+	statusCancelled = "cancelled"
+	statusInvalid   = "invalid"
+	statusTimeout   = "timeout"
+)
+
+// resultModel is implemented by every model a runner command can finish with.
+type resultModel interface {
+	Submitted() bool
+	ToMap() map[string]interface{}
+}
+
+// timeoutModel is implemented by models that support timeout:.
+type timeoutModel interface {
+	TimedOut() bool
+}
+
+// finishRun writes the result of a submitted model. Otherwise it reports the
+// cancel or timeout with --print-status and returns an error mapped to
+// errors.ExitCancelled or errors.ExitTimeout.
+func finishRun(cmd *cobra.Command, out *outputOptions, model resultModel) error {
+	if model.Submitted() {
+		return out.write(model.ToMap())
+	}
+
+	// Cancelling is not a usage mistake
+	cmd.SilenceUsage = true
+
+	if timed, ok := model.(timeoutModel); ok && timed.TimedOut() {
+		if err := out.writeStatus(statusTimeout, nil); err != nil {
+			return err
+		}
+		return errors.NewTimeoutError("tempo esgotado sem resposta")
+	}
+
+	if err := out.writeStatus(statusCancelled, nil); err != nil {
+		return err
+	}
+	return errors.NewCancelledError("operação cancelada pelo usuário")
+}
@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/models"
+	"github.com/helton/shantilly/internal/styles"
+	"github.com/spf13/cobra"
+)
+
+var tabsCmd = &cobra.Command{
+	Use:   "tabs [config.yaml]",
+	Short: "Executa uma TUI com componentes organizados em abas",
+	Long: `Carrega um arquivo de configuração YAML e executa uma TUI com abas.
+Ao submeter, todas as abas são validadas; se houver erros, a primeira aba
+com problemas é exibida. O resultado é serializado em JSON aninhado por aba,
+ou no formato escolhido com --format.
+
+Com --schema, o documento aninhado por aba precisa atender ao JSON Schema
+indicado.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTabs,
+}
+
+var (
+	tabsOutput  outputOptions
+	tabsValues  valueOptions
+	tabsDisplay displayOptions
+	tabsSchema  schemaValidationOptions
+	tabsLive    validationOptions
+)
+
+func init() {
+	tabsOutput.addFlags(tabsCmd)
+	tabsValues.addFlags(tabsCmd)
+	tabsDisplay.addFlags(tabsCmd)
+	tabsSchema.addFlags(tabsCmd)
+	tabsLive.addFlags(tabsCmd)
+}
+
+func runTabs(cmd *cobra.Command, args []string) error {
+	start := time.Now()
+	log.Printf("[DEBUG] Iniciando execução do comando tabs - arquivo: %s", args[0])
+
+	if err := tabsOutput.validate(); err != nil {
+		return err
+	}
+	if err := tabsValues.load(); err != nil {
+		return err
+	}
+
+	configPath := args[0]
+
+	// Load configuration with explicit error handling
+	log.Printf("[DEBUG] Carregando configuração das abas: %s", configPath)
+	cfg, err := config.LoadTabsConfig(configPath)
+	if err != nil {
+		log.Printf("[ERROR] Falha ao carregar configuração das abas após %v: %v", time.Since(start), err)
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+	log.Printf("[DEBUG] Configuração das abas carregada em %v", time.Since(start))
+
+	// Create theme
+	theme := styles.DefaultTheme()
+
+	// Create tabs model
+	log.Printf("[DEBUG] Criando modelo de abas")
+	model, err := models.NewTabsModel(cfg, theme)
+	if err != nil {
+		log.Printf("[ERROR] Falha ao criar modelo de abas após %v: %v", time.Since(start), err)
+		return fmt.Errorf("erro ao criar modelo de abas: %w", err)
+	}
+	model.SetStandalone(true)
+
+	// Contract of the submitted values, checked on the nested document
+	schemaValidation, err := tabsLive.schemaValidation(&tabsSchema)
+	if err != nil {
+		return err
+	}
+	if err := model.SetSchemaValidation(schemaValidation); err != nil {
+		return err
+	}
+
+	// Real-time validation settings of the application configuration
+	componentValidation, err := tabsLive.componentValidation()
+	if err != nil {
+		return err
+	}
+	model.SetComponentValidation(componentValidation)
+
+	// Preset values from --values, the environment and --set
+	if err := tabsValues.apply(model); err != nil {
+		return err
+	}
+	if !tabsValues.interactive() {
+		return runHeadless(cmd, model, &tabsOutput)
+	}
+
+	// Configure program options based on environment
+	opts, closeTTY, err := programOptions(tabsDisplay.inlineFor(cfg.Display))
+	if err != nil {
+		return err
+	}
+	defer closeTTY()
+
+	p := tea.NewProgram(model, opts...)
+	log.Printf("[DEBUG] Programa criado em %v, iniciando execução", time.Since(start))
+
+	finalModel, err := p.Run()
+	if err != nil {
+		log.Printf("[ERROR] Falha na execução da TUI de abas após %v: %v", time.Since(start), err)
+		return fmt.Errorf("erro ao executar TUI de abas: %w", err)
+	}
+
+	tabsModel, ok := finalModel.(*models.TabsModel)
+	if !ok {
+		log.Printf("[ERROR] Tipo de modelo inválido após %v", time.Since(start))
+		return fmt.Errorf("erro interno: tipo de modelo inválido")
+	}
+
+	log.Printf("[DEBUG] Modelo verificado, status de submissão: %v (tempo: %v)", tabsModel.Submitted(), time.Since(start))
+
+	if err := finishRun(cmd, &tabsOutput, tabsModel); err != nil {
+		log.Printf("[DEBUG] Comando tabs finalizado sem resultado após %v: %v", time.Since(start), err)
+		return err
+	}
+	log.Printf("[DEBUG] Comando tabs concluído com sucesso em %v", time.Since(start))
+
+	return nil
+}
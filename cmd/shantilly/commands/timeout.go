@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/helton/shantilly/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// timeoutOptions holds the --timeout and --on-timeout flags of form and layout.
+type timeoutOptions struct {
+	timeout   time.Duration
+	onTimeout string
+}
+
+// addFlags registers the timeout flags on cmd.
+func (t *timeoutOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&t.timeout, "timeout", 0, "encerra a TUI após o tempo indicado (ex.: 30s), substitui timeout: da configuração")
+	cmd.Flags().StringVar(&t.onTimeout, "on-timeout", "",
+		"ação ao esgotar o tempo: "+config.TimeoutSubmitDefaults+" ou "+config.TimeoutCancel+" (padrão "+config.TimeoutCancel+")")
+}
+
+// override replaces the configured timeout keys with the flags that were given.
+// The configuration validates the result when the model is created.
+func (t *timeoutOptions) override(timeout *time.Duration, onTimeout *string) {
+	if t.timeout != 0 {
+		*timeout = t.timeout
+	}
+	if t.onTimeout != "" {
+		*onTimeout = t.onTimeout
+	}
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/helton/shantilly/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate [config.yaml...]",
+	Short: "Verifica arquivos de configuração sem executar a TUI",
+	Long: `Analisa arquivos de configuração e relata todos os problemas encontrados,
+com linha e coluna: tipos de componente desconhecidos, nomes duplicados,
+opções desconhecidas para o tipo, regex inválida em pattern, slider com
+min >= max, entre outros. O tipo (form, layout, menu ou tabs) é detectado
+pelas chaves do arquivo ou escolhido com --kind.
+
+Com --format json, o relatório é emitido em JSON para integração com
+editores. O comando termina com código diferente de zero se houver erros.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runValidate,
+}
+
+var validateFlags struct {
+	format string
+	kind   string
+}
+
+func init() {
+	validateCmd.Flags().StringVar(&validateFlags.format, "format", "text", "formato do relatório (text ou json)")
+	validateCmd.Flags().StringVar(&validateFlags.kind, "kind", "", "tipo da configuração: form, layout, menu ou tabs (padrão: detectado)")
+}
+
+// fileReport is the validation result of one file.
+type fileReport struct {
+	File        string              `json:"file"`
+	Kind        string              `json:"kind"`
+	Valid       bool                `json:"valid"`
+	Diagnostics []config.Diagnostic `json:"diagnostics"`
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	log.Printf("[DEBUG] Iniciando execução do comando validate - arquivos: %v", args)
+
+	if validateFlags.format != "text" && validateFlags.format != "json" {
+		return fmt.Errorf("formato de relatório inválido: %s (use text ou json)", validateFlags.format)
+	}
+
+	reports := make([]fileReport, 0, len(args))
+	problems := 0
+	for _, path := range args {
+		kind, diags, err := config.ValidateFile(path, validateFlags.kind)
+		if err != nil {
+			return err
+		}
+		if diags == nil {
+			diags = []config.Diagnostic{}
+		}
+		reports = append(reports, fileReport{File: path, Kind: kind, Valid: len(diags) == 0, Diagnostics: diags})
+		problems += len(diags)
+	}
+
+	out := cmd.OutOrStdout()
+	if validateFlags.format == "json" {
+		data, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			return fmt.Errorf("erro ao serializar relatório: %w", err)
+		}
+		fmt.Fprintln(out, string(data))
+	} else {
+		for _, r := range reports {
+			if r.Valid {
+				fmt.Fprintf(out, "%s: configuração válida (%s)\n", r.File, r.Kind)
+			}
+			for _, d := range r.Diagnostics {
+				fmt.Fprintf(out, "%s:%s\n", r.File, d)
+			}
+		}
+	}
+
+	if problems > 0 {
+		// A broken file is not a usage mistake
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d problema(s) encontrado(s) na configuração", problems)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/helton/shantilly/internal/models"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// valueTarget is implemented by models that accept preset values.
+type valueTarget interface {
+	ApplyValues(values map[string]interface{}) error
+	ApplyEnvValues(lookup func(string) (string, bool)) error
+}
+
+// valueOptions holds the --values, --set, --values-from-env and
+// --non-interactive flags.
+type valueOptions struct {
+	file           string
+	set            []string
+	fromEnv        bool
+	nonInteractive bool
+
+	fileValues map[string]interface{} // Parsed by load
+	setValues  map[string]interface{} // Parsed by load
+}
+
+// addFlags registers the value flags on cmd.
+func (v *valueOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&v.file, "values", "", "arquivo JSON ou YAML com valores iniciais (- para stdin)")
+	cmd.Flags().StringArrayVar(&v.set, "set", nil, "define um valor inicial: nome=valor (aba.nome=valor em abas)")
+	cmd.Flags().BoolVar(&v.fromEnv, "values-from-env", false, "lê valores iniciais das variáveis "+models.EnvValuePrefix+"<NOME>")
+	cmd.Flags().BoolVar(&v.nonInteractive, "non-interactive", false,
+		"não abre a TUI: valida os valores informados e imprime o resultado (automático sem terminal)")
+}
+
+// load reads and parses the value sources before the model is built.
+func (v *valueOptions) load() error {
+	if v.file != "" {
+		var data []byte
+		var err error
+		if v.file == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(v.file)
+		}
+		if err != nil {
+			return fmt.Errorf("erro ao ler valores de %s: %w", v.file, err)
+		}
+
+		// YAML is a superset of JSON, so one decoder handles both
+		if err := yaml.Unmarshal(data, &v.fileValues); err != nil {
+			return fmt.Errorf("erro ao analisar valores de %s: %w", v.file, err)
+		}
+	}
+
+	v.setValues = make(map[string]interface{}, len(v.set))
+	for _, assignment := range v.set {
+		name, value, ok := strings.Cut(assignment, "=")
+		if !ok || name == "" {
+			return fmt.Errorf("formato inválido para --set %q: use nome=valor", assignment)
+		}
+		v.setValues[name] = value
+	}
+
+	return nil
+}
+
+// apply presets values on target: file first, then environment, then --set.
+func (v *valueOptions) apply(target valueTarget) error {
+	if len(v.fileValues) > 0 {
+		log.Printf("[DEBUG] Aplicando %d valor(es) de %s", len(v.fileValues), v.file)
+		if err := target.ApplyValues(v.fileValues); err != nil {
+			return fmt.Errorf("erro ao aplicar valores de %s: %w", v.file, err)
+		}
+	}
+
+	if v.fromEnv {
+		log.Printf("[DEBUG] Aplicando valores das variáveis %s*", models.EnvValuePrefix)
+		if err := target.ApplyEnvValues(os.LookupEnv); err != nil {
+			return fmt.Errorf("erro ao aplicar valores do ambiente: %w", err)
+		}
+	}
+
+	if len(v.setValues) > 0 {
+		if err := target.ApplyValues(v.setValues); err != nil {
+			return fmt.Errorf("erro ao aplicar --set: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// interactive reports whether the TUI should run. Without a terminal the
+// values are validated and printed directly, as with --non-interactive.
+func (v *valueOptions) interactive() bool {
+	if v.nonInteractive {
+		return false
+	}
+	if !hasTerminal() {
+		log.Printf("[DEBUG] Nenhum terminal disponível, ativando modo não interativo")
+		return false
+	}
+	return true
+}
@@ -5,11 +5,17 @@ import (
 	"os"
 
 	"github.com/helton/shantilly/cmd/shantilly/commands"
+	"github.com/helton/shantilly/internal/errors"
 )
 
 func main() {
 	if err := commands.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Erro: %v\n", err)
-		os.Exit(1)
+		code := errors.ExitCodeOf(err)
+
+		// Cancelling is a choice of the user, not an error worth reporting
+		if code != errors.ExitCancelled {
+			fmt.Fprintf(os.Stderr, "Erro: %v\n", err)
+		}
+		os.Exit(code)
 	}
 }
@@ -47,6 +47,11 @@ const (
 	FormatYAML ExportFormat = "yaml"
 	FormatXML  ExportFormat = "xml"
 	FormatCSV  ExportFormat = "csv"
+
+	// FormatEnv and FormatShell render KEY='value' lines for shell scripts.
+	// They are used for orchestration results, not individual components.
+	FormatEnv   ExportFormat = "env"
+	FormatShell ExportFormat = "shell"
 )
 
 // Component defines the contract for all UI widgets in Shantilly.
@@ -112,6 +117,7 @@ type Component interface {
 	// - RadioGroup: string (selected item ID)
 	// - Slider: float64 or int
 	// - FilePicker: string (selected file path)
+	// - Computed: result of its expression, nil when it can't be evaluated
 	//
 	// This method is called by FormModel.ToJSON() to serialize form data.
 	Value() interface{}
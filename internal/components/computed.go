@@ -0,0 +1,244 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/expr"
+	"github.com/helton/shantilly/internal/styles"
+)
+
+// Computed implements a read-only field whose value is derived from the
+// other fields by its compute expression. The orchestration models call
+// Recompute whenever a value changes.
+type Computed struct {
+	name     string
+	label    string
+	help     string
+	compute  *expr.Expr
+	decimals int // Rounding of numeric results, -1 when not set
+	value    interface{}
+	theme    *styles.Theme
+}
+
+// NewComputed creates a new Computed component from configuration.
+func NewComputed(cfg config.ComponentConfig, theme *styles.Theme) (*Computed, error) {
+	if cfg.Type != config.TypeComputed {
+		return nil, fmt.Errorf("tipo de componente inválido: esperado computed, recebido %s", cfg.Type)
+	}
+
+	compute, err := cfg.ParseCompute()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Computed{
+		name:     cfg.Name,
+		label:    cfg.Label,
+		help:     cfg.Help,
+		compute:  compute,
+		decimals: -1,
+		theme:    theme,
+	}
+	if cfg.Options != nil {
+		decimals, ok := cfg.Options["decimals"].(int)
+		if f, isFloat := cfg.Options["decimals"].(float64); isFloat {
+			// JSON and forms generated from a JSON Schema decode every number as float64
+			if f != math.Trunc(f) {
+				return nil, fmt.Errorf("decimals deve ser um número inteiro: %v", f)
+			}
+			decimals, ok = int(f), true
+		}
+		if ok {
+			if decimals < 0 {
+				return nil, fmt.Errorf("decimals não pode ser negativo: %d", decimals)
+			}
+			c.decimals = decimals
+		}
+	}
+	return c, nil
+}
+
+// Recompute evaluates the expression against values, keyed by field name.
+// An expression that fails to evaluate, typically while the fields it uses
+// are still empty, leaves the value nil.
+func (c *Computed) Recompute(values map[string]interface{}) {
+	v, err := c.compute.Eval(values)
+	if err != nil {
+		log.Printf("erro ao calcular o componente %s (%s): %v", c.name, c.compute, err)
+		c.value = nil
+		return
+	}
+	if f, ok := v.(float64); ok && c.decimals >= 0 {
+		scale := math.Pow(10, float64(c.decimals))
+		v = math.Round(f*scale) / scale
+	}
+	c.value = v
+}
+
+// Init implements tea.Model.
+func (c *Computed) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (c *Computed) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Read-only component, the value changes through Recompute
+	return c, nil
+}
+
+// View implements tea.Model.
+func (c *Computed) View() string {
+	var b strings.Builder
+	if c.label != "" {
+		b.WriteString(c.theme.Label.Render(c.label))
+		b.WriteString("\n")
+	}
+
+	if c.value == nil {
+		b.WriteString(c.theme.Help.Render("—"))
+	} else {
+		b.WriteString(c.formatted())
+	}
+	b.WriteString("\n")
+
+	if c.help != "" {
+		b.WriteString(c.theme.Help.Render(c.help))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// formatted renders the value, numbers with the configured decimals.
+func (c *Computed) formatted() string {
+	switch v := c.value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', c.decimals, 64)
+	case bool:
+		if v {
+			return "sim"
+		}
+		return "não"
+	}
+	return fmt.Sprint(c.value)
+}
+
+// Name implements Component.
+func (c *Computed) Name() string {
+	return c.name
+}
+
+// CanFocus implements Component.
+func (c *Computed) CanFocus() bool {
+	return false // Read-only component cannot receive focus
+}
+
+// SetFocus implements Component.
+func (c *Computed) SetFocus(focused bool) {
+	// No-op for read-only component
+}
+
+// IsValid implements Component.
+func (c *Computed) IsValid() bool {
+	return true // Derived values are checked by rules, not by the component
+}
+
+// GetError implements Component.
+func (c *Computed) GetError() string {
+	return "" // Read-only component never has errors
+}
+
+// SetError implements Component.
+func (c *Computed) SetError(msg string) {
+	// No-op for read-only component
+}
+
+// Value implements Component.
+func (c *Computed) Value() interface{} {
+	return c.value
+}
+
+// SetValue implements Component. Computed values can't be set.
+func (c *Computed) SetValue(value interface{}) error {
+	return fmt.Errorf("campo calculado não aceita valores: o valor vem de %s", c.compute)
+}
+
+// Reset implements Component.
+func (c *Computed) Reset() {
+	// No-op, the value follows the other fields
+}
+
+// GetMetadata implements Component.
+func (c *Computed) GetMetadata() ComponentMetadata {
+	return ComponentMetadata{
+		Version:      "1.0.0",
+		Author:       "Shantilly Team",
+		Description:  "Read-only field derived from other fields by an expression",
+		Dependencies: c.GetDependencies(),
+		Examples: []ComponentExample{
+			{
+				Name:        "FQDN",
+				Description: "Host name joined with the domain",
+				Config: map[string]interface{}{
+					"type":    "computed",
+					"name":    "fqdn",
+					"label":   "FQDN",
+					"compute": "hostname + '.' + domain",
+				},
+			},
+		},
+		Schema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"value": map[string]interface{}{
+					"type":        []string{"string", "number", "boolean", "null"},
+					"description": "Result of the compute expression",
+				},
+			},
+		},
+	}
+}
+
+// ValidateWithContext implements Component.
+func (c *Computed) ValidateWithContext(context ValidationContext) []ValidationError {
+	// Read-only component always passes validation
+	return []ValidationError{}
+}
+
+// ExportToFormat implements Component.
+func (c *Computed) ExportToFormat(format ExportFormat) ([]byte, error) {
+	data := map[string]interface{}{
+		"name":     c.Name(),
+		"value":    c.Value(),
+		"metadata": c.GetMetadata(),
+	}
+
+	switch format {
+	case FormatJSON:
+		return json.MarshalIndent(data, "", "  ")
+	default:
+		return nil, fmt.Errorf("formato não suportado: %s", format)
+	}
+}
+
+// ImportFromFormat implements Component. The value of a computed field
+// can't be imported.
+func (c *Computed) ImportFromFormat(format ExportFormat, data []byte) error {
+	return fmt.Errorf("campo calculado não aceita valores: o valor vem de %s", c.compute)
+}
+
+// GetDependencies implements Component.
+func (c *Computed) GetDependencies() []string {
+	return append([]string{}, c.compute.Fields()...)
+}
+
+// SetTheme implements Component.
+func (c *Computed) SetTheme(theme *styles.Theme) {
+	c.theme = theme
+}
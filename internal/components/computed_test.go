@@ -0,0 +1,117 @@
+package components
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewComputed(t *testing.T) {
+	theme := styles.DefaultTheme()
+
+	tests := []struct {
+		name    string
+		cfg     config.ComponentConfig
+		wantErr string
+	}{
+		{
+			name: "valid",
+			cfg:  config.ComponentConfig{Type: config.TypeComputed, Name: "fqdn", Compute: "host + '.' + domain"},
+		},
+		{
+			name:    "missing compute",
+			cfg:     config.ComponentConfig{Type: config.TypeComputed, Name: "fqdn"},
+			wantErr: "compute é obrigatório em componentes do tipo computed",
+		},
+		{
+			name:    "syntax error",
+			cfg:     config.ComponentConfig{Type: config.TypeComputed, Name: "fqdn", Compute: "host +"},
+			wantErr: "compute inválido: posição 7: fim inesperado da expressão",
+		},
+		{
+			name:    "negative decimals",
+			cfg:     config.ComponentConfig{Type: config.TypeComputed, Name: "total", Compute: "1", Options: map[string]interface{}{"decimals": -1}},
+			wantErr: "decimals não pode ser negativo: -1",
+		},
+		{
+			name:    "fractional decimals",
+			cfg:     config.ComponentConfig{Type: config.TypeComputed, Name: "total", Compute: "1", Options: map[string]interface{}{"decimals": 1.5}},
+			wantErr: "decimals deve ser um número inteiro: 1.5",
+		},
+		{
+			name:    "wrong type",
+			cfg:     config.ComponentConfig{Type: config.TypeText, Name: "fqdn"},
+			wantErr: "tipo de componente inválido: esperado computed, recebido text",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewComputed(tt.cfg, theme)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.cfg.Name, c.Name())
+		})
+	}
+}
+
+func TestComputed_Recompute(t *testing.T) {
+	c, err := NewComputed(config.ComponentConfig{
+		Type:    config.TypeComputed,
+		Name:    "total",
+		Label:   "Total",
+		Compute: "qty * price * (1 - discount / 100)",
+		Options: map[string]interface{}{"decimals": 2},
+	}, styles.DefaultTheme())
+	require.NoError(t, err)
+
+	// Nothing to compute from yet
+	assert.Nil(t, c.Value())
+	assert.Contains(t, ansi.Strip(c.View()), "—")
+
+	c.Recompute(map[string]interface{}{"qty": "3", "price": 19.99, "discount": 10.0})
+	assert.Equal(t, 53.97, c.Value())
+	assert.Contains(t, ansi.Strip(c.View()), "53.97")
+
+	// An evaluation error clears the value
+	c.Recompute(map[string]interface{}{"qty": "", "price": 19.99, "discount": 10.0})
+	assert.Nil(t, c.Value())
+}
+
+func TestComputed_DecimalsFromJSON(t *testing.T) {
+	// JSON decodes decimals: 1 as a float64
+	c, err := NewComputed(config.ComponentConfig{
+		Type:    config.TypeComputed,
+		Name:    "ratio",
+		Compute: "2 / 3",
+		Options: map[string]interface{}{"decimals": float64(1)},
+	}, styles.DefaultTheme())
+	require.NoError(t, err)
+
+	c.Recompute(map[string]interface{}{})
+	assert.Equal(t, 0.7, c.Value())
+}
+
+func TestComputed_ReadOnly(t *testing.T) {
+	c, err := NewComputed(config.ComponentConfig{Type: config.TypeComputed, Name: "fqdn", Compute: "host + '.' + domain"}, styles.DefaultTheme())
+	require.NoError(t, err)
+	c.Recompute(map[string]interface{}{"host": "web01", "domain": "example.com"})
+
+	assert.False(t, c.CanFocus())
+	assert.True(t, c.IsValid())
+	assert.Equal(t, []string{"host", "domain"}, c.GetDependencies())
+
+	_, cmd := c.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	assert.Nil(t, cmd)
+	assert.EqualError(t, c.SetValue("other"), "campo calculado não aceita valores: o valor vem de host + '.' + domain")
+	c.Reset()
+	assert.Equal(t, "web01.example.com", c.Value())
+}
@@ -47,6 +47,8 @@ func NewComponent(cfg config.ComponentConfig, theme *styles.Theme) (Component, e
 		component, err = NewTextLabel(cfg, theme)
 	case config.TypeFilePicker:
 		component, err = NewFilePicker(cfg, theme)
+	case config.TypeComputed:
+		component, err = NewComputed(cfg, theme)
 	default:
 		err = fmt.Errorf("tipo de componente não suportado: %s", cfg.Type)
 	}
@@ -0,0 +1,146 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/helton/shantilly/internal/config"
+)
+
+// inputMask formats the value of a text input as the user types, following
+// options.mask. Only the characters typed into slots are kept; the literals
+// of the mask are inserted around them.
+type inputMask struct {
+	alternatives []string // Ordered by number of slots
+	raw          bool     // Value() returns only the slot characters
+}
+
+// newInputMask parses options.mask and options.mask_output. It returns nil
+// when the component has no mask.
+func newInputMask(options map[string]interface{}) (*inputMask, error) {
+	pattern, _ := options["mask"].(string)
+	if pattern == "" {
+		return nil, nil
+	}
+	alternatives, err := config.ExpandMask(pattern)
+	if err != nil {
+		return nil, err
+	}
+	output, _ := options["mask_output"].(string)
+	if err := config.ValidateMaskOutput(output); err != nil {
+		return nil, err
+	}
+	return &inputMask{alternatives: alternatives, raw: output == config.MaskOutputRaw}, nil
+}
+
+// fitsSlot reports whether r can be typed into the mask slot.
+func fitsSlot(slot, r rune) bool {
+	switch slot {
+	case config.MaskDigit:
+		return r >= '0' && r <= '9'
+	case config.MaskLetter:
+		return unicode.IsLetter(r)
+	case config.MaskAlphaNum:
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+	return false
+}
+
+// isSlot reports whether r is an editable position of a mask.
+func isSlot(r rune) bool {
+	return r == config.MaskDigit || r == config.MaskLetter || r == config.MaskAlphaNum
+}
+
+// unmask extracts the slot characters of a value, typed or pasted, by
+// walking it along the longest alternative: literals of the mask are
+// skipped whether present or not, and characters that fit no slot are
+// dropped.
+func (m *inputMask) unmask(value string) string {
+	mask := []rune(m.alternatives[len(m.alternatives)-1])
+	var raw strings.Builder
+	j := 0
+	for _, r := range value {
+		for j < len(mask) && !isSlot(mask[j]) && mask[j] != r {
+			j++
+		}
+		if j == len(mask) {
+			break
+		}
+		if !isSlot(mask[j]) {
+			j++ // The literal itself was typed
+			continue
+		}
+		if fitsSlot(mask[j], r) {
+			raw.WriteRune(r)
+			j++
+		}
+	}
+	return raw.String()
+}
+
+// alternative returns the shortest alternative with room for n slot
+// characters, or the longest one.
+func (m *inputMask) alternative(n int) []rune {
+	for _, alt := range m.alternatives {
+		if config.MaskSlots(alt) >= n {
+			return []rune(alt)
+		}
+	}
+	return []rune(m.alternatives[len(m.alternatives)-1])
+}
+
+// format lays raw slot characters out on the mask. Literals are only
+// written before a filled slot, so erasing never stops at a separator.
+func (m *inputMask) format(raw string) string {
+	chars := []rune(raw)
+	var b strings.Builder
+	k := 0
+	for _, r := range m.alternative(len(chars)) {
+		if k == len(chars) {
+			break
+		}
+		if isSlot(r) {
+			b.WriteRune(chars[k])
+			k++
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// complete reports whether raw fills every slot of one of the alternatives.
+func (m *inputMask) complete(raw string) bool {
+	n := len([]rune(raw))
+	for _, alt := range m.alternatives {
+		if config.MaskSlots(alt) == n {
+			return true
+		}
+	}
+	return false
+}
+
+// incompleteMessage tells the user which format is expected.
+func (m *inputMask) incompleteMessage() string {
+	return fmt.Sprintf("Preencha no formato %s", strings.Join(m.alternatives, " ou "))
+}
+
+// cursor returns the position, in the formatting of raw, right after its
+// first n slot characters.
+func (m *inputMask) cursor(raw string, n int) int {
+	if n == 0 {
+		return 0
+	}
+	formatted := []rune(m.format(raw))
+	count := 0
+	for i, r := range m.alternative(len([]rune(raw)))[:len(formatted)] {
+		if isSlot(r) {
+			count++
+			if count == n {
+				return i + 1
+			}
+		}
+	}
+	return len(formatted)
+}
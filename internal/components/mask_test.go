@@ -0,0 +1,118 @@
+package components
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInputMask_Format(t *testing.T) {
+	tests := []struct {
+		mask  string
+		value string
+		want  string
+	}{
+		{"cpf", "529", "529"},
+		{"cpf", "5299", "529.9"},
+		{"cpf", "52998224725", "529.982.247-25"},
+		{"cpf", "529.982.247-25", "529.982.247-25"},
+		{"cpf", "529.982.247-2599", "529.982.247-25"},
+		{"cpf", "52a9", "529"},
+		{"phone", "1123456789", "(11) 2345-6789"},
+		{"phone", "11987654321", "(11) 98765-4321"},
+		{"phone", "(11) 2345-67891", "(11) 23456-7891"},
+		{"cnpj", "12abc34501de35", "12.abc.345/01de-35"},
+		{"AA-###", "br123", "br-123"},
+		{"AA-###", "1br", "br"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mask+"/"+tt.value, func(t *testing.T) {
+			m, err := newInputMask(map[string]interface{}{"mask": tt.mask})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, m.format(m.unmask(tt.value)))
+		})
+	}
+}
+
+func TestNewInputMask(t *testing.T) {
+	m, err := newInputMask(map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Nil(t, m)
+
+	_, err = newInputMask(map[string]interface{}{"mask": "--"})
+	assert.ErrorContains(t, err, "máscara sem posições editáveis")
+
+	_, err = newInputMask(map[string]interface{}{"mask": "cpf", "mask_output": "digits"})
+	assert.ErrorContains(t, err, "mask_output deve ser")
+}
+
+func TestTextInput_Mask(t *testing.T) {
+	ti, err := NewTextInput(config.ComponentConfig{
+		Type:     config.TypeTextInput,
+		Name:     "cpf",
+		Required: true,
+		Options:  map[string]interface{}{"mask": "###.###.###-##", "validate": "cpf"},
+	}, styles.DefaultTheme())
+	require.NoError(t, err)
+	ti.SetFocus(true)
+
+	for _, r := range "5299822" {
+		ti.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	assert.Equal(t, "529.982.2", ti.Value())
+	assert.False(t, ti.IsValid())
+	assert.Equal(t, "Preencha no formato ###.###.###-##", ti.GetError())
+
+	// Separators typed by the user are not doubled
+	for _, r := range "47-24" {
+		ti.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	assert.Equal(t, "529.982.247-24", ti.Value())
+	assert.False(t, ti.IsValid())
+	assert.Equal(t, "CPF inválido", ti.GetError())
+
+	// Erasing removes the digit and the separator before it
+	ti.Update(tea.KeyPressMsg{Code: tea.KeyBackspace})
+	ti.Update(tea.KeyPressMsg{Code: tea.KeyBackspace})
+	assert.Equal(t, "529.982.247", ti.Value())
+
+	require.NoError(t, ti.SetValue("52998224725"))
+	assert.Equal(t, "529.982.247-25", ti.Value())
+	assert.True(t, ti.IsValid())
+}
+
+func TestTextInput_MaskOutput(t *testing.T) {
+	ti, err := NewTextInput(config.ComponentConfig{
+		Type:    config.TypeTextInput,
+		Name:    "phone",
+		Default: "11987654321",
+		Options: map[string]interface{}{"mask": "phone", "mask_output": "raw"},
+	}, styles.DefaultTheme())
+	require.NoError(t, err)
+
+	assert.Equal(t, "(11) 98765-4321", ti.model.Value())
+	assert.Equal(t, "11987654321", ti.Value())
+	assert.True(t, ti.IsValid())
+}
+
+func TestTextInput_MaskKeepsCursor(t *testing.T) {
+	ti, err := NewTextInput(config.ComponentConfig{
+		Type:    config.TypeTextInput,
+		Name:    "cep",
+		Options: map[string]interface{}{"mask": "cep"},
+	}, styles.DefaultTheme())
+	require.NoError(t, err)
+	ti.SetFocus(true)
+	require.NoError(t, ti.SetValue("0131010"))
+
+	// Insert the missing digit after "013"
+	ti.model.SetCursor(3)
+	ti.Update(tea.KeyPressMsg{Code: '1', Text: "1"})
+	assert.Equal(t, "01311-010", ti.Value())
+	assert.Equal(t, 4, ti.model.Position())
+}
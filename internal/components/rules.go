@@ -0,0 +1,219 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/expr"
+)
+
+// dateLayouts lists the date formats understood by date_after.
+var dateLayouts = []string{"2006-01-02", time.RFC3339, "02/01/2006"}
+
+// EvaluateRules checks the declarative rules against the values of the
+// context and returns one error per broken rule, reported on the rule field
+// (the first candidate for one_of_required, the first field of an
+// expression).
+func EvaluateRules(rules []config.Rule, context ValidationContext) []ValidationError {
+	var errors []ValidationError
+	for _, rule := range rules {
+		msg := evaluateRule(rule, context)
+		if msg == "" {
+			continue
+		}
+		if rule.Message != "" {
+			msg = rule.Message
+		}
+
+		field := rule.Field
+		if refs := rule.Refs(); field == "" && len(refs) > 0 {
+			field = refs[0]
+		}
+		errors = append(errors, ValidationError{
+			Code:     "RULE_" + strings.ToUpper(rule.Type),
+			Message:  msg,
+			Field:    field,
+			Severity: "error",
+			Context: map[string]interface{}{
+				"rule":           rule.Type,
+				"related_fields": rule.Refs(),
+			},
+		})
+	}
+	return errors
+}
+
+// Values returns the names an expression can refer to: the global
+// configuration and external data, overridden by the component values.
+func (c ValidationContext) Values() map[string]interface{} {
+	values := make(map[string]interface{}, len(c.GlobalConfig)+len(c.ExternalData)+len(c.ComponentValues))
+	for _, source := range []map[string]interface{}{c.GlobalConfig, c.ExternalData, c.ComponentValues} {
+		for name, v := range source {
+			values[name] = v
+		}
+	}
+	return values
+}
+
+// Eval evaluates e against the values of the context.
+func (c ValidationContext) Eval(e *expr.Expr) (interface{}, error) {
+	return e.Eval(c.Values())
+}
+
+// Bool evaluates e against the values of the context and reports whether
+// the result is truthy.
+func (c ValidationContext) Bool(e *expr.Expr) (bool, error) {
+	return e.Bool(c.Values())
+}
+
+// evaluateRule returns the default message of a broken rule, or "" when the
+// values satisfy it. Comparisons other than equals pass while either side is
+// empty, leaving that case to required. Values compare as in expressions, so
+// a rule and the same condition written as show_if agree.
+func evaluateRule(rule config.Rule, context ValidationContext) string {
+	if rule.Type == config.RuleExpr {
+		return evaluateExprRule(rule, context)
+	}
+
+	values := context.ComponentValues
+	value := values[rule.Field]
+	target, targetName := rule.Value, fmt.Sprint(rule.Value)
+	if rule.Other != "" {
+		target, targetName = values[rule.Other], rule.Other
+	}
+
+	switch rule.Type {
+	case config.RuleEquals:
+		if isEmptyValue(value) && isEmptyValue(target) {
+			return ""
+		}
+		if !expr.Equal(value, target) {
+			return fmt.Sprintf("Deve ser igual a %s", targetName)
+		}
+
+	case config.RuleNotEquals:
+		if !isEmptyValue(value) && expr.Equal(value, target) {
+			return fmt.Sprintf("Deve ser diferente de %s", targetName)
+		}
+
+	case config.RuleRequiredIf:
+		other := values[rule.Other]
+		applies := !isEmptyValue(other)
+		if rule.Value != nil {
+			applies = expr.Equal(other, rule.Value)
+		}
+		if applies && isEmptyValue(value) {
+			if rule.Value != nil {
+				return fmt.Sprintf("Obrigatório quando %s é %v", rule.Other, rule.Value)
+			}
+			return fmt.Sprintf("Obrigatório quando %s está preenchido", rule.Other)
+		}
+
+	case config.RuleGreaterThan:
+		if isEmptyValue(value) || isEmptyValue(target) {
+			return ""
+		}
+		n, ok := expr.Numeric(value)
+		if !ok {
+			return "Valor numérico inválido"
+		}
+		limit, ok := expr.Numeric(target)
+		if !ok {
+			return ""
+		}
+		if n <= limit {
+			return fmt.Sprintf("Deve ser maior que %s", targetName)
+		}
+
+	case config.RuleOneOfRequired:
+		for _, name := range rule.Fields {
+			if !isEmptyValue(values[name]) {
+				return ""
+			}
+		}
+		return fmt.Sprintf("Preencha pelo menos um dos campos: %s", strings.Join(rule.Fields, ", "))
+
+	case config.RuleDateAfter:
+		if isEmptyValue(value) || isEmptyValue(target) {
+			return ""
+		}
+		date, ok := toDate(value)
+		if !ok {
+			return "Data inválida (use AAAA-MM-DD)"
+		}
+		limit, ok := toDate(target)
+		if !ok {
+			return ""
+		}
+		if !date.After(limit) {
+			if target == "today" {
+				targetName = "hoje"
+			}
+			return fmt.Sprintf("Deve ser posterior a %s", targetName)
+		}
+	}
+	return ""
+}
+
+// evaluateExprRule returns the default message of a broken expr rule, or ""
+// when its expression holds. An expression that fails to evaluate, such as
+// a number compared against an empty text, passes while any of its fields
+// is empty.
+func evaluateExprRule(rule config.Rule, context ValidationContext) string {
+	e, err := rule.Expression()
+	if err != nil || e == nil {
+		return "" // Rejected when the configuration is validated
+	}
+	ok, err := context.Bool(e)
+	if err == nil {
+		if ok {
+			return ""
+		}
+		return fmt.Sprintf("Condição não atendida: %s", e)
+	}
+	for _, field := range e.Fields() {
+		if isEmptyValue(context.ComponentValues[field]) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("Não foi possível avaliar %s: %v", e, err)
+}
+
+// isEmptyValue reports whether a component value counts as not filled.
+func isEmptyValue(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case bool:
+		return !v
+	case []string:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// toDate parses a date in one of dateLayouts; "today" is the current date.
+func toDate(v interface{}) (time.Time, bool) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		s := strings.TrimSpace(v)
+		if s == "today" {
+			y, m, d := time.Now().Date()
+			return time.Date(y, m, d, 0, 0, 0, 0, time.UTC), true
+		}
+		for _, layout := range dateLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
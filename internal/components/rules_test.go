@@ -0,0 +1,228 @@
+package components
+
+import (
+	"testing"
+	"time"
+
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/expr"
+	"github.com/helton/shantilly/internal/styles"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    config.Rule
+		values  map[string]interface{}
+		wantMsg string // Empty when the rule holds
+	}{
+		{
+			name:    "equals broken",
+			rule:    config.Rule{Type: config.RuleEquals, Field: "confirm", Other: "password"},
+			values:  map[string]interface{}{"password": "s3cret", "confirm": "secret"},
+			wantMsg: "Deve ser igual a password",
+		},
+		{
+			name:   "equals holds",
+			rule:   config.Rule{Type: config.RuleEquals, Field: "confirm", Other: "password"},
+			values: map[string]interface{}{"password": "s3cret", "confirm": "s3cret"},
+		},
+		{
+			name:    "equals with empty confirmation",
+			rule:    config.Rule{Type: config.RuleEquals, Field: "confirm", Other: "password"},
+			values:  map[string]interface{}{"password": "s3cret", "confirm": ""},
+			wantMsg: "Deve ser igual a password",
+		},
+		{
+			name:   "equals literal across types",
+			rule:   config.Rule{Type: config.RuleEquals, Field: "n", Value: 3},
+			values: map[string]interface{}{"n": 3.0},
+		},
+		{
+			name:    "equals keeps zero padding like expressions",
+			rule:    config.Rule{Type: config.RuleEquals, Field: "code", Value: "7"},
+			values:  map[string]interface{}{"code": "007"},
+			wantMsg: "Deve ser igual a 7",
+		},
+		{
+			name:   "equals list across types",
+			rule:   config.Rule{Type: config.RuleEquals, Field: "tags", Value: []interface{}{"a", "b"}},
+			values: map[string]interface{}{"tags": []string{"a", "b"}},
+		},
+		{
+			name:    "custom message",
+			rule:    config.Rule{Type: config.RuleEquals, Field: "confirm", Other: "password", Message: "Senhas não coincidem"},
+			values:  map[string]interface{}{"password": "a", "confirm": "b"},
+			wantMsg: "Senhas não coincidem",
+		},
+		{
+			name:    "not_equals broken",
+			rule:    config.Rule{Type: config.RuleNotEquals, Field: "new", Other: "old"},
+			values:  map[string]interface{}{"old": "abc", "new": "abc"},
+			wantMsg: "Deve ser diferente de old",
+		},
+		{
+			name:   "not_equals skips empty",
+			rule:   config.Rule{Type: config.RuleNotEquals, Field: "new", Value: ""},
+			values: map[string]interface{}{"new": ""},
+		},
+		{
+			name:    "required_if value matches",
+			rule:    config.Rule{Type: config.RuleRequiredIf, Field: "company", Other: "kind", Value: "business"},
+			values:  map[string]interface{}{"kind": "business", "company": " "},
+			wantMsg: "Obrigatório quando kind é business",
+		},
+		{
+			name:   "required_if value differs",
+			rule:   config.Rule{Type: config.RuleRequiredIf, Field: "company", Other: "kind", Value: "business"},
+			values: map[string]interface{}{"kind": "personal", "company": ""},
+		},
+		{
+			name:    "required_if other filled",
+			rule:    config.Rule{Type: config.RuleRequiredIf, Field: "reason", Other: "cancel"},
+			values:  map[string]interface{}{"cancel": true, "reason": ""},
+			wantMsg: "Obrigatório quando cancel está preenchido",
+		},
+		{
+			name:    "greater_than broken",
+			rule:    config.Rule{Type: config.RuleGreaterThan, Field: "max", Other: "min"},
+			values:  map[string]interface{}{"min": 10.0, "max": "5"},
+			wantMsg: "Deve ser maior que min",
+		},
+		{
+			name:    "greater_than not a number",
+			rule:    config.Rule{Type: config.RuleGreaterThan, Field: "max", Value: 0},
+			values:  map[string]interface{}{"max": "muitos"},
+			wantMsg: "Valor numérico inválido",
+		},
+		{
+			name:   "greater_than skips empty",
+			rule:   config.Rule{Type: config.RuleGreaterThan, Field: "max", Other: "min"},
+			values: map[string]interface{}{"min": "", "max": "5"},
+		},
+		{
+			name:    "one_of_required broken",
+			rule:    config.Rule{Type: config.RuleOneOfRequired, Fields: []string{"email", "phone"}},
+			values:  map[string]interface{}{"email": "", "phone": ""},
+			wantMsg: "Preencha pelo menos um dos campos: email, phone",
+		},
+		{
+			name:   "one_of_required holds",
+			rule:   config.Rule{Type: config.RuleOneOfRequired, Fields: []string{"email", "phone"}},
+			values: map[string]interface{}{"email": "", "phone": "5511"},
+		},
+		{
+			name:    "date_after broken",
+			rule:    config.Rule{Type: config.RuleDateAfter, Field: "end", Other: "start"},
+			values:  map[string]interface{}{"start": "2025-03-10", "end": "10/03/2025"},
+			wantMsg: "Deve ser posterior a start",
+		},
+		{
+			name:   "date_after holds",
+			rule:   config.Rule{Type: config.RuleDateAfter, Field: "end", Value: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+			values: map[string]interface{}{"end": "2025-01-02"},
+		},
+		{
+			name:    "date_after today",
+			rule:    config.Rule{Type: config.RuleDateAfter, Field: "end", Value: "today"},
+			values:  map[string]interface{}{"end": "2000-01-01"},
+			wantMsg: "Deve ser posterior a hoje",
+		},
+		{
+			name:    "date_after invalid date",
+			rule:    config.Rule{Type: config.RuleDateAfter, Field: "end", Value: "today"},
+			values:  map[string]interface{}{"end": "amanhã"},
+			wantMsg: "Data inválida (use AAAA-MM-DD)",
+		},
+		{
+			name:    "expr broken",
+			rule:    config.Rule{Type: config.RuleExpr, Field: "password", Expr: "len(password) >= 8"},
+			values:  map[string]interface{}{"password": "curta"},
+			wantMsg: "Condição não atendida: len(password) >= 8",
+		},
+		{
+			name:   "expr holds",
+			rule:   config.Rule{Type: config.RuleExpr, Expr: "max > min && env in ['dev', 'qa']"},
+			values: map[string]interface{}{"min": "2", "max": 10.0, "env": "qa"},
+		},
+		{
+			name:   "expr type error skips empty",
+			rule:   config.Rule{Type: config.RuleExpr, Expr: "age >= 18"},
+			values: map[string]interface{}{"age": ""},
+		},
+		{
+			name:    "expr type error",
+			rule:    config.Rule{Type: config.RuleExpr, Expr: "age >= 18"},
+			values:  map[string]interface{}{"age": "dezoito"},
+			wantMsg: "Não foi possível avaliar age >= 18: posição 5: não é possível comparar texto com número",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := EvaluateRules([]config.Rule{tt.rule}, ValidationContext{ComponentValues: tt.values})
+			if tt.wantMsg == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			if assert.Len(t, errs, 1) {
+				assert.Equal(t, tt.wantMsg, errs[0].Message)
+			}
+		})
+	}
+}
+
+func TestEvaluateRules_Field(t *testing.T) {
+	rules := []config.Rule{
+		{Type: config.RuleEquals, Field: "confirm", Other: "password"},
+		{Type: config.RuleOneOfRequired, Fields: []string{"email", "phone"}},
+	}
+	errs := EvaluateRules(rules, ValidationContext{ComponentValues: map[string]interface{}{"password": "a"}})
+
+	if assert.Len(t, errs, 2) {
+		assert.Equal(t, "RULE_EQUALS", errs[0].Code)
+		assert.Equal(t, "confirm", errs[0].Field)
+		assert.Equal(t, []string{"confirm", "password"}, errs[0].Context["related_fields"])
+		assert.Equal(t, "RULE_ONE_OF_REQUIRED", errs[1].Code)
+		assert.Equal(t, "email", errs[1].Field, "one_of_required reports on the first candidate")
+	}
+}
+
+func TestEvaluateRules_ExprField(t *testing.T) {
+	rules := []config.Rule{{Type: config.RuleExpr, Expr: "end > start", Message: "Fim antes do início"}}
+	errs := EvaluateRules(rules, ValidationContext{ComponentValues: map[string]interface{}{"start": 5.0, "end": 1.0}})
+
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "RULE_EXPR", errs[0].Code)
+		assert.Equal(t, "end", errs[0].Field, "expr reports on the first field of the expression")
+		assert.Equal(t, "Fim antes do início", errs[0].Message)
+		assert.Equal(t, []string{"end", "start"}, errs[0].Context["related_fields"])
+	}
+}
+
+func TestValidationContext_Eval(t *testing.T) {
+	e, err := expr.Parse("env + '/' + region")
+	if !assert.NoError(t, err) {
+		return
+	}
+	ctx := ValidationContext{
+		GlobalConfig:    map[string]interface{}{"env": "prod", "region": "global"},
+		ExternalData:    map[string]interface{}{"region": "sa-east"},
+		ComponentValues: map[string]interface{}{"region": "us-east"},
+	}
+
+	// Component values win over external data, which wins over the global configuration
+	got, err := ctx.Eval(e)
+	assert.NoError(t, err)
+	assert.Equal(t, "prod/us-east", got)
+}
+
+func TestTextInput_ValidateWithContext_NoMagicNames(t *testing.T) {
+	ti, err := NewTextInput(config.ComponentConfig{Type: config.TypeTextInput, Name: "confirm_password", Default: "a"}, styles.DefaultTheme())
+	if !assert.NoError(t, err) {
+		return
+	}
+	errs := ti.ValidateWithContext(ValidationContext{ComponentValues: map[string]interface{}{"password": "b"}})
+	assert.Empty(t, errs, "cross-field checks are declared as rules")
+}
@@ -27,8 +27,7 @@ type TextArea struct {
 	initialValue string
 
 	// Validation options
-	minLength int
-	maxLength int
+	checks textChecks
 
 	// Error management integration
 	errorManager *errors.ErrorManager
@@ -45,6 +44,8 @@ func NewTextArea(cfg config.ComponentConfig, theme *styles.Theme) (*TextArea, er
 	ta.Placeholder = cfg.Placeholder
 	ta.ShowLineNumbers = false
 	ta.CharLimit = 0 // No default limit
+	// Enter submits the form, so ctrl+j also inserts a newline
+	ta.KeyMap.InsertNewline.SetKeys("enter", "ctrl+m", "ctrl+j")
 
 	// Set default value if provided
 	if cfg.Default != nil {
@@ -64,14 +65,14 @@ func NewTextArea(cfg config.ComponentConfig, theme *styles.Theme) (*TextArea, er
 	}
 
 	// Parse validation options
+	checks, err := newTextChecks(cfg.Options)
+	if err != nil {
+		return nil, err
+	}
+	t.checks = checks
+	t.model.CharLimit = checks.maxLength // Typing stops at max_length
+
 	if cfg.Options != nil {
-		if minLen, ok := cfg.Options["min_length"].(int); ok {
-			t.minLength = minLen
-		}
-		if maxLen, ok := cfg.Options["max_length"].(int); ok {
-			t.maxLength = maxLen
-			ta.CharLimit = maxLen
-		}
 		if height, ok := cfg.Options["height"].(int); ok {
 			ta.SetHeight(height)
 		} else {
@@ -195,22 +196,12 @@ func (t *TextArea) IsValid() bool {
 		return true
 	}
 
-	// Min length validation with ErrorManager integration
-	if t.minLength > 0 && len(value) < t.minLength {
-		t.errorMsg = fmt.Sprintf("Mínimo de %d caracteres", t.minLength)
-
-		if t.errorManager != nil {
-			log.Printf("TextArea min length validation error in %s: valor abaixo do mínimo", t.name)
-		}
-		return false
-	}
-
-	// Max length validation with ErrorManager integration
-	if t.maxLength > 0 && len(value) > t.maxLength {
-		t.errorMsg = fmt.Sprintf("Máximo de %d caracteres", t.maxLength)
+	// Length, pattern and named validators with ErrorManager integration
+	if msg := t.checks.check(value); msg != "" {
+		t.errorMsg = msg
 
 		if t.errorManager != nil {
-			log.Printf("TextArea max length validation error in %s: valor excede o máximo", t.name)
+			log.Printf("TextArea validation error in %s: %s", t.name, msg)
 		}
 		return false
 	}
@@ -248,7 +239,11 @@ func (t *TextArea) SetValue(value interface{}) error {
 		return err
 	}
 
+	// Preset values past max_length are kept whole, so validation reports
+	// them instead of the input cutting them short
+	t.model.CharLimit = 0
 	t.model.SetValue(strValue)
+	t.model.CharLimit = t.checks.maxLength
 
 	// Clear any previous error when setting a valid value
 	t.errorMsg = ""
@@ -311,8 +306,9 @@ func (t *TextArea) ValidateWithContext(context ValidationContext) []ValidationEr
 				"value":              t.Value(),
 				"validation_context": context,
 				"required":           t.required,
-				"min_length":         t.minLength,
-				"max_length":         t.maxLength,
+				"min_length":         t.checks.minLength,
+				"max_length":         t.checks.maxLength,
+				"validators":         t.checks.names(),
 			},
 		}
 		errors = append(errors, validationErr)
@@ -53,8 +53,8 @@ func TestNewTextArea(t *testing.T) {
 			},
 			expectError: false,
 			validate: func(t *testing.T, ta *TextArea) {
-				assert.Equal(t, 10, ta.minLength)
-				assert.Equal(t, 500, ta.maxLength)
+				assert.Equal(t, 10, ta.checks.minLength)
+				assert.Equal(t, 500, ta.checks.maxLength)
 			},
 		},
 		{
@@ -150,6 +150,14 @@ func TestTextArea_Update(t *testing.T) {
 		assert.Equal(t, initialValue, ta.Value(), "Value should not change when not focused")
 	})
 
+	t.Run("ctrl+j inserts a newline", func(t *testing.T) {
+		ta.SetValue("a")
+		ta.SetFocus(true)
+		ta.Update(tea.KeyPressMsg{Code: 'j', Mod: tea.ModCtrl})
+		ta.Update(tea.KeyPressMsg{Code: 'b', Text: "b"})
+		assert.Equal(t, "a\nb", ta.Value())
+	})
+
 	t.Run("non-key message does not clear error when focused", func(t *testing.T) {
 		ta.SetFocus(true)
 		ta.SetError("Persistent error")
@@ -400,6 +408,22 @@ func TestTextArea_Validation(t *testing.T) {
 	}
 }
 
+func TestTextArea_MaxLengthTyping(t *testing.T) {
+	ta, err := NewTextArea(config.ComponentConfig{
+		Type:    config.TypeTextArea,
+		Name:    "notes",
+		Options: map[string]interface{}{"max_length": 3},
+	}, styles.DefaultTheme())
+	require.NoError(t, err)
+
+	ta.SetFocus(true)
+	for _, r := range "abcdef" {
+		ta.Update(tea.KeyPressMsg{Text: string(r), Code: r})
+	}
+	assert.Equal(t, "abc", ta.Value())
+	assert.True(t, ta.IsValid())
+}
+
 func TestTextArea_Reset(t *testing.T) {
 	t.Run("with initial value", func(t *testing.T) {
 		theme := styles.DefaultTheme()
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
-	"regexp"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/v2/textinput"
@@ -29,9 +28,8 @@ type TextInput struct {
 	initialValue string
 
 	// Validation options
-	minLength int
-	maxLength int
-	pattern   *regexp.Regexp
+	checks textChecks
+	mask   *inputMask
 
 	// Error management integration
 	errorManager *errors.ErrorManager
@@ -48,9 +46,17 @@ func NewTextInput(cfg config.ComponentConfig, theme *styles.Theme) (*TextInput,
 	ti.Placeholder = cfg.Placeholder
 	ti.CharLimit = 0 // No default limit
 
+	mask, err := newInputMask(cfg.Options)
+	if err != nil {
+		return nil, err
+	}
+
 	// Set default value if provided
 	if cfg.Default != nil {
 		if defaultStr, ok := cfg.Default.(string); ok {
+			if mask != nil {
+				defaultStr = mask.format(mask.unmask(defaultStr))
+			}
 			ti.SetValue(defaultStr)
 		}
 	}
@@ -63,25 +69,16 @@ func NewTextInput(cfg config.ComponentConfig, theme *styles.Theme) (*TextInput,
 		model:        ti,
 		theme:        theme,
 		initialValue: ti.Value(),
+		mask:         mask,
 	}
 
 	// Parse validation options
-	if cfg.Options != nil {
-		if minLen, ok := cfg.Options["min_length"].(int); ok {
-			t.minLength = minLen
-		}
-		if maxLen, ok := cfg.Options["max_length"].(int); ok {
-			t.maxLength = maxLen
-			ti.CharLimit = maxLen
-		}
-		if patternStr, ok := cfg.Options["pattern"].(string); ok {
-			pattern, err := regexp.Compile(patternStr)
-			if err != nil {
-				return nil, fmt.Errorf("erro ao compilar regex pattern: %w", err)
-			}
-			t.pattern = pattern
-		}
+	checks, err := newTextChecks(cfg.Options)
+	if err != nil {
+		return nil, err
 	}
+	t.checks = checks
+	t.model.CharLimit = checks.maxLength // Typing stops at max_length
 
 	return t, nil
 }
@@ -102,7 +99,11 @@ func (t *TextInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// Only process messages if focused
 	if t.focused {
+		before := t.model.Value()
 		t.model, cmd = t.model.Update(msg)
+		if t.mask != nil && t.model.Value() != before {
+			t.applyMask()
+		}
 		// Clear error when user types
 		if _, ok := msg.(tea.KeyMsg); ok {
 			t.errorMsg = ""
@@ -112,6 +113,18 @@ func (t *TextInput) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return t, cmd
 }
 
+// applyMask reformats the edited value, keeping the cursor after the same
+// typed character.
+func (t *TextInput) applyMask() {
+	value := t.model.Value()
+	typed := len([]rune(t.mask.unmask(string([]rune(value)[:t.model.Position()]))))
+	raw := t.mask.unmask(value)
+	if formatted := t.mask.format(raw); formatted != value {
+		t.model.SetValue(formatted)
+		t.model.SetCursor(t.mask.cursor(raw, typed))
+	}
+}
+
 // View implements tea.Model.
 func (t *TextInput) View() string {
 	var b strings.Builder
@@ -185,32 +198,18 @@ func (t *TextInput) IsValid() bool {
 		return true
 	}
 
-	// Min length validation
-	if t.minLength > 0 && len(value) < t.minLength {
-		t.errorMsg = fmt.Sprintf("Mínimo de %d caracteres", t.minLength)
-
-		if t.errorManager != nil {
-			log.Printf("TextInput min length validation error in %s: valor abaixo do mínimo", t.name)
-		}
-		return false
-	}
-
-	// Max length validation (already enforced by CharLimit, but check anyway)
-	if t.maxLength > 0 && len(value) > t.maxLength {
-		t.errorMsg = fmt.Sprintf("Máximo de %d caracteres", t.maxLength)
-
-		if t.errorManager != nil {
-			log.Printf("TextInput max length validation error in %s: valor excede o máximo", t.name)
-		}
+	// Every slot of the mask must be filled
+	if t.mask != nil && !t.mask.complete(t.mask.unmask(value)) {
+		t.errorMsg = t.mask.incompleteMessage()
 		return false
 	}
 
-	// Pattern validation
-	if t.pattern != nil && !t.pattern.MatchString(value) {
-		t.errorMsg = "Formato inválido"
+	// Length, pattern and named validators
+	if msg := t.checks.check(value); msg != "" {
+		t.errorMsg = msg
 
 		if t.errorManager != nil {
-			log.Printf("TextInput pattern validation error in %s: formato inválido", t.name)
+			log.Printf("TextInput validation error in %s: %s", t.name, msg)
 		}
 		return false
 	}
@@ -232,6 +231,9 @@ func (t *TextInput) SetError(msg string) {
 
 // Value implements Component.
 func (t *TextInput) Value() interface{} {
+	if t.mask != nil && t.mask.raw {
+		return t.mask.unmask(t.model.Value())
+	}
 	return t.model.Value()
 }
 
@@ -248,7 +250,14 @@ func (t *TextInput) SetValue(value interface{}) error {
 		return err
 	}
 
+	if t.mask != nil {
+		strValue = t.mask.format(t.mask.unmask(strValue))
+	}
+	// Preset values past max_length are kept whole, so validation reports
+	// them instead of the input cutting them short
+	t.model.CharLimit = 0
 	t.model.SetValue(strValue)
+	t.model.CharLimit = t.checks.maxLength
 
 	// Clear any previous error when setting a valid value
 	t.errorMsg = ""
@@ -321,32 +330,6 @@ func (t *TextInput) ValidateWithContext(context ValidationContext) []ValidationE
 		}
 	}
 
-	// Cross-field validation examples with ErrorManager
-	if componentValues, ok := context.ComponentValues["password"]; ok {
-		if password, ok := componentValues.(string); ok && t.name == "confirm_password" {
-			if currentValue := t.Value().(string); currentValue != password {
-				validationErr := ValidationError{
-					Code:     "PASSWORD_MISMATCH",
-					Message:  "Senhas não coincidem",
-					Field:    t.name,
-					Severity: "error",
-					Context: map[string]interface{}{
-						"component":       "TextInput",
-						"related_field":   "password",
-						"password_length": len(password),
-						"current_length":  len(currentValue),
-					},
-				}
-				errors = append(errors, validationErr)
-
-				// Log cross-field validation error (simplified for compilation)
-				if t.errorManager != nil {
-					log.Printf("TextInput cross-field validation error in %s: senhas não coincidem", t.name)
-				}
-			}
-		}
-	}
-
 	return errors
 }
 
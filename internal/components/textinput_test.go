@@ -218,6 +218,22 @@ func TestTextInput_Update(t *testing.T) {
 	assert.Empty(t, ti.GetError())
 }
 
+func TestTextInput_MaxLengthTyping(t *testing.T) {
+	ti, err := NewTextInput(config.ComponentConfig{
+		Type:    config.TypeTextInput,
+		Name:    "code",
+		Options: map[string]interface{}{"max_length": 3},
+	}, styles.DefaultTheme())
+	require.NoError(t, err)
+
+	ti.SetFocus(true)
+	for _, r := range "abcdef" {
+		ti.Update(tea.KeyPressMsg{Text: string(r), Code: r})
+	}
+	assert.Equal(t, "abc", ti.Value())
+	assert.True(t, ti.IsValid())
+}
+
 func TestTextInput_View(t *testing.T) {
 	theme := styles.DefaultTheme()
 	cfg := config.ComponentConfig{
@@ -0,0 +1,366 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/helton/shantilly/internal/config"
+)
+
+// textChecks holds the validation options shared by text-based components:
+// length limits, pattern and the named validators of options.validate.
+type textChecks struct {
+	minLength  int
+	maxLength  int
+	pattern    *regexp.Regexp
+	validators []config.ValidatorSpec
+}
+
+// newTextChecks parses the validation options of a text-based component.
+func newTextChecks(options map[string]interface{}) (textChecks, error) {
+	var c textChecks
+	if minLen, ok := options["min_length"].(int); ok {
+		c.minLength = minLen
+	}
+	if maxLen, ok := options["max_length"].(int); ok {
+		c.maxLength = maxLen
+	}
+	if patternStr, ok := options["pattern"].(string); ok {
+		pattern, err := regexp.Compile(patternStr)
+		if err != nil {
+			return c, fmt.Errorf("erro ao compilar regex pattern: %w", err)
+		}
+		c.pattern = pattern
+	}
+
+	validators, err := config.ParseValidators(options["validate"])
+	if err != nil {
+		return c, err
+	}
+	c.validators = validators
+	return c, nil
+}
+
+// check returns the message of the first failed check of a non-empty value,
+// or "" when the value passes them all.
+func (c textChecks) check(value string) string {
+	if c.minLength > 0 && len(value) < c.minLength {
+		return fmt.Sprintf("Mínimo de %d caracteres", c.minLength)
+	}
+	if c.maxLength > 0 && len(value) > c.maxLength {
+		return fmt.Sprintf("Máximo de %d caracteres", c.maxLength)
+	}
+	if c.pattern != nil && !c.pattern.MatchString(value) {
+		return "Formato inválido"
+	}
+	for _, v := range c.validators {
+		if msg := runValidator(v, value); msg != "" {
+			return msg
+		}
+	}
+	return ""
+}
+
+// names returns the validator specs as written in the configuration.
+func (c textChecks) names() []string {
+	names := make([]string, len(c.validators))
+	for i, v := range c.validators {
+		names[i] = v.Name
+	}
+	return names
+}
+
+var (
+	hostnameLabelRe = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+	semverRe        = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+	uuidRe          = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	cepRe           = regexp.MustCompile(`^\d{5}-?\d{3}$`)
+	phoneRe         = regexp.MustCompile(`^\+?[\d\s().-]+$`)
+)
+
+// runValidator returns the localized message of a failed validator, or "".
+func runValidator(v config.ValidatorSpec, value string) string {
+	switch v.Name {
+	case config.ValidatorEmail:
+		if addr, err := mail.ParseAddress(value); err != nil || addr.Address != value {
+			return "E-mail inválido"
+		}
+	case config.ValidatorURL:
+		if u, err := url.Parse(value); err != nil || u.Scheme == "" || u.Host == "" {
+			return "URL inválida (ex.: https://exemplo.com)"
+		}
+	case config.ValidatorHostname:
+		if !validHostname(value) {
+			return "Nome de host inválido"
+		}
+	case config.ValidatorIPv4:
+		if ip := net.ParseIP(value); ip == nil || ip.To4() == nil || strings.Contains(value, ":") {
+			return "Endereço IPv4 inválido"
+		}
+	case config.ValidatorIPv6:
+		if ip := net.ParseIP(value); ip == nil || !strings.Contains(value, ":") {
+			return "Endereço IPv6 inválido"
+		}
+	case config.ValidatorCIDR:
+		if _, _, err := net.ParseCIDR(value); err != nil {
+			return "Bloco CIDR inválido (ex.: 10.0.0.0/8)"
+		}
+	case config.ValidatorPort:
+		if port, err := strconv.Atoi(value); err != nil || port < 1 || port > 65535 {
+			return "Porta inválida (1 a 65535)"
+		}
+	case config.ValidatorSemver:
+		if !semverRe.MatchString(value) {
+			return "Versão semântica inválida (ex.: 1.2.3)"
+		}
+	case config.ValidatorUUID:
+		if !uuidRe.MatchString(value) {
+			return "UUID inválido"
+		}
+	case config.ValidatorInt:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return "Deve ser um número inteiro"
+		}
+		return rangeMessage(v, float64(n))
+	case config.ValidatorFloat:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return "Deve ser um número"
+		}
+		return rangeMessage(v, f)
+	case config.ValidatorJSON:
+		if !json.Valid([]byte(value)) {
+			return "JSON inválido"
+		}
+	case config.ValidatorDuration:
+		if _, err := time.ParseDuration(value); err != nil {
+			return "Duração inválida (ex.: 30s, 5m, 1h30m)"
+		}
+	case config.ValidatorCron:
+		if !validCron(value) {
+			return "Expressão cron inválida (ex.: */5 * * * *)"
+		}
+	case config.ValidatorPathExists:
+		if _, err := os.Stat(value); err != nil {
+			return "Caminho não encontrado"
+		}
+	case config.ValidatorCPF:
+		if !validCPF(value) {
+			return "CPF inválido"
+		}
+	case config.ValidatorCNPJ:
+		if !validCNPJ(value) {
+			return "CNPJ inválido"
+		}
+	case config.ValidatorCEP:
+		if !cepRe.MatchString(strings.TrimSpace(value)) {
+			return "CEP inválido"
+		}
+	case config.ValidatorPhone:
+		if !validPhone(value) {
+			return "Telefone inválido (DDD + número)"
+		}
+	}
+	return ""
+}
+
+// onlyDigits drops every character of s that is not an ASCII digit.
+func onlyDigits(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// stripDocument removes the punctuation accepted in formatted documents.
+func stripDocument(s string) string {
+	return strings.NewReplacer(".", "", "-", "", "/", "", " ", "").Replace(strings.TrimSpace(s))
+}
+
+// checkDigit computes a mod-11 check digit of the Receita Federal: the
+// characters count as their ASCII code minus 48, so letters of alphanumeric
+// CNPJs weigh 17 to 42.
+func checkDigit(s string, weights []int) byte {
+	sum := 0
+	for i, w := range weights {
+		sum += int(s[i]-'0') * w
+	}
+	r := sum % 11
+	if r < 2 {
+		return '0'
+	}
+	return byte('0' + 11 - r)
+}
+
+// validCPF checks the length and both check digits of a CPF.
+func validCPF(value string) bool {
+	s := stripDocument(value)
+	if len(s) != 11 || onlyDigits(s) != s || strings.Count(s, s[:1]) == len(s) {
+		return false
+	}
+	return checkDigit(s, []int{10, 9, 8, 7, 6, 5, 4, 3, 2}) == s[9] &&
+		checkDigit(s, []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2}) == s[10]
+}
+
+// validCNPJ checks the length and both check digits of a CNPJ. The first
+// twelve characters may be letters, as in the alphanumeric CNPJ.
+func validCNPJ(value string) bool {
+	s := strings.ToUpper(stripDocument(value))
+	if len(s) != 14 || onlyDigits(s[12:]) != s[12:] || strings.Count(s, s[:1]) == len(s) {
+		return false
+	}
+	for _, r := range s[:12] {
+		if !(r >= '0' && r <= '9') && !(r >= 'A' && r <= 'Z') {
+			return false
+		}
+	}
+	return checkDigit(s, []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) == s[12] &&
+		checkDigit(s, []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) == s[13]
+}
+
+// validPhone checks a Brazilian phone with area code, optionally prefixed
+// by +55: mobiles have nine digits starting with 9, landlines eight digits
+// starting with 2 to 5.
+func validPhone(value string) bool {
+	if !phoneRe.MatchString(strings.TrimSpace(value)) {
+		return false
+	}
+	digits := onlyDigits(value)
+	if strings.HasPrefix(strings.TrimSpace(value), "+") {
+		if !strings.HasPrefix(digits, "55") {
+			return false
+		}
+		digits = digits[2:]
+	}
+	if len(digits) != 10 && len(digits) != 11 {
+		return false
+	}
+	if digits[0] == '0' || digits[1] == '0' {
+		return false
+	}
+	if len(digits) == 11 {
+		return digits[2] == '9'
+	}
+	return digits[2] >= '2' && digits[2] <= '5'
+}
+
+// rangeMessage checks n against the bounds of an int or float validator.
+func rangeMessage(v config.ValidatorSpec, n float64) string {
+	switch {
+	case v.Min != nil && v.Max != nil && (n < *v.Min || n > *v.Max):
+		return fmt.Sprintf("Deve estar entre %s e %s", formatBound(*v.Min), formatBound(*v.Max))
+	case v.Min != nil && n < *v.Min:
+		return fmt.Sprintf("Deve ser no mínimo %s", formatBound(*v.Min))
+	case v.Max != nil && n > *v.Max:
+		return fmt.Sprintf("Deve ser no máximo %s", formatBound(*v.Max))
+	}
+	return ""
+}
+
+// formatBound formats a range bound without trailing zeros.
+func formatBound(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// validHostname checks a hostname per RFC 1123.
+func validHostname(value string) bool {
+	value = strings.TrimSuffix(value, ".")
+	if value == "" || len(value) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(value, ".") {
+		if !hostnameLabelRe.MatchString(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// cronFields lists the bounds and names of the five standard cron fields.
+var cronFields = []struct {
+	min, max int
+	names    []string // Names for the values from min, if any
+}{
+	{0, 59, nil}, // minute
+	{0, 23, nil}, // hour
+	{1, 31, nil}, // day of month
+	{1, 12, []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+	{0, 7, []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}}, // 7 is also Sunday
+}
+
+// cronMacros lists the accepted shorthand schedules.
+var cronMacros = map[string]bool{
+	"@yearly": true, "@annually": true, "@monthly": true, "@weekly": true,
+	"@daily": true, "@midnight": true, "@hourly": true, "@reboot": true,
+}
+
+// validCron checks a five-field cron expression or a macro such as @daily.
+func validCron(value string) bool {
+	if cronMacros[strings.ToLower(strings.TrimSpace(value))] {
+		return true
+	}
+	fields := strings.Fields(value)
+	if len(fields) != len(cronFields) {
+		return false
+	}
+	for i, field := range fields {
+		for _, item := range strings.Split(field, ",") {
+			if !validCronItem(item, cronFields[i].min, cronFields[i].max, cronFields[i].names) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// validCronItem checks one list item: "*", a value or a range, with an
+// optional "/step".
+func validCronItem(item string, min, max int, names []string) bool {
+	base, step, stepped := strings.Cut(item, "/")
+	if stepped {
+		if n, err := strconv.Atoi(step); err != nil || n < 1 {
+			return false
+		}
+	}
+	if base == "*" {
+		return true
+	}
+
+	low, high, ranged := strings.Cut(base, "-")
+	a, ok := cronValue(low, min, max, names)
+	if !ok {
+		return false
+	}
+	if !ranged {
+		return true
+	}
+	b, ok := cronValue(high, min, max, names)
+	return ok && a <= b
+}
+
+// cronValue parses a number or name within the bounds of a field.
+func cronValue(s string, min, max int, names []string) (int, bool) {
+	for i, name := range names {
+		if strings.EqualFold(s, name) {
+			return min + i, true
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < min || n > max {
+		return 0, false
+	}
+	return n, true
+}
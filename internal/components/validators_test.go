@@ -0,0 +1,114 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunValidator(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		spec    string
+		valid   []string
+		invalid []string
+		message string
+	}{
+		{"email", []string{"maria@exemplo.com", "a.b+c@sub.exemplo.com.br"}, []string{"maria", "Maria <maria@exemplo.com>", "maria@"}, "E-mail inválido"},
+		{"url", []string{"https://exemplo.com", "http://localhost:8080/api?q=1"}, []string{"exemplo.com", "https://", "::"}, "URL inválida (ex.: https://exemplo.com)"},
+		{"hostname", []string{"localhost", "api.exemplo.com", "db-1.local."}, []string{"-db.local", "a..b", "under_score.com"}, "Nome de host inválido"},
+		{"ipv4", []string{"10.0.0.1", "255.255.255.255"}, []string{"256.0.0.1", "::1", "::ffff:10.0.0.1"}, "Endereço IPv4 inválido"},
+		{"ipv6", []string{"::1", "2001:db8::ff00:42:8329"}, []string{"10.0.0.1", "2001:db8::g"}, "Endereço IPv6 inválido"},
+		{"cidr", []string{"10.0.0.0/8", "2001:db8::/32"}, []string{"10.0.0.0", "10.0.0.0/33"}, "Bloco CIDR inválido (ex.: 10.0.0.0/8)"},
+		{"port", []string{"1", "8080", "65535"}, []string{"0", "65536", "http"}, "Porta inválida (1 a 65535)"},
+		{"semver", []string{"1.2.3", "0.1.0-rc.1+build.5"}, []string{"1.2", "v1.2.3", "01.2.3"}, "Versão semântica inválida (ex.: 1.2.3)"},
+		{"uuid", []string{"123e4567-e89b-12d3-a456-426614174000"}, []string{"123e4567e89b12d3a456426614174000", "xyz"}, "UUID inválido"},
+		{"int", []string{"42", "-7"}, []string{"4.2", "dez"}, "Deve ser um número inteiro"},
+		{"float", []string{"4.2", "-1e3", "7"}, []string{"NaN", "1,5"}, "Deve ser um número"},
+		{"json", []string{`{"a": [1, 2]}`, "null"}, []string{"{a: 1}", "[1,"}, "JSON inválido"},
+		{"duration", []string{"30s", "1h30m", "250ms"}, []string{"30", "1 dia"}, "Duração inválida (ex.: 30s, 5m, 1h30m)"},
+		{"cron", []string{"*/5 * * * *", "0 9 * * mon-fri", "0 0 1,15 jan,jul 0", "@daily"}, []string{"* * * *", "60 * * * *", "0 9 * * 8", "5-1 * * * *", "*/0 * * * *"}, "Expressão cron inválida (ex.: */5 * * * *)"},
+		{"path_exists", []string{dir, "."}, []string{dir + "/nada"}, "Caminho não encontrado"},
+		{"cpf", []string{"529.982.247-25", "52998224725"}, []string{"529.982.247-24", "111.111.111-11", "5299822472", "52998224725a"}, "CPF inválido"},
+		{"cnpj", []string{"11.222.333/0001-81", "11222333000181", "12.ABC.345/01DE-35", "12abc34501de35"}, []string{"11.222.333/0001-80", "00.000.000/0000-00", "12.ABC.345/01DE-3X"}, "CNPJ inválido"},
+		{"cep", []string{"01310-100", "01310100"}, []string{"0131-0100", "1310100", "01310-10a"}, "CEP inválido"},
+		{"phone", []string{"(11) 98765-4321", "(11) 2345-6789", "+55 21 99876-5432", "1134567890"}, []string{"(11) 8765-4321", "(01) 98765-4321", "(11) 88765-4321", "+1 415 555 0100", "(11) 9876-543a"}, "Telefone inválido (DDD + número)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			v, err := config.ParseValidator(tt.spec)
+			require.NoError(t, err)
+			for _, value := range tt.valid {
+				assert.Empty(t, runValidator(v, value), value)
+			}
+			for _, value := range tt.invalid {
+				assert.Equal(t, tt.message, runValidator(v, value), value)
+			}
+		})
+	}
+}
+
+func TestRunValidator_Ranges(t *testing.T) {
+	tests := []struct {
+		spec    string
+		value   string
+		message string
+	}{
+		{"int:1..10", "5", ""},
+		{"int:1..10", "11", "Deve estar entre 1 e 10"},
+		{"int:1..", "0", "Deve ser no mínimo 1"},
+		{"float:..0.5", "0.75", "Deve ser no máximo 0.5"},
+		{"float:-1.5..1.5", "-1.5", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec+"="+tt.value, func(t *testing.T) {
+			v, err := config.ParseValidator(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.message, runValidator(v, tt.value))
+		})
+	}
+}
+
+func TestTextChecks_SharedByTextComponents(t *testing.T) {
+	options := map[string]interface{}{
+		"pattern":  "^[0-9]+$",
+		"validate": []interface{}{"port"},
+	}
+
+	ti, err := NewTextInput(config.ComponentConfig{Type: config.TypeTextInput, Name: "port", Options: options}, styles.DefaultTheme())
+	require.NoError(t, err)
+	ta, err := NewTextArea(config.ComponentConfig{Type: config.TypeTextArea, Name: "port", Options: options}, styles.DefaultTheme())
+	require.NoError(t, err)
+
+	for _, comp := range []Component{ti, ta} {
+		require.NoError(t, comp.SetValue("80a"))
+		assert.False(t, comp.IsValid())
+		assert.Equal(t, "Formato inválido", comp.GetError())
+
+		require.NoError(t, comp.SetValue("70000"))
+		assert.False(t, comp.IsValid())
+		assert.Equal(t, "Porta inválida (1 a 65535)", comp.GetError())
+
+		require.NoError(t, comp.SetValue("8080"))
+		assert.True(t, comp.IsValid())
+
+		// Empty optional fields skip the validators
+		require.NoError(t, comp.SetValue(""))
+		assert.True(t, comp.IsValid())
+	}
+}
+
+func TestNewTextInput_InvalidValidator(t *testing.T) {
+	_, err := NewTextInput(config.ComponentConfig{
+		Type:    config.TypeTextInput,
+		Name:    "x",
+		Options: map[string]interface{}{"validate": "rg"},
+	}, styles.DefaultTheme())
+	assert.ErrorContains(t, err, "validador desconhecido: rg")
+}
@@ -110,7 +110,7 @@ type CrossFieldValidation struct {
 type BusinessValidation struct {
 	Enabled         bool   `yaml:"enabled" json:"enabled"`
 	RulesPath       string `yaml:"rules_path" json:"rules_path"`
-	CustomValidator string `yaml:"custom_validator" json:"custom_validator"`
+	CustomValidator string `yaml:"custom_validator" json:"custom_validator"` // Expression the submitted values must satisfy
 }
 
 // SchemaValidation contains schema validation settings
@@ -175,6 +175,11 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Validate the business rule expression
+	if err := c.Validation.Business.Validate(); err != nil {
+		return fmt.Errorf("erro na validação de negócio: %w", err)
+	}
+
 	// Validate themes
 	for name, theme := range c.Themes {
 		if err := theme.Validate(); err != nil {
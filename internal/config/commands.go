@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultCommandTimeout bounds a validate_command run when the component
+// sets no validate_timeout.
+const DefaultCommandTimeout = 5 * time.Second
+
+// CommandValuePlaceholder is replaced by the field value in the arguments
+// of validate_command.
+const CommandValuePlaceholder = "{{value}}"
+
+// ValidateCommand is an external program that checks the value of a field.
+// A non-zero exit or a JSON {"error": "..."} on stdout rejects the value.
+type ValidateCommand struct {
+	Args    []string      // Program and arguments, before placeholder expansion
+	Timeout time.Duration // Maximum run time
+}
+
+// Expand returns the arguments with the placeholder replaced by value.
+func (c ValidateCommand) Expand(value string) []string {
+	args := make([]string, len(c.Args))
+	for i, arg := range c.Args {
+		args[i] = strings.ReplaceAll(arg, CommandValuePlaceholder, value)
+	}
+	return args
+}
+
+// ParseValidateCommand parses options.validate_command and
+// options.validate_timeout. It returns nil when the component sets no
+// command.
+func ParseValidateCommand(options map[string]interface{}) (*ValidateCommand, error) {
+	raw, ok := options["validate_command"]
+	if !ok || raw == nil {
+		if _, ok := options["validate_timeout"]; ok {
+			return nil, fmt.Errorf("validate_timeout requer validate_command")
+		}
+		return nil, nil
+	}
+
+	var args []string
+	switch v := raw.(type) {
+	case []string:
+		args = v
+	case []interface{}:
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("validate_command deve conter apenas textos, recebido: %v", item)
+			}
+			args = append(args, s)
+		}
+	default:
+		return nil, fmt.Errorf("validate_command deve ser uma lista com o programa e seus argumentos")
+	}
+	if len(args) == 0 || strings.TrimSpace(args[0]) == "" {
+		return nil, fmt.Errorf("validate_command deve conter pelo menos o programa")
+	}
+
+	cmd := &ValidateCommand{Args: args, Timeout: DefaultCommandTimeout}
+	if timeout, ok := options["validate_timeout"]; ok {
+		d, err := parseCommandTimeout(timeout)
+		if err != nil {
+			return nil, err
+		}
+		cmd.Timeout = d
+	}
+	return cmd, nil
+}
+
+// parseCommandTimeout parses validate_timeout, a positive duration such as "10s".
+func parseCommandTimeout(value interface{}) (time.Duration, error) {
+	s, ok := value.(string)
+	if !ok {
+		return 0, fmt.Errorf("validate_timeout deve ser uma duração, por exemplo 10s")
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("validate_timeout inválido: %s (use uma duração positiva, por exemplo 10s)", s)
+	}
+	return d, nil
+}
+
+// CollectValidateCommands returns the external validators of comps by
+// component name.
+func CollectValidateCommands(comps []ComponentConfig) (map[string]*ValidateCommand, error) {
+	commands := make(map[string]*ValidateCommand)
+	for _, comp := range comps {
+		cmd, err := ParseValidateCommand(comp.Options)
+		if err != nil {
+			return nil, fmt.Errorf("componente %s: %w", comp.Name, err)
+		}
+		if cmd != nil {
+			commands[comp.Name] = cmd
+		}
+	}
+	return commands, nil
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValidateCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]interface{}
+		want    *ValidateCommand
+		wantErr string
+	}{
+		{
+			name:    "no command",
+			options: map[string]interface{}{"pattern": "^a$"},
+		},
+		{
+			name:    "default timeout",
+			options: map[string]interface{}{"validate_command": []interface{}{"./check-user.sh", "{{value}}"}},
+			want:    &ValidateCommand{Args: []string{"./check-user.sh", "{{value}}"}, Timeout: DefaultCommandTimeout},
+		},
+		{
+			name: "custom timeout",
+			options: map[string]interface{}{
+				"validate_command": []string{"ldap-check"},
+				"validate_timeout": "10s",
+			},
+			want: &ValidateCommand{Args: []string{"ldap-check"}, Timeout: 10 * time.Second},
+		},
+		{
+			name:    "plain string",
+			options: map[string]interface{}{"validate_command": "./check.sh {{value}}"},
+			wantErr: "validate_command deve ser uma lista",
+		},
+		{
+			name:    "empty list",
+			options: map[string]interface{}{"validate_command": []interface{}{}},
+			wantErr: "deve conter pelo menos o programa",
+		},
+		{
+			name:    "non-string argument",
+			options: map[string]interface{}{"validate_command": []interface{}{"./check.sh", 3}},
+			wantErr: "deve conter apenas textos",
+		},
+		{
+			name: "bad timeout",
+			options: map[string]interface{}{
+				"validate_command": []interface{}{"./check.sh"},
+				"validate_timeout": "-1s",
+			},
+			wantErr: "validate_timeout inválido: -1s",
+		},
+		{
+			name:    "timeout without command",
+			options: map[string]interface{}{"validate_timeout": "1s"},
+			wantErr: "validate_timeout requer validate_command",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseValidateCommand(tt.options)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateCommand_Expand(t *testing.T) {
+	cmd := ValidateCommand{Args: []string{"check", "--user={{value}}", "{{value}}"}}
+	assert.Equal(t, []string{"check", "--user=maria", "maria"}, cmd.Expand("maria"))
+	assert.Equal(t, []string{"check", "--user={{value}}", "{{value}}"}, cmd.Args)
+}
+
+func TestCollectValidateCommands(t *testing.T) {
+	comps := []ComponentConfig{
+		{Type: TypeTextInput, Name: "user", Options: map[string]interface{}{"validate_command": []interface{}{"check"}}},
+		{Type: TypeTextInput, Name: "email"},
+	}
+	commands, err := CollectValidateCommands(comps)
+	require.NoError(t, err)
+	assert.Len(t, commands, 1)
+	assert.Equal(t, []string{"check"}, commands["user"].Args)
+
+	comps[1].Options = map[string]interface{}{"validate_command": []interface{}{}}
+	_, err = CollectValidateCommands(comps)
+	assert.ErrorContains(t, err, "componente email: validate_command deve conter pelo menos o programa")
+}
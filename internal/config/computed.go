@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/helton/shantilly/internal/expr"
+)
+
+// ParseCompute parses the compute expression of a computed component.
+func (c *ComponentConfig) ParseCompute() (*expr.Expr, error) {
+	if c.Type != TypeComputed {
+		if c.Compute != "" {
+			return nil, fmt.Errorf("compute só é permitido em componentes do tipo %s", TypeComputed)
+		}
+		return nil, nil
+	}
+	e, err := ParseExpression("compute", c.Compute)
+	if err != nil {
+		return nil, err
+	}
+	if e == nil {
+		return nil, fmt.Errorf("compute é obrigatório em componentes do tipo %s", TypeComputed)
+	}
+	return e, nil
+}
+
+// validateComputed checks that the compute expressions of comps parse and
+// only refer to fields of the same list. A computed component may use
+// another one only if it is declared above it, which rules out cycles.
+func validateComputed(comps []ComponentConfig) error {
+	names := make(map[string]bool, len(comps))
+	order := make(map[string]int) // Position of each computed component
+	for i, comp := range comps {
+		names[comp.Name] = true
+		if comp.Type == TypeComputed {
+			order[comp.Name] = i
+		}
+	}
+
+	for i, comp := range comps {
+		e, err := comp.ParseCompute()
+		if err != nil {
+			return fmt.Errorf("componente %s: %w", comp.Name, err)
+		}
+		if err := computeRefs(e, i, names, order); err != nil {
+			return fmt.Errorf("componente %s: %w", comp.Name, err)
+		}
+	}
+	return nil
+}
+
+// computeRefs checks that the compute expression of the component at index
+// only refers to names, and to computed components declared above it.
+func computeRefs(e *expr.Expr, index int, names map[string]bool, order map[string]int) error {
+	if err := conditionRefs("compute", e, names); err != nil || e == nil {
+		return err
+	}
+	for _, field := range e.Fields() {
+		if at, ok := order[field]; ok && at == index {
+			return fmt.Errorf("compute não pode usar o próprio campo")
+		} else if ok && at > index {
+			return fmt.Errorf("compute: %s deve ser declarado antes deste campo", field)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/helton/shantilly/internal/expr"
+)
+
+// Conditions are the parsed show_if and enable_if expressions of a
+// component. A nil expression always holds.
+type Conditions struct {
+	ShowIf   *expr.Expr
+	EnableIf *expr.Expr
+}
+
+// ParseConditions parses the show_if and enable_if keys of the component.
+func (c *ComponentConfig) ParseConditions() (Conditions, error) {
+	var conds Conditions
+	var err error
+	if conds.ShowIf, err = ParseExpression("show_if", c.ShowIf); err != nil {
+		return conds, err
+	}
+	if conds.EnableIf, err = ParseExpression("enable_if", c.EnableIf); err != nil {
+		return conds, err
+	}
+	return conds, nil
+}
+
+// CollectConditions returns the conditions of the components that declare
+// any, by component name.
+func CollectConditions(comps []ComponentConfig) (map[string]Conditions, error) {
+	conditions := make(map[string]Conditions)
+	for _, comp := range comps {
+		conds, err := comp.ParseConditions()
+		if err != nil {
+			return nil, fmt.Errorf("componente %s: %w", comp.Name, err)
+		}
+		if conds.ShowIf != nil || conds.EnableIf != nil {
+			conditions[comp.Name] = conds
+		}
+	}
+	return conditions, nil
+}
+
+// validateConditions checks that the conditions of comps parse and only
+// refer to fields of the same list.
+func validateConditions(comps []ComponentConfig) error {
+	names := make(map[string]bool, len(comps))
+	for _, comp := range comps {
+		names[comp.Name] = true
+	}
+
+	for _, comp := range comps {
+		conds, err := comp.ParseConditions()
+		if err != nil {
+			return fmt.Errorf("componente %s: %w", comp.Name, err)
+		}
+		if err := conditionRefs("show_if", conds.ShowIf, names); err != nil {
+			return fmt.Errorf("componente %s: %w", comp.Name, err)
+		}
+		if err := conditionRefs("enable_if", conds.EnableIf, names); err != nil {
+			return fmt.Errorf("componente %s: %w", comp.Name, err)
+		}
+	}
+	return nil
+}
+
+// conditionRefs checks that the expression of the key only refers to names.
+func conditionRefs(key string, e *expr.Expr, names map[string]bool) error {
+	if e == nil {
+		return nil
+	}
+	for _, field := range e.Fields() {
+		if !names[field] {
+			return fmt.Errorf("%s: campo inexistente: %s", key, field)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,834 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/helton/shantilly/internal/expr"
+	"gopkg.in/yaml.v3"
+)
+
+// Configuration kinds accepted by ValidateDocument.
+const (
+	KindForm   = "form"
+	KindLayout = "layout"
+	KindMenu   = "menu"
+	KindTabs   = "tabs"
+)
+
+// Diagnostic is a problem found in a configuration file, with its position.
+type Diagnostic struct {
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Path    string `json:"path,omitempty"` // e.g. components[2].options.pattern
+	Message string `json:"message"`
+}
+
+// String formats the diagnostic as "line:column: message".
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: %s", d.Line, d.Column, d.Message)
+}
+
+// OptionKind is the YAML type expected for a component option.
+type OptionKind string
+
+// Option kinds, named as shown in diagnostics.
+const (
+	OptionInt    OptionKind = "inteiro"
+	OptionNumber OptionKind = "número"
+	OptionString OptionKind = "texto"
+	OptionBool   OptionKind = "booleano"
+	OptionItems  OptionKind = "lista de itens"
+
+	OptionValidators OptionKind = "validador ou lista de validadores"
+	OptionCommand    OptionKind = "lista com o programa e seus argumentos"
+)
+
+// componentOptions lists the options keys understood by each component type.
+var componentOptions = map[ComponentType]map[string]OptionKind{
+	TypeTextInput: {
+		"min_length":  OptionInt,
+		"max_length":  OptionInt,
+		"pattern":     OptionString,
+		"validate":    OptionValidators,
+		"mask":        OptionString,
+		"mask_output": OptionString,
+
+		"validate_command": OptionCommand,
+		"validate_timeout": OptionString,
+	},
+	TypeTextArea: {
+		"min_length": OptionInt,
+		"max_length": OptionInt,
+		"pattern":    OptionString,
+		"validate":   OptionValidators,
+		"height":     OptionInt,
+		"width":      OptionInt,
+
+		"validate_command": OptionCommand,
+		"validate_timeout": OptionString,
+	},
+	TypeCheckbox: {},
+	TypeRadioGroup: {
+		"items": OptionItems,
+	},
+	TypeSlider: {
+		"min":   OptionNumber,
+		"max":   OptionNumber,
+		"step":  OptionNumber,
+		"width": OptionInt,
+	},
+	TypeFilePicker: {
+		"filter":       OptionString,
+		"show_hidden":  OptionBool,
+		"max_history":  OptionInt,
+		"preview_mode": OptionBool,
+	},
+	TypeText: {},
+	TypeComputed: {
+		"decimals": OptionInt,
+	},
+}
+
+// ComponentOptions returns the options keys understood by a component type
+// and their kinds, or nil for an unknown type.
+func ComponentOptions(t ComponentType) map[string]OptionKind {
+	known, ok := componentOptions[t]
+	if !ok {
+		return nil
+	}
+	options := make(map[string]OptionKind, len(known))
+	for key, kind := range known {
+		options[key] = kind
+	}
+	return options
+}
+
+// ComponentTypes lists every valid component type.
+func ComponentTypes() []ComponentType {
+	return []ComponentType{
+		TypeTextInput, TypeTextArea, TypeCheckbox,
+		TypeRadioGroup, TypeSlider, TypeFilePicker, TypeText, TypeComputed,
+	}
+}
+
+// lineRe extracts the line number of yaml.v3 error messages.
+var lineRe = regexp.MustCompile(`line (\d+): `)
+
+// ValidateFile reads and checks a configuration file. See ValidateDocument.
+func ValidateFile(filePath, kind string) (string, []Diagnostic, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", nil, fmt.Errorf("erro ao ler o arquivo de configuração: %w", err)
+	}
+	return ValidateDocument(data, kind)
+}
+
+// ValidateDocument checks a configuration document and reports every problem
+// found, sorted by position. An empty kind is detected from the top-level keys.
+// It returns the kind that was checked.
+func ValidateDocument(data []byte, kind string) (string, []Diagnostic, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return kind, []Diagnostic{syntaxDiagnostic(err)}, nil
+	}
+
+	l := &linter{}
+	if len(doc.Content) == 0 {
+		l.report(&doc, "", "o arquivo de configuração está vazio")
+		return kind, l.diags, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		l.report(root, "", "a configuração deve ser um mapa de chaves")
+		return kind, l.diags, nil
+	}
+
+	if kind == "" {
+		kind = detectKind(root)
+	}
+
+	switch kind {
+	case KindForm:
+		l.decode(root, &FormConfig{})
+		l.form(root)
+	case KindLayout:
+		l.decode(root, &LayoutConfig{})
+		l.layout(root)
+	case KindMenu:
+		l.decode(root, &MenuConfig{})
+		l.menu(root)
+	case KindTabs:
+		l.decode(root, &TabsConfig{})
+		l.tabs(root)
+	default:
+		return kind, nil, fmt.Errorf("tipo de configuração inválido: %s", kind)
+	}
+
+	sort.SliceStable(l.diags, func(i, j int) bool {
+		if l.diags[i].Line != l.diags[j].Line {
+			return l.diags[i].Line < l.diags[j].Line
+		}
+		return l.diags[i].Column < l.diags[j].Column
+	})
+	return kind, l.diags, nil
+}
+
+// detectKind guesses the configuration kind from its top-level keys.
+func detectKind(root *yaml.Node) string {
+	switch {
+	case mappingValue(root, "tabs") != nil:
+		return KindTabs
+	case mappingValue(root, "items") != nil && mappingValue(root, "components") == nil:
+		return KindMenu
+	case mappingValue(root, "layout") != nil:
+		return KindLayout
+	default:
+		return KindForm
+	}
+}
+
+// syntaxDiagnostic converts a YAML parse error into a diagnostic.
+func syntaxDiagnostic(err error) Diagnostic {
+	d := Diagnostic{Line: 1, Column: 1, Message: strings.TrimPrefix(err.Error(), "yaml: ")}
+	if m := lineRe.FindStringSubmatch(d.Message); m != nil {
+		d.Line, _ = strconv.Atoi(m[1])
+		d.Message = strings.Replace(d.Message, m[0], "", 1)
+	}
+	return d
+}
+
+// linter collects the diagnostics of one document.
+type linter struct {
+	diags []Diagnostic
+}
+
+// report records a problem at the position of n.
+func (l *linter) report(n *yaml.Node, path, format string, args ...interface{}) {
+	l.diags = append(l.diags, Diagnostic{
+		Line:    n.Line,
+		Column:  n.Column,
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// decode decodes root into target to report type mismatches such as a
+// string where a number is expected.
+func (l *linter) decode(root *yaml.Node, target interface{}) {
+	err := root.Decode(target)
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		return
+	}
+	for _, msg := range typeErr.Errors {
+		d := Diagnostic{Line: root.Line, Column: 1, Message: msg}
+		if m := lineRe.FindStringSubmatch(msg); m != nil {
+			d.Line, _ = strconv.Atoi(m[1])
+			d.Column = valueColumn(root, d.Line)
+			d.Message = strings.Replace(msg, m[0], "", 1)
+		}
+		l.diags = append(l.diags, d)
+	}
+}
+
+// valueColumn returns the column of the last node on line, which is the
+// offending value of a "key: value" line. yaml.v3 type errors only carry lines.
+func valueColumn(n *yaml.Node, line int) int {
+	column := 1
+	if n.Line == line && n.Column > column {
+		column = n.Column
+	}
+	for _, child := range n.Content {
+		if c := valueColumn(child, line); c > column {
+			column = c
+		}
+	}
+	return column
+}
+
+// knownKeys reports keys of n that are not yaml fields of the struct type of v.
+func (l *linter) knownKeys(n *yaml.Node, path string, v interface{}) {
+	known := yamlKeys(reflect.TypeOf(v))
+	forEachPair(n, func(key, _ *yaml.Node) {
+		if !known[key.Value] {
+			l.report(key, joinPath(path, key.Value), "campo desconhecido: %s", key.Value)
+		}
+	})
+}
+
+// form checks a FormConfig document.
+func (l *linter) form(root *yaml.Node) {
+	l.knownKeys(root, "", FormConfig{})
+	l.runner(root)
+	names := l.components(root, "")
+	l.rules(root, "", "", names)
+}
+
+// layout checks a LayoutConfig document.
+func (l *linter) layout(root *yaml.Node) {
+	l.knownKeys(root, "", LayoutConfig{})
+	if n := mappingValue(root, "layout"); n == nil {
+		l.report(root, "layout", "layout é obrigatório ('horizontal' ou 'vertical')")
+	} else if n.Value != "horizontal" && n.Value != "vertical" {
+		l.report(n, "layout", "layout deve ser 'horizontal' ou 'vertical', recebido: %s", n.Value)
+	}
+	l.runner(root)
+	l.components(root, "")
+}
+
+// runner checks the timeout and display keys shared by forms and layouts.
+func (l *linter) runner(root *yaml.Node) {
+	if n := mappingValue(root, "timeout"); n != nil {
+		var timeout time.Duration
+		if n.Decode(&timeout) == nil {
+			if err := validateTimeout(timeout, ""); err != nil {
+				l.report(n, "timeout", "%v", err)
+			}
+		}
+	}
+	if n := mappingValue(root, "on_timeout"); n != nil {
+		if err := validateTimeout(0, n.Value); err != nil {
+			l.report(n, "on_timeout", "%v", err)
+		}
+	}
+	l.display(root)
+}
+
+// display checks the display key.
+func (l *linter) display(root *yaml.Node) {
+	if n := mappingValue(root, "display"); n != nil {
+		if err := validateDisplay(n.Value); err != nil {
+			l.report(n, "display", "%v", err)
+		}
+	}
+}
+
+// menu checks a MenuConfig document.
+func (l *linter) menu(root *yaml.Node) {
+	l.knownKeys(root, "", MenuConfig{})
+	items := mappingValue(root, "items")
+	if items == nil || items.Kind != yaml.SequenceNode || len(items.Content) == 0 {
+		l.report(nodeOr(items, root), "items", "o menu deve conter pelo menos um item")
+	}
+	if n := mappingValue(root, "page_size"); n != nil {
+		if size, err := strconv.Atoi(n.Value); err == nil && size < 0 {
+			l.report(n, "page_size", "page_size não pode ser negativo: %d", size)
+		}
+	}
+	l.display(root)
+}
+
+// tabs checks a TabsConfig document.
+func (l *linter) tabs(root *yaml.Node) {
+	l.knownKeys(root, "", TabsConfig{})
+	l.display(root)
+
+	tabs := mappingValue(root, "tabs")
+	if tabs == nil || tabs.Kind != yaml.SequenceNode || len(tabs.Content) == 0 {
+		l.report(nodeOr(tabs, root), "tabs", "a configuração deve conter pelo menos uma aba")
+		return
+	}
+
+	seen := make(map[string]int)
+	for i, tab := range tabs.Content {
+		path := fmt.Sprintf("tabs[%d]", i)
+		if tab.Kind != yaml.MappingNode {
+			l.report(tab, path, "aba %d deve ser um mapa de chaves", i)
+			continue
+		}
+		l.knownKeys(tab, path, TabConfig{})
+
+		name := mappingValue(tab, "name")
+		if name == nil || name.Value == "" {
+			l.report(nodeOr(name, tab), path+".name", "aba %d: nome é obrigatório", i)
+		} else if line, dup := seen[name.Value]; dup {
+			l.report(name, path+".name", "nome de aba duplicado: %s (primeira ocorrência na linha %d)", name.Value, line)
+		} else {
+			seen[name.Value] = name.Line
+		}
+		if label := mappingValue(tab, "label"); label == nil || label.Value == "" {
+			l.report(nodeOr(label, tab), path+".label", "aba %d: label é obrigatório", i)
+		}
+
+		l.components(tab, path)
+	}
+}
+
+// components checks the components list of parent and returns the names
+// found. Names must be unique within the list.
+func (l *linter) components(parent *yaml.Node, path string) map[string]bool {
+	path = joinPath(path, "components")
+	list := mappingValue(parent, "components")
+	if list == nil || list.Kind != yaml.SequenceNode || len(list.Content) == 0 {
+		l.report(nodeOr(list, parent), path, "a configuração deve conter pelo menos um componente")
+		return nil
+	}
+
+	seen := make(map[string]int)
+	for i, comp := range list.Content {
+		compPath := fmt.Sprintf("%s[%d]", path, i)
+		if comp.Kind != yaml.MappingNode {
+			l.report(comp, compPath, "componente %d deve ser um mapa de chaves", i)
+			continue
+		}
+		l.knownKeys(comp, compPath, ComponentConfig{})
+
+		name := mappingValue(comp, "name")
+		if name == nil || name.Value == "" {
+			l.report(nodeOr(name, comp), compPath+".name", "componente %d: nome do componente é obrigatório", i)
+		} else if line, dup := seen[name.Value]; dup {
+			l.report(name, compPath+".name", "nome de componente duplicado: %s (primeira ocorrência na linha %d)", name.Value, line)
+		} else {
+			seen[name.Value] = name.Line
+		}
+
+		typ := mappingValue(comp, "type")
+		if typ == nil {
+			l.report(comp, compPath+".type", "componente %d: tipo do componente é obrigatório", i)
+			continue
+		}
+		options, ok := componentOptions[ComponentType(typ.Value)]
+		if !ok {
+			l.report(typ, compPath+".type", "tipo de componente inválido: %s (válidos: %s)", typ.Value, typeList())
+			continue
+		}
+		l.options(comp, compPath, ComponentType(typ.Value), options)
+	}
+
+	// Rules may refer to any component of the list
+	names := make(map[string]bool, len(seen))
+	for name := range seen {
+		names[name] = true
+	}
+	order := make(map[string]int) // Position of each computed component
+	for i, comp := range list.Content {
+		if typ, name := mappingValue(comp, "type"), mappingValue(comp, "name"); typ != nil && name != nil && typ.Value == string(TypeComputed) {
+			order[name.Value] = i
+		}
+	}
+	for i, comp := range list.Content {
+		if name := mappingValue(comp, "name"); name != nil && comp.Kind == yaml.MappingNode {
+			l.rules(comp, fmt.Sprintf("%s[%d]", path, i), name.Value, names)
+		}
+		for _, key := range []string{"show_if", "enable_if"} {
+			l.expression(mappingValue(comp, key), fmt.Sprintf("%s[%d].%s", path, i, key), key, names)
+		}
+		l.compute(comp, fmt.Sprintf("%s[%d]", path, i), i, names, order)
+	}
+	return names
+}
+
+// compute checks the compute key of the component at index: required on
+// computed components and refused on the others. Computed components used
+// in the expression must be declared above.
+func (l *linter) compute(comp *yaml.Node, path string, index int, names map[string]bool, order map[string]int) {
+	typ := mappingValue(comp, "type")
+	if typ == nil {
+		return
+	}
+	n := mappingValue(comp, "compute")
+	if typ.Value != string(TypeComputed) {
+		if n != nil {
+			l.report(n, path+".compute", "compute só é permitido em componentes do tipo %s", TypeComputed)
+		}
+		return
+	}
+	if n == nil || n.Value == "" {
+		l.report(nodeOr(n, comp), path+".compute", "compute é obrigatório em componentes do tipo %s", TypeComputed)
+		return
+	}
+
+	path += ".compute"
+	l.expression(n, path, "compute", names)
+	if e, err := ParseExpression("compute", n.Value); err == nil {
+		if err := computeRefs(e, index, names, order); err != nil && conditionRefs("compute", e, names) == nil {
+			l.report(n, path, "%v", err)
+		}
+	}
+}
+
+// expression checks the expression held by n under key: syntax errors are
+// reported at their column, fields must be among names.
+func (l *linter) expression(n *yaml.Node, path, key string, names map[string]bool) {
+	if n == nil || n.Kind != yaml.ScalarNode {
+		return
+	}
+	e, err := ParseExpression(key, n.Value)
+	var exprErr *expr.Error
+	if errors.As(err, &exprErr) {
+		at := *n
+		at.Column = expressionColumn(n, exprErr.Pos)
+		l.report(&at, path, "%v", err)
+		return
+	}
+	if err := conditionRefs(key, e, names); err != nil {
+		l.report(n, path, "%v", err)
+	}
+}
+
+// expressionColumn returns the column of position pos of the expression
+// held by n, skipping the opening quote of quoted scalars.
+func expressionColumn(n *yaml.Node, pos int) int {
+	column := n.Column + pos - 1
+	if n.Style&(yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle) != 0 {
+		column++
+	}
+	return column
+}
+
+// rules checks the rules list of parent. Rules of a component (owner) apply
+// to it unless they name another field; every field referred to must be one
+// of names.
+func (l *linter) rules(parent *yaml.Node, path, owner string, names map[string]bool) {
+	path = joinPath(path, "rules")
+	list := mappingValue(parent, "rules")
+	if list == nil {
+		return
+	}
+	if list.Kind != yaml.SequenceNode {
+		l.report(list, path, "rules deve ser uma lista")
+		return
+	}
+
+	for i, item := range list.Content {
+		rulePath := fmt.Sprintf("%s[%d]", path, i)
+		if item.Kind != yaml.MappingNode {
+			l.report(item, rulePath, "regra %d deve ser um mapa de chaves", i)
+			continue
+		}
+		l.knownKeys(item, rulePath, Rule{})
+
+		var rule Rule
+		if item.Decode(&rule) != nil {
+			continue // Reported by decode
+		}
+		if rule.Type == RuleExpr && rule.Expr != "" {
+			l.expression(mappingValue(item, "expr"), rulePath+".expr", "expr", names)
+			if _, err := rule.Expression(); err != nil {
+				continue // Reported at its column
+			}
+		}
+		if rule.Field == "" && owner != "" && rule.Type != RuleOneOfRequired {
+			rule.Field = owner
+		}
+		if err := rule.Validate(); err != nil {
+			l.report(nodeOr(mappingValue(item, "type"), item), rulePath, "%v", err)
+			continue
+		}
+
+		for _, key := range []string{"field", "other"} {
+			if n := mappingValue(item, key); n != nil && !names[n.Value] {
+				l.report(n, rulePath+"."+key, "campo inexistente: %s", n.Value)
+			}
+		}
+		if fields := mappingValue(item, "fields"); fields != nil {
+			for j, n := range fields.Content {
+				if !names[n.Value] {
+					l.report(n, fmt.Sprintf("%s.fields[%d]", rulePath, j), "campo inexistente: %s", n.Value)
+				}
+			}
+		}
+	}
+}
+
+// options checks the options of a component of type typ.
+func (l *linter) options(comp *yaml.Node, path string, typ ComponentType, known map[string]OptionKind) {
+	path += ".options"
+	opts := mappingValue(comp, "options")
+	if opts == nil {
+		if typ == TypeRadioGroup {
+			l.report(comp, path+".items", "radiogroup deve conter pelo menos um item")
+		}
+		return
+	}
+	if opts.Kind != yaml.MappingNode {
+		l.report(opts, path, "options deve ser um mapa de chaves")
+		return
+	}
+
+	forEachPair(opts, func(key, value *yaml.Node) {
+		kind, ok := known[key.Value]
+		if !ok {
+			l.report(key, joinPath(path, key.Value), "opção desconhecida para %s: %s (válidas: %s)", typ, key.Value, optionList(known))
+			return
+		}
+		if !matchesKind(value, kind) {
+			l.report(value, joinPath(path, key.Value), "opção %s deve ser do tipo %s", key.Value, kind)
+		}
+	})
+
+	if pattern := mappingValue(opts, "pattern"); pattern != nil && pattern.Tag == "!!str" {
+		if _, err := regexp.Compile(pattern.Value); err != nil {
+			l.report(pattern, path+".pattern", "regex inválida em pattern: %v", err)
+		}
+	}
+	if validate := mappingValue(opts, "validate"); validate != nil && matchesKind(validate, OptionValidators) {
+		l.validators(validate, path+".validate")
+	}
+	l.validateCommand(opts, path)
+	if mask := mappingValue(opts, "mask"); mask != nil && mask.Tag == "!!str" {
+		if _, err := ExpandMask(mask.Value); err != nil {
+			l.report(mask, path+".mask", "%v", err)
+		}
+	}
+	if output := mappingValue(opts, "mask_output"); output != nil && output.Tag == "!!str" {
+		if err := ValidateMaskOutput(output.Value); err != nil {
+			l.report(output, path+".mask_output", "%v", err)
+		}
+	}
+
+	minLen, okMin := intOption(opts, "min_length")
+	maxLen, okMax := intOption(opts, "max_length")
+	if okMin && okMax && minLen > maxLen {
+		l.report(mappingValue(opts, "min_length"), path+".min_length", "min_length (%d) deve ser menor ou igual a max_length (%d)", minLen, maxLen)
+	}
+
+	switch typ {
+	case TypeSlider:
+		l.sliderRange(opts, path)
+	case TypeRadioGroup:
+		l.radioItems(opts, path)
+	}
+}
+
+// validators checks the names and ranges of options.validate, a single
+// validator or a list.
+func (l *linter) validators(n *yaml.Node, path string) {
+	if n.Kind != yaml.SequenceNode {
+		if _, err := ParseValidator(n.Value); err != nil {
+			l.report(n, path, "%v", err)
+		}
+		return
+	}
+	for i, item := range n.Content {
+		if _, err := ParseValidator(item.Value); err != nil {
+			l.report(item, fmt.Sprintf("%s[%d]", path, i), "%v", err)
+		}
+	}
+}
+
+// validateCommand checks options.validate_command and its timeout.
+func (l *linter) validateCommand(opts *yaml.Node, path string) {
+	command := mappingValue(opts, "validate_command")
+	if command != nil && command.Kind == yaml.SequenceNode {
+		if len(command.Content) == 0 || strings.TrimSpace(command.Content[0].Value) == "" {
+			l.report(command, path+".validate_command", "validate_command deve conter pelo menos o programa")
+		}
+	}
+
+	timeout := mappingValue(opts, "validate_timeout")
+	if timeout == nil || timeout.Tag != "!!str" {
+		return
+	}
+	if command == nil {
+		l.report(timeout, path+".validate_timeout", "validate_timeout requer validate_command")
+		return
+	}
+	if _, err := parseCommandTimeout(timeout.Value); err != nil {
+		l.report(timeout, path+".validate_timeout", "%v", err)
+	}
+}
+
+// sliderRange checks that min is below max, using the slider defaults
+// (0 and 100) for a missing bound.
+func (l *linter) sliderRange(opts *yaml.Node, path string) {
+	min, okMin := numberOption(opts, "min")
+	max, okMax := numberOption(opts, "max")
+	if !okMin && !okMax {
+		return
+	}
+	if !okMin {
+		min = 0
+	}
+	if !okMax {
+		max = 100
+	}
+	if min >= max {
+		at := mappingValue(opts, "min")
+		if at == nil {
+			at = mappingValue(opts, "max")
+		}
+		l.report(at, path+".min", "min (%s) deve ser menor que max (%s)", formatNumber(min), formatNumber(max))
+	}
+}
+
+// radioItems checks the items of a radiogroup: a list of {id, label} with
+// unique ids.
+func (l *linter) radioItems(opts *yaml.Node, path string) {
+	path += ".items"
+	items := mappingValue(opts, "items")
+	if items == nil || items.Kind != yaml.SequenceNode || len(items.Content) == 0 {
+		l.report(nodeOr(items, opts), path, "radiogroup deve conter pelo menos um item")
+		return
+	}
+
+	seen := make(map[string]int)
+	for i, item := range items.Content {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		if item.Kind != yaml.MappingNode {
+			l.report(item, itemPath, "item %d deve ter os campos 'id' e 'label'", i)
+			continue
+		}
+		for _, key := range []string{"id", "label"} {
+			if v := mappingValue(item, key); v == nil || v.Tag != "!!str" || v.Value == "" {
+				l.report(nodeOr(v, item), itemPath+"."+key, "item %d: campo '%s' deve ser um texto não vazio", i, key)
+			}
+		}
+		if id := mappingValue(item, "id"); id != nil && id.Value != "" {
+			if line, dup := seen[id.Value]; dup {
+				l.report(id, itemPath+".id", "id de item duplicado: %s (primeira ocorrência na linha %d)", id.Value, line)
+			} else {
+				seen[id.Value] = id.Line
+			}
+		}
+	}
+}
+
+// matchesKind reports whether the YAML node has the expected option type.
+func matchesKind(n *yaml.Node, kind OptionKind) bool {
+	switch kind {
+	case OptionInt:
+		return n.Tag == "!!int"
+	case OptionNumber:
+		return n.Tag == "!!int" || n.Tag == "!!float"
+	case OptionString:
+		return n.Tag == "!!str"
+	case OptionBool:
+		return n.Tag == "!!bool"
+	case OptionItems:
+		return n.Kind == yaml.SequenceNode
+	case OptionCommand:
+		if n.Kind != yaml.SequenceNode {
+			return false
+		}
+		for _, item := range n.Content {
+			if item.Tag != "!!str" {
+				return false
+			}
+		}
+		return true
+	case OptionValidators:
+		if n.Kind == yaml.SequenceNode {
+			for _, item := range n.Content {
+				if item.Tag != "!!str" {
+					return false
+				}
+			}
+			return true
+		}
+		return n.Tag == "!!str"
+	default:
+		return true
+	}
+}
+
+// intOption returns an integer option of opts.
+func intOption(opts *yaml.Node, key string) (int, bool) {
+	n := mappingValue(opts, key)
+	if n == nil || n.Tag != "!!int" {
+		return 0, false
+	}
+	var v int
+	if err := n.Decode(&v); err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+// numberOption returns a numeric option of opts.
+func numberOption(opts *yaml.Node, key string) (float64, bool) {
+	n := mappingValue(opts, key)
+	if n == nil || !matchesKind(n, OptionNumber) {
+		return 0, false
+	}
+	var v float64
+	if err := n.Decode(&v); err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+// mappingValue returns the value of key in the mapping node n, or nil.
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// forEachPair calls fn for every key/value pair of the mapping node n.
+func forEachPair(n *yaml.Node, fn func(key, value *yaml.Node)) {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		fn(n.Content[i], n.Content[i+1])
+	}
+}
+
+// nodeOr returns n, or fallback when n is nil, as the position of a report.
+func nodeOr(n, fallback *yaml.Node) *yaml.Node {
+	if n == nil {
+		return fallback
+	}
+	return n
+}
+
+// yamlKeys returns the yaml field names of a struct type.
+func yamlKeys(t reflect.Type) map[string]bool {
+	keys := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if name != "" && name != "-" {
+			keys[name] = true
+		}
+	}
+	return keys
+}
+
+// joinPath appends key to a dotted path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// typeList formats the valid component types for messages.
+func typeList() string {
+	types := ComponentTypes()
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t)
+	}
+	return strings.Join(names, ", ")
+}
+
+// optionList formats the options of a component type for messages.
+func optionList(known map[string]OptionKind) string {
+	if len(known) == 0 {
+		return "nenhuma"
+	}
+	names := make([]string, 0, len(known))
+	for name := range known {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// formatNumber formats a slider bound without trailing zeros.
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
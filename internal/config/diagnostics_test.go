@@ -0,0 +1,366 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDocument(t *testing.T) {
+	tests := []struct {
+		name     string
+		yaml     string
+		kind     string
+		wantKind string
+		want     []Diagnostic
+	}{
+		{
+			name: "valid form",
+			yaml: `title: Cadastro
+components:
+  - type: textinput
+    name: user
+    options:
+      pattern: "^[a-z]+$"
+  - type: slider
+    name: n
+    options:
+      min: 1
+      max: 10
+`,
+			wantKind: KindForm,
+		},
+		{
+			name: "unknown type and duplicate name",
+			yaml: `components:
+  - type: textinput
+    name: user
+  - type: widget
+    name: user
+`,
+			wantKind: KindForm,
+			want: []Diagnostic{
+				{Line: 4, Column: 11, Path: "components[1].type", Message: "tipo de componente inválido: widget (válidos: textinput, textarea, checkbox, radiogroup, slider, filepicker, text, computed)"},
+				{Line: 5, Column: 11, Path: "components[1].name", Message: "nome de componente duplicado: user (primeira ocorrência na linha 3)"},
+			},
+		},
+		{
+			name: "unknown option and bad pattern",
+			yaml: `components:
+  - type: textinput
+    name: user
+    options:
+      pattern: "[a-z"
+      colour: red
+`,
+			wantKind: KindForm,
+			want: []Diagnostic{
+				{Line: 5, Column: 16, Path: "components[0].options.pattern", Message: "regex inválida em pattern: error parsing regexp: missing closing ]: `[a-z`"},
+				{Line: 6, Column: 7, Path: "components[0].options.colour", Message: "opção desconhecida para textinput: colour (válidas: mask, mask_output, max_length, min_length, pattern, validate, validate_command, validate_timeout)"},
+			},
+		},
+		{
+			name: "unknown validator and bad range",
+			yaml: `components:
+  - type: textinput
+    name: port
+    options:
+      validate: [port, int:10..1]
+  - type: textarea
+    name: payload
+    options:
+      validate: yaml
+`,
+			wantKind: KindForm,
+			want: []Diagnostic{
+				{Line: 5, Column: 24, Path: "components[0].options.validate[1]", Message: "intervalo inválido em int:10..1: mínimo maior que o máximo"},
+				{Line: 9, Column: 17, Path: "components[1].options.validate", Message: "validador desconhecido: yaml (válidos: email, url, hostname, ipv4, ipv6, cidr, port, semver, uuid, int, float, json, duration, cron, path_exists, cpf, cnpj, cep, phone)"},
+			},
+		},
+		{
+			name: "mask without slots and bad output",
+			yaml: `components:
+  - type: textinput
+    name: doc
+    options:
+      mask: "--/--"
+      mask_output: digits
+`,
+			wantKind: KindForm,
+			want: []Diagnostic{
+				{Line: 5, Column: 13, Path: "components[0].options.mask", Message: `máscara sem posições editáveis: "--/--" (use #, A ou *, ou um dos nomes: cpf, cnpj, cep, phone)`},
+				{Line: 6, Column: 20, Path: "components[0].options.mask_output", Message: "mask_output deve ser 'masked' ou 'raw', recebido: digits"},
+			},
+		},
+		{
+			name: "validate command without program and bad timeout",
+			yaml: `components:
+  - type: textinput
+    name: user
+    options:
+      validate_command: []
+  - type: textarea
+    name: bio
+    options:
+      validate_command: ["./check.sh", "{{value}}"]
+      validate_timeout: rápido
+  - type: textinput
+    name: nick
+    options:
+      validate_timeout: 2s
+`,
+			wantKind: KindForm,
+			want: []Diagnostic{
+				{Line: 5, Column: 25, Path: "components[0].options.validate_command", Message: "validate_command deve conter pelo menos o programa"},
+				{Line: 10, Column: 25, Path: "components[1].options.validate_timeout", Message: "validate_timeout inválido: rápido (use uma duração positiva, por exemplo 10s)"},
+				{Line: 14, Column: 25, Path: "components[2].options.validate_timeout", Message: "validate_timeout requer validate_command"},
+			},
+		},
+		{
+			name: "conditions with syntax error and missing field",
+			yaml: `components:
+  - type: textinput
+    name: env
+  - type: textinput
+    name: region
+    show_if: "env = 'prod'"
+  - type: checkbox
+    name: tls
+    enable_if: envs == 'prod'
+`,
+			wantKind: KindForm,
+			want: []Diagnostic{
+				{Line: 6, Column: 19, Path: "components[1].show_if", Message: "show_if inválido: posição 5: caractere inesperado: '='"},
+				{Line: 9, Column: 16, Path: "components[2].enable_if", Message: "enable_if: campo inexistente: envs"},
+			},
+		},
+		{
+			name: "expr rules with unknown function and missing field",
+			yaml: `components:
+  - type: textinput
+    name: password
+    rules:
+      - type: expr
+        expr: "size(password) >= 8"
+  - type: textinput
+    name: confirm
+rules:
+  - type: expr
+    expr: confirm == pasword
+`,
+			wantKind: KindForm,
+			want: []Diagnostic{
+				{Line: 6, Column: 16, Path: "components[0].rules[0].expr", Message: "expr inválido: posição 1: função desconhecida: size (disponíveis: contains, if, len, lower, matches)"},
+				{Line: 11, Column: 11, Path: "rules[0].expr", Message: "expr: campo inexistente: pasword"},
+			},
+		},
+		{
+			name: "computed fields",
+			yaml: `components:
+  - type: computed
+    name: fqdn
+    compute: "host + '.' + domain"
+  - type: textinput
+    name: host
+    compute: "'x'"
+  - type: computed
+    name: url
+    compute: "'https://' + fqdn + path"
+  - type: computed
+    name: total
+`,
+			wantKind: KindForm,
+			want: []Diagnostic{
+				{Line: 4, Column: 14, Path: "components[0].compute", Message: "compute: campo inexistente: domain"},
+				{Line: 7, Column: 14, Path: "components[1].compute", Message: "compute só é permitido em componentes do tipo computed"},
+				{Line: 10, Column: 14, Path: "components[2].compute", Message: "compute: campo inexistente: path"},
+				{Line: 11, Column: 5, Path: "components[3].compute", Message: "compute é obrigatório em componentes do tipo computed"},
+			},
+		},
+		{
+			name: "slider min not below max",
+			yaml: `components:
+  - type: slider
+    name: n
+    options:
+      min: 10
+      max: 5
+`,
+			wantKind: KindForm,
+			want: []Diagnostic{
+				{Line: 5, Column: 12, Path: "components[0].options.min", Message: "min (10) deve ser menor que max (5)"},
+			},
+		},
+		{
+			name: "slider min above default max",
+			yaml: `components:
+  - type: slider
+    name: n
+    options:
+      min: 150
+`,
+			wantKind: KindForm,
+			want: []Diagnostic{
+				{Line: 5, Column: 12, Path: "components[0].options.min", Message: "min (150) deve ser menor que max (100)"},
+			},
+		},
+		{
+			name: "option of the wrong type",
+			yaml: `components:
+  - type: textarea
+    name: bio
+    options:
+      height: alta
+`,
+			wantKind: KindForm,
+			want: []Diagnostic{
+				{Line: 5, Column: 15, Path: "components[0].options.height", Message: "opção height deve ser do tipo inteiro"},
+			},
+		},
+		{
+			name: "unknown top-level key and bad display",
+			yaml: `titel: Cadastro
+display: tela
+components:
+  - type: checkbox
+    name: ok
+`,
+			wantKind: KindForm,
+			want: []Diagnostic{
+				{Line: 1, Column: 1, Path: "titel", Message: "campo desconhecido: titel"},
+				{Line: 2, Column: 10, Path: "display", Message: "display deve ser 'fullscreen' ou 'inline', recebido: tela"},
+			},
+		},
+		{
+			name: "radiogroup without items",
+			yaml: `components:
+  - type: radiogroup
+    name: plan
+`,
+			wantKind: KindForm,
+			want: []Diagnostic{
+				{Line: 2, Column: 5, Path: "components[0].options.items", Message: "radiogroup deve conter pelo menos um item"},
+			},
+		},
+		{
+			name: "rules with bad type and missing field",
+			yaml: `components:
+  - type: textinput
+    name: password
+  - type: textinput
+    name: confirm
+    rules:
+      - type: equals
+        other: pasword
+rules:
+  - type: matches
+    field: confirm
+  - type: one_of_required
+    fields: [password, phone]
+`,
+			wantKind: KindForm,
+			want: []Diagnostic{
+				{Line: 8, Column: 16, Path: "components[1].rules[0].other", Message: "campo inexistente: pasword"},
+				{Line: 10, Column: 11, Path: "rules[0]", Message: "tipo de regra inválido: matches (válidos: equals, not_equals, required_if, greater_than, one_of_required, date_after, expr)"},
+				{Line: 13, Column: 24, Path: "rules[1].fields[1]", Message: "campo inexistente: phone"},
+			},
+		},
+		{
+			name: "layout detected with bad direction",
+			yaml: `layout: diagonal
+components:
+  - type: checkbox
+    name: ok
+`,
+			wantKind: KindLayout,
+			want: []Diagnostic{
+				{Line: 1, Column: 9, Path: "layout", Message: "layout deve ser 'horizontal' ou 'vertical', recebido: diagonal"},
+			},
+		},
+		{
+			name:     "menu detected without items",
+			yaml:     "title: Menu\nitems: []\n",
+			wantKind: KindMenu,
+			want: []Diagnostic{
+				{Line: 2, Column: 8, Path: "items", Message: "o menu deve conter pelo menos um item"},
+			},
+		},
+		{
+			name: "tabs with duplicate names per tab",
+			yaml: `tabs:
+  - name: a
+    label: A
+    components:
+      - type: checkbox
+        name: ok
+  - name: a
+    components:
+      - type: checkbox
+        name: ok
+`,
+			wantKind: KindTabs,
+			want: []Diagnostic{
+				{Line: 7, Column: 5, Path: "tabs[1].label", Message: "aba 1: label é obrigatório"},
+				{Line: 7, Column: 11, Path: "tabs[1].name", Message: "nome de aba duplicado: a (primeira ocorrência na linha 2)"},
+			},
+		},
+		{
+			name: "type mismatch reported with position",
+			yaml: `components:
+  - type: checkbox
+    name: ok
+    required: talvez
+`,
+			wantKind: KindForm,
+			want: []Diagnostic{
+				{Line: 4, Column: 15, Message: "cannot unmarshal !!str `talvez` into bool"},
+			},
+		},
+		{
+			name:     "explicit kind",
+			yaml:     "items: [a]\n",
+			kind:     KindForm,
+			wantKind: KindForm,
+			want: []Diagnostic{
+				{Line: 1, Column: 1, Path: "items", Message: "campo desconhecido: items"},
+				{Line: 1, Column: 1, Path: "components", Message: "a configuração deve conter pelo menos um componente"},
+			},
+		},
+		{
+			name: "syntax error",
+			yaml: "components:\n  - type: [\n",
+			want: []Diagnostic{
+				{Line: 2, Column: 1, Message: "did not find expected node content"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, diags, err := ValidateDocument([]byte(tt.yaml), tt.kind)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantKind, kind)
+			assert.Equal(t, tt.want, diags)
+		})
+	}
+}
+
+func TestValidateDocument_InvalidKind(t *testing.T) {
+	_, _, err := ValidateDocument([]byte("title: x\n"), "wizard")
+	assert.Error(t, err)
+}
+
+func TestValidateFile_Examples(t *testing.T) {
+	for _, name := range []string{"simple-form.yaml", "horizontal-layout.yaml", "menu.yaml", "tabs-wizard.yaml"} {
+		t.Run(name, func(t *testing.T) {
+			_, diags, err := ValidateFile(filepath.Join("..", "..", "docs", "examples", name), "")
+			require.NoError(t, err)
+			assert.Empty(t, diags)
+		})
+	}
+
+	_, _, err := ValidateFile(filepath.Join(t.TempDir(), "missing.yaml"), "")
+	assert.Error(t, err)
+}
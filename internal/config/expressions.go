@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/helton/shantilly/internal/expr"
+)
+
+// ParseExpression parses the expression of a configuration key, nil when it
+// is empty. Syntax errors name the key and the position in the expression.
+func ParseExpression(key, src string) (*expr.Expr, error) {
+	if src == "" {
+		return nil, nil
+	}
+	e, err := expr.Parse(src)
+	if err != nil {
+		return nil, fmt.Errorf("%s inválido: %w", key, err)
+	}
+	return e, nil
+}
+
+// Validator parses custom_validator, the expression the submitted values
+// must satisfy. It returns nil when business validation is disabled or
+// declares no validator.
+func (b *BusinessValidation) Validator() (*expr.Expr, error) {
+	if !b.Enabled {
+		return nil, nil
+	}
+	return ParseExpression("custom_validator", b.CustomValidator)
+}
+
+// Validate checks that custom_validator parses.
+func (b *BusinessValidation) Validate() error {
+	_, err := b.Validator()
+	return err
+}
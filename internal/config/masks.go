@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Mask slots: any other character of a mask is a literal inserted as the
+// user types.
+const (
+	MaskDigit    = '#' // 0-9
+	MaskLetter   = 'A' // a letter
+	MaskAlphaNum = '*' // a letter or a digit
+)
+
+// Values accepted by mask_output.
+const (
+	MaskOutputMasked = "masked" // The formatted value, as shown (default)
+	MaskOutputRaw    = "raw"    // Only the characters typed into slots
+)
+
+// maskPresets are the masks that can be referred to by name. Alternatives
+// are separated by "|": the shortest one that fits the typed characters is
+// used, so phones switch from 8 to 9 digits as the user types.
+var maskPresets = map[string]string{
+	"cpf":   "###.###.###-##",
+	"cnpj":  "**.***.***/****-##",
+	"cep":   "#####-###",
+	"phone": "(##) ####-####|(##) #####-####",
+}
+
+// MaskPresetNames returns the names accepted in place of a mask.
+func MaskPresetNames() []string {
+	return []string{"cpf", "cnpj", "cep", "phone"}
+}
+
+// ExpandMask returns the alternatives of a mask or preset name, ordered by
+// the number of slots.
+func ExpandMask(mask string) ([]string, error) {
+	if preset, ok := maskPresets[mask]; ok {
+		mask = preset
+	}
+
+	alternatives := strings.Split(mask, "|")
+	slots := make([]int, len(alternatives))
+	for i, alt := range alternatives {
+		slots[i] = MaskSlots(alt)
+		if slots[i] == 0 {
+			return nil, fmt.Errorf("máscara sem posições editáveis: %q (use #, A ou *, ou um dos nomes: %s)", alt, strings.Join(MaskPresetNames(), ", "))
+		}
+		if i > 0 && slots[i] <= slots[i-1] {
+			return nil, fmt.Errorf("as alternativas da máscara %q devem estar em ordem crescente de tamanho", mask)
+		}
+	}
+	return alternatives, nil
+}
+
+// MaskSlots counts the editable positions of a mask alternative.
+func MaskSlots(mask string) int {
+	n := 0
+	for _, r := range mask {
+		if r == MaskDigit || r == MaskLetter || r == MaskAlphaNum {
+			n++
+		}
+	}
+	return n
+}
+
+// ValidateMaskOutput checks the value of mask_output.
+func ValidateMaskOutput(output string) error {
+	switch output {
+	case "", MaskOutputMasked, MaskOutputRaw:
+		return nil
+	default:
+		return fmt.Errorf("mask_output deve ser '%s' ou '%s', recebido: %s", MaskOutputMasked, MaskOutputRaw, output)
+	}
+}
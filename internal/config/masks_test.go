@@ -0,0 +1,50 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandMask(t *testing.T) {
+	tests := []struct {
+		mask    string
+		want    []string
+		wantErr string
+	}{
+		{mask: "###.###.###-##", want: []string{"###.###.###-##"}},
+		{mask: "cpf", want: []string{"###.###.###-##"}},
+		{mask: "phone", want: []string{"(##) ####-####", "(##) #####-####"}},
+		{mask: "AAA-#*##", want: []string{"AAA-#*##"}},
+		{mask: "(..)", wantErr: "máscara sem posições editáveis"},
+		{mask: "#####|###", wantErr: "ordem crescente de tamanho"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mask, func(t *testing.T) {
+			got, err := ExpandMask(tt.mask)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMaskPresetsExpand(t *testing.T) {
+	for _, name := range MaskPresetNames() {
+		_, err := ExpandMask(name)
+		assert.NoError(t, err, name)
+	}
+}
+
+func TestValidateMaskOutput(t *testing.T) {
+	assert.NoError(t, ValidateMaskOutput(""))
+	assert.NoError(t, ValidateMaskOutput(MaskOutputMasked))
+	assert.NoError(t, ValidateMaskOutput(MaskOutputRaw))
+	assert.ErrorContains(t, ValidateMaskOutput("digits"), "mask_output deve ser 'masked' ou 'raw'")
+}
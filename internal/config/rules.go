@@ -0,0 +1,136 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/helton/shantilly/internal/expr"
+)
+
+// Rule types accepted in rules.
+const (
+	RuleEquals        = "equals"          // field must equal other (or value)
+	RuleNotEquals     = "not_equals"      // field must differ from other (or value)
+	RuleRequiredIf    = "required_if"     // field is required when other equals value (or is filled)
+	RuleGreaterThan   = "greater_than"    // field must be numerically greater than other (or value)
+	RuleOneOfRequired = "one_of_required" // at least one of fields must be filled
+	RuleDateAfter     = "date_after"      // field must be a date after other (or value, "today" allowed)
+	RuleExpr          = "expr"            // expr must hold, reported on field (or the first field of expr)
+)
+
+// RuleTypes returns the accepted rule types, in documentation order.
+func RuleTypes() []string {
+	return []string{RuleEquals, RuleNotEquals, RuleRequiredIf, RuleGreaterThan, RuleOneOfRequired, RuleDateAfter, RuleExpr}
+}
+
+// Rule is a declarative cross-field validation rule. Rules are declared in
+// the rules section of a form, or under a component, in which case field
+// defaults to that component.
+type Rule struct {
+	Type    string      `yaml:"type"`
+	Field   string      `yaml:"field,omitempty"`   // Field that receives the error
+	Other   string      `yaml:"other,omitempty"`   // Field compared against
+	Value   interface{} `yaml:"value,omitempty"`   // Literal compared against when other is empty
+	Fields  []string    `yaml:"fields,omitempty"`  // Candidates of one_of_required
+	Expr    string      `yaml:"expr,omitempty"`    // Expression of the expr rule
+	Message string      `yaml:"message,omitempty"` // Replaces the default error message
+}
+
+// Validate checks the shape of the rule: its type and the keys that type needs.
+func (r *Rule) Validate() error {
+	switch r.Type {
+	case RuleEquals, RuleNotEquals, RuleGreaterThan, RuleDateAfter:
+		if r.Field == "" {
+			return fmt.Errorf("regra %s: field é obrigatório", r.Type)
+		}
+		if r.Other == "" && r.Value == nil {
+			return fmt.Errorf("regra %s: informe other ou value", r.Type)
+		}
+	case RuleRequiredIf:
+		if r.Field == "" {
+			return fmt.Errorf("regra %s: field é obrigatório", r.Type)
+		}
+		if r.Other == "" {
+			return fmt.Errorf("regra %s: other é obrigatório", r.Type)
+		}
+	case RuleOneOfRequired:
+		if len(r.Fields) < 2 {
+			return fmt.Errorf("regra %s: fields deve conter pelo menos dois campos", r.Type)
+		}
+	case RuleExpr:
+		e, err := r.Expression()
+		if err != nil {
+			return fmt.Errorf("regra %s: %w", r.Type, err)
+		}
+		if e == nil {
+			return fmt.Errorf("regra %s: expr é obrigatório", r.Type)
+		}
+		if r.Field == "" && len(e.Fields()) == 0 {
+			return fmt.Errorf("regra %s: informe field ou use algum campo em expr", r.Type)
+		}
+	default:
+		return fmt.Errorf("tipo de regra inválido: %s (válidos: %s)", r.Type, strings.Join(RuleTypes(), ", "))
+	}
+	return nil
+}
+
+// Expression parses the expression of an expr rule, nil when it has none.
+func (r *Rule) Expression() (*expr.Expr, error) {
+	return ParseExpression("expr", r.Expr)
+}
+
+// Refs returns the fields the rule refers to, including the fields used in
+// the expression of an expr rule.
+func (r *Rule) Refs() []string {
+	var refs []string
+	if r.Field != "" {
+		refs = append(refs, r.Field)
+	}
+	if r.Other != "" {
+		refs = append(refs, r.Other)
+	}
+	refs = append(refs, r.Fields...)
+	if e, err := r.Expression(); err == nil && e != nil {
+		for _, field := range e.Fields() {
+			if field != r.Field {
+				refs = append(refs, field)
+			}
+		}
+	}
+	return refs
+}
+
+// CollectRules returns the rules of a list of components followed by the
+// extra rules, with field defaulting to the component that declares it.
+func CollectRules(comps []ComponentConfig, extra []Rule) []Rule {
+	var rules []Rule
+	for _, comp := range comps {
+		for _, rule := range comp.Rules {
+			if rule.Field == "" && rule.Type != RuleOneOfRequired {
+				rule.Field = comp.Name
+			}
+			rules = append(rules, rule)
+		}
+	}
+	return append(rules, extra...)
+}
+
+// validateRules checks every rule and that the fields it refers to exist.
+func validateRules(rules []Rule, comps []ComponentConfig) error {
+	names := make(map[string]bool, len(comps))
+	for _, comp := range comps {
+		names[comp.Name] = true
+	}
+
+	for i, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("erro na regra %d: %w", i, err)
+		}
+		for _, ref := range rule.Refs() {
+			if !names[ref] {
+				return fmt.Errorf("erro na regra %d: campo inexistente: %s", i, ref)
+			}
+		}
+	}
+	return nil
+}
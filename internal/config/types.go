@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -17,7 +18,8 @@ const (
 	TypeRadioGroup ComponentType = "radiogroup"
 	TypeSlider     ComponentType = "slider"
 	TypeFilePicker ComponentType = "filepicker"
-	TypeText       ComponentType = "text" // Static label
+	TypeText       ComponentType = "text"     // Static label
+	TypeComputed   ComponentType = "computed" // Read-only value derived from compute
 )
 
 // ComponentConfig represents the declarative configuration for a single component.
@@ -31,6 +33,10 @@ type ComponentConfig struct {
 	Required    bool                   `yaml:"required,omitempty"`
 	Help        string                 `yaml:"help,omitempty"`
 	Options     map[string]interface{} `yaml:"options,omitempty"`
+	Rules       []Rule                 `yaml:"rules,omitempty"`     // Cross-field rules, field defaults to this component
+	ShowIf      string                 `yaml:"show_if,omitempty"`   // Expression; the component is hidden while false
+	EnableIf    string                 `yaml:"enable_if,omitempty"` // Expression; the component is read-only while false
+	Compute     string                 `yaml:"compute,omitempty"`   // Expression giving the value of a computed component
 }
 
 // Validate performs validation on the ComponentConfig.
@@ -40,13 +46,8 @@ func (c *ComponentConfig) Validate() error {
 		return fmt.Errorf("nome do componente é obrigatório")
 	}
 
-	validTypes := []ComponentType{
-		TypeTextInput, TypeTextArea, TypeCheckbox,
-		TypeRadioGroup, TypeSlider, TypeFilePicker, TypeText,
-	}
-
 	valid := false
-	for _, t := range validTypes {
+	for _, t := range ComponentTypes() {
 		if c.Type == t {
 			valid = true
 			break
@@ -60,11 +61,52 @@ func (c *ComponentConfig) Validate() error {
 	return nil
 }
 
+// Actions accepted by on_timeout.
+const (
+	TimeoutSubmitDefaults = "submit_defaults" // Submit the current values if they are valid
+	TimeoutCancel         = "cancel"          // Quit without a result (default)
+)
+
+// validateTimeout checks the timeout and on_timeout keys shared by forms and layouts.
+func validateTimeout(timeout time.Duration, onTimeout string) error {
+	if timeout < 0 {
+		return fmt.Errorf("timeout não pode ser negativo: %s", timeout)
+	}
+
+	switch onTimeout {
+	case "", TimeoutSubmitDefaults, TimeoutCancel:
+		return nil
+	default:
+		return fmt.Errorf("on_timeout deve ser '%s' ou '%s', recebido: %s", TimeoutSubmitDefaults, TimeoutCancel, onTimeout)
+	}
+}
+
+// Values accepted by display.
+const (
+	DisplayFullscreen = "fullscreen" // Alternate screen, the default
+	DisplayInline     = "inline"     // Below the prompt, leaving a summary in the scrollback
+)
+
+// validateDisplay checks the display key shared by every runner configuration.
+func validateDisplay(display string) error {
+	switch display {
+	case "", DisplayFullscreen, DisplayInline:
+		return nil
+	default:
+		return fmt.Errorf("display deve ser '%s' ou '%s', recebido: %s", DisplayFullscreen, DisplayInline, display)
+	}
+}
+
 // FormConfig represents the complete form configuration with multiple components.
 type FormConfig struct {
 	Title       string            `yaml:"title,omitempty"`
 	Description string            `yaml:"description,omitempty"`
 	Components  []ComponentConfig `yaml:"components"`
+	Timeout     time.Duration     `yaml:"timeout,omitempty"`     // e.g. "30s"; zero waits forever
+	OnTimeout   string            `yaml:"on_timeout,omitempty"`  // submit_defaults or cancel
+	Display     string            `yaml:"display,omitempty"`     // fullscreen or inline
+	Rules       []Rule            `yaml:"rules,omitempty"`       // Cross-field validation rules
+	OmitHidden  bool              `yaml:"omit_hidden,omitempty"` // Leave hidden components out of the result
 }
 
 // Validate performs validation on the FormConfig.
@@ -73,6 +115,13 @@ func (f *FormConfig) Validate() error {
 		return fmt.Errorf("a configuração deve conter pelo menos um componente")
 	}
 
+	if err := validateTimeout(f.Timeout, f.OnTimeout); err != nil {
+		return err
+	}
+	if err := validateDisplay(f.Display); err != nil {
+		return err
+	}
+
 	// Validate each component
 	for i, comp := range f.Components {
 		if err := comp.Validate(); err != nil {
@@ -89,7 +138,13 @@ func (f *FormConfig) Validate() error {
 		names[comp.Name] = true
 	}
 
-	return nil
+	if err := validateConditions(f.Components); err != nil {
+		return err
+	}
+	if err := validateComputed(f.Components); err != nil {
+		return err
+	}
+	return validateRules(CollectRules(f.Components, f.Rules), f.Components)
 }
 
 // LayoutConfig represents a layout configuration with positioned components.
@@ -98,6 +153,10 @@ type LayoutConfig struct {
 	Description string            `yaml:"description,omitempty"`
 	Layout      string            `yaml:"layout"` // "horizontal" or "vertical"
 	Components  []ComponentConfig `yaml:"components"`
+	Timeout     time.Duration     `yaml:"timeout,omitempty"`     // e.g. "30s"; zero waits forever
+	OnTimeout   string            `yaml:"on_timeout,omitempty"`  // submit_defaults or cancel
+	Display     string            `yaml:"display,omitempty"`     // fullscreen or inline
+	OmitHidden  bool              `yaml:"omit_hidden,omitempty"` // Leave hidden components out of the result
 }
 
 // Validate performs validation on the LayoutConfig.
@@ -110,13 +169,35 @@ func (l *LayoutConfig) Validate() error {
 		return fmt.Errorf("a configuração deve conter pelo menos um componente")
 	}
 
+	if err := validateTimeout(l.Timeout, l.OnTimeout); err != nil {
+		return err
+	}
+	if err := validateDisplay(l.Display); err != nil {
+		return err
+	}
+
 	for i, comp := range l.Components {
 		if err := comp.Validate(); err != nil {
 			return fmt.Errorf("erro no componente %d: %w", i, err)
 		}
 	}
 
-	return nil
+	// Check for duplicate component names
+	names := make(map[string]bool)
+	for _, comp := range l.Components {
+		if names[comp.Name] {
+			return fmt.Errorf("nome de componente duplicado: %s", comp.Name)
+		}
+		names[comp.Name] = true
+	}
+
+	if err := validateConditions(l.Components); err != nil {
+		return err
+	}
+	if err := validateComputed(l.Components); err != nil {
+		return err
+	}
+	return validateRules(CollectRules(l.Components, nil), l.Components)
 }
 
 // MenuConfig represents a menu/list selection configuration.
@@ -125,6 +206,8 @@ type MenuConfig struct {
 	Description string   `yaml:"description,omitempty"`
 	Items       []string `yaml:"items"`
 	MultiSelect bool     `yaml:"multi_select,omitempty"`
+	PageSize    int      `yaml:"page_size,omitempty"`
+	Display     string   `yaml:"display,omitempty"` // fullscreen or inline
 }
 
 // Validate performs validation on the MenuConfig.
@@ -132,7 +215,10 @@ func (m *MenuConfig) Validate() error {
 	if len(m.Items) == 0 {
 		return fmt.Errorf("o menu deve conter pelo menos um item")
 	}
-	return nil
+	if m.PageSize < 0 {
+		return fmt.Errorf("page_size não pode ser negativo: %d", m.PageSize)
+	}
+	return validateDisplay(m.Display)
 }
 
 // TabConfig represents a single tab configuration.
@@ -144,8 +230,10 @@ type TabConfig struct {
 
 // TabsConfig represents a tabs configuration with multiple tabs.
 type TabsConfig struct {
-	Title string      `yaml:"title,omitempty"`
-	Tabs  []TabConfig `yaml:"tabs"`
+	Title      string      `yaml:"title,omitempty"`
+	Tabs       []TabConfig `yaml:"tabs"`
+	Display    string      `yaml:"display,omitempty"`     // fullscreen or inline
+	OmitHidden bool        `yaml:"omit_hidden,omitempty"` // Leave hidden components out of the result
 }
 
 // Validate performs validation on the TabsConfig.
@@ -154,6 +242,12 @@ func (t *TabsConfig) Validate() error {
 		return fmt.Errorf("a configuração deve conter pelo menos uma aba")
 	}
 
+	if err := validateDisplay(t.Display); err != nil {
+		return err
+	}
+
+	// Tab names are the keys of the nested output, so they must be unique
+	tabNames := make(map[string]bool)
 	for i, tab := range t.Tabs {
 		if tab.Name == "" {
 			return fmt.Errorf("aba %d: nome é obrigatório", i)
@@ -161,11 +255,31 @@ func (t *TabsConfig) Validate() error {
 		if tab.Label == "" {
 			return fmt.Errorf("aba %d: label é obrigatório", i)
 		}
+		if tabNames[tab.Name] {
+			return fmt.Errorf("nome de aba duplicado: %s", tab.Name)
+		}
+		tabNames[tab.Name] = true
 
+		names := make(map[string]bool)
 		for j, comp := range tab.Components {
 			if err := comp.Validate(); err != nil {
 				return fmt.Errorf("aba %d, componente %d: %w", i, j, err)
 			}
+			if names[comp.Name] {
+				return fmt.Errorf("aba %s: nome de componente duplicado: %s", tab.Name, comp.Name)
+			}
+			names[comp.Name] = true
+		}
+
+		// Rules of a component refer to fields of the same tab
+		if err := validateRules(CollectRules(tab.Components, nil), tab.Components); err != nil {
+			return fmt.Errorf("aba %s: %w", tab.Name, err)
+		}
+		if err := validateConditions(tab.Components); err != nil {
+			return fmt.Errorf("aba %s: %w", tab.Name, err)
+		}
+		if err := validateComputed(tab.Components); err != nil {
+			return fmt.Errorf("aba %s: %w", tab.Name, err)
 		}
 	}
 
@@ -213,15 +327,25 @@ func LoadLayoutConfig(filePath string) (*LayoutConfig, error) {
 
 // LoadMenuConfig loads and validates a MenuConfig from a YAML file.
 func LoadMenuConfig(filePath string) (*MenuConfig, error) {
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao ler o arquivo de configuração: %w", err)
-	}
+	return LoadMenuConfigWithItems(filePath, nil)
+}
 
+// LoadMenuConfigWithItems loads a MenuConfig, appends extra items (such as
+// lines piped on stdin) and validates the result.
+// An empty filePath starts from an empty menu.
+func LoadMenuConfigWithItems(filePath string, items []string) (*MenuConfig, error) {
 	var config MenuConfig
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("erro ao analisar o YAML de configuração: %w", err)
+	if filePath != "" {
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler o arquivo de configuração: %w", err)
+		}
+
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("erro ao analisar o YAML de configuração: %w", err)
+		}
 	}
+	config.Items = append(config.Items, items...)
 
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("erro de validação da configuração: %w", err)
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -93,6 +94,169 @@ func TestFormConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "duplicado",
 		},
+		{
+			name: "valid conditions",
+			config: FormConfig{
+				Components: []ComponentConfig{
+					{Type: TypeTextInput, Name: "env"},
+					{Type: TypeTextInput, Name: "region", ShowIf: "env == 'prod'", EnableIf: "env != ''"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "condition with syntax error",
+			config: FormConfig{
+				Components: []ComponentConfig{
+					{Type: TypeTextInput, Name: "env"},
+					{Type: TypeTextInput, Name: "region", ShowIf: "env == "},
+				},
+			},
+			wantErr: true,
+			errMsg:  "componente region: show_if inválido: posição 8: fim inesperado da expressão",
+		},
+		{
+			name: "condition on missing field",
+			config: FormConfig{
+				Components: []ComponentConfig{
+					{Type: TypeTextInput, Name: "region", EnableIf: "env == 'prod'"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "componente region: enable_if: campo inexistente: env",
+		},
+		{
+			name: "timeout with action",
+			config: FormConfig{
+				Components: []ComponentConfig{{Type: TypeTextInput, Name: "field1"}},
+				Timeout:    30 * time.Second,
+				OnTimeout:  TimeoutSubmitDefaults,
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative timeout",
+			config: FormConfig{
+				Components: []ComponentConfig{{Type: TypeTextInput, Name: "field1"}},
+				Timeout:    -time.Second,
+			},
+			wantErr: true,
+			errMsg:  "timeout não pode ser negativo",
+		},
+		{
+			name: "unknown timeout action",
+			config: FormConfig{
+				Components: []ComponentConfig{{Type: TypeTextInput, Name: "field1"}},
+				Timeout:    time.Second,
+				OnTimeout:  "retry",
+			},
+			wantErr: true,
+			errMsg:  "on_timeout deve ser",
+		},
+		{
+			name: "rules on the form and on a component",
+			config: FormConfig{
+				Components: []ComponentConfig{
+					{Type: TypeTextInput, Name: "password"},
+					{Type: TypeTextInput, Name: "confirm", Rules: []Rule{{Type: RuleEquals, Other: "password"}}},
+				},
+				Rules: []Rule{{Type: RuleOneOfRequired, Fields: []string{"password", "confirm"}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown rule type",
+			config: FormConfig{
+				Components: []ComponentConfig{{Type: TypeTextInput, Name: "field1"}},
+				Rules:      []Rule{{Type: "matches", Field: "field1"}},
+			},
+			wantErr: true,
+			errMsg:  "tipo de regra inválido: matches",
+		},
+		{
+			name: "rule without a comparison",
+			config: FormConfig{
+				Components: []ComponentConfig{{Type: TypeTextInput, Name: "field1", Rules: []Rule{{Type: RuleGreaterThan}}}},
+			},
+			wantErr: true,
+			errMsg:  "informe other ou value",
+		},
+		{
+			name: "rule on a missing field",
+			config: FormConfig{
+				Components: []ComponentConfig{{Type: TypeTextInput, Name: "field1"}},
+				Rules:      []Rule{{Type: RuleRequiredIf, Field: "field1", Other: "field2"}},
+			},
+			wantErr: true,
+			errMsg:  "campo inexistente: field2",
+		},
+		{
+			name: "expr rule",
+			config: FormConfig{
+				Components: []ComponentConfig{
+					{Type: TypeTextInput, Name: "min"},
+					{Type: TypeTextInput, Name: "max", Rules: []Rule{{Type: RuleExpr, Expr: "max > min"}}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "expr rule with a syntax error",
+			config: FormConfig{
+				Components: []ComponentConfig{{Type: TypeTextInput, Name: "field1"}},
+				Rules:      []Rule{{Type: RuleExpr, Expr: "len(field1) >"}},
+			},
+			wantErr: true,
+			errMsg:  "regra expr: expr inválido: posição 14: fim inesperado da expressão",
+		},
+		{
+			name: "expr rule on a missing field",
+			config: FormConfig{
+				Components: []ComponentConfig{{Type: TypeTextInput, Name: "field1"}},
+				Rules:      []Rule{{Type: RuleExpr, Expr: "field1 != field2"}},
+			},
+			wantErr: true,
+			errMsg:  "campo inexistente: field2",
+		},
+		{
+			name: "computed fields",
+			config: FormConfig{
+				Components: []ComponentConfig{
+					{Type: TypeTextInput, Name: "host"},
+					{Type: TypeComputed, Name: "fqdn", Compute: "host + '.example.com'"},
+					{Type: TypeComputed, Name: "url", Compute: "'https://' + fqdn"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "computed field used above its declaration",
+			config: FormConfig{
+				Components: []ComponentConfig{
+					{Type: TypeComputed, Name: "url", Compute: "'https://' + fqdn"},
+					{Type: TypeComputed, Name: "fqdn", Compute: "'example.com'"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "componente url: compute: fqdn deve ser declarado antes deste campo",
+		},
+		{
+			name: "computed field using itself",
+			config: FormConfig{
+				Components: []ComponentConfig{{Type: TypeComputed, Name: "n", Compute: "n + 1"}},
+			},
+			wantErr: true,
+			errMsg:  "compute não pode usar o próprio campo",
+		},
+		{
+			name: "one_of_required with a single field",
+			config: FormConfig{
+				Components: []ComponentConfig{{Type: TypeTextInput, Name: "field1"}},
+				Rules:      []Rule{{Type: RuleOneOfRequired, Fields: []string{"field1"}}},
+			},
+			wantErr: true,
+			errMsg:  "pelo menos dois campos",
+		},
 	}
 
 	for _, tt := range tests {
@@ -113,6 +277,7 @@ func TestLoadFormConfig(t *testing.T) {
 	tmpDir := t.TempDir()
 	validYAML := `
 title: "Test Form"
+timeout: 1m30s
 components:
   - type: textinput
     name: username
@@ -153,6 +318,7 @@ components:
 				require.NoError(t, err)
 				assert.NotNil(t, cfg)
 				assert.Equal(t, "Test Form", cfg.Title)
+				assert.Equal(t, 90*time.Second, cfg.Timeout)
 				assert.Len(t, cfg.Components, 1)
 			}
 		})
@@ -232,6 +398,23 @@ invalid_field: true
 	assert.Nil(t, cfg)
 }
 
+func TestLoadMenuConfigWithItems(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "menu.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("title: \"Branch\"\n"), 0600))
+
+	cfg, err := LoadMenuConfigWithItems(path, []string{"main", "develop"})
+	require.NoError(t, err)
+	assert.Equal(t, "Branch", cfg.Title)
+	assert.Equal(t, []string{"main", "develop"}, cfg.Items)
+
+	cfg, err = LoadMenuConfigWithItems("", []string{"a"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, cfg.Items)
+
+	_, err = LoadMenuConfig(path)
+	assert.ErrorContains(t, err, "pelo menos um item")
+}
+
 func TestLoadTabsConfig_FileNotFound(t *testing.T) {
 	invalidPath := filepath.Join(t.TempDir(), "nonexistent.yaml")
 
@@ -309,6 +492,18 @@ func TestLayoutConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "pelo menos um componente",
 		},
+		{
+			name: "duplicate component names",
+			config: LayoutConfig{
+				Layout: "vertical",
+				Components: []ComponentConfig{
+					{Type: TypeTextInput, Name: "field1"},
+					{Type: TypeTextInput, Name: "field1"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "nome de componente duplicado",
+		},
 	}
 
 	for _, tt := range tests {
@@ -348,6 +543,32 @@ func TestMenuConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "pelo menos um item",
 		},
+		{
+			name: "negative page size",
+			config: MenuConfig{
+				Items:    []string{"Option 1"},
+				PageSize: -1,
+			},
+			wantErr: true,
+			errMsg:  "page_size",
+		},
+		{
+			name: "inline display",
+			config: MenuConfig{
+				Items:   []string{"Option 1"},
+				Display: DisplayInline,
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown display",
+			config: MenuConfig{
+				Items:   []string{"Option 1"},
+				Display: "popup",
+			},
+			wantErr: true,
+			errMsg:  "display deve ser",
+		},
 	}
 
 	for _, tt := range tests {
@@ -407,6 +628,34 @@ func TestTabsConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "nome é obrigatório",
 		},
+		{
+			name: "duplicate tab names",
+			config: TabsConfig{
+				Tabs: []TabConfig{
+					{Name: "tab1", Label: "Tab 1"},
+					{Name: "tab1", Label: "Tab 1 again"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "nome de aba duplicado",
+		},
+		{
+			name: "duplicate component names in a tab",
+			config: TabsConfig{
+				Tabs: []TabConfig{
+					{
+						Name:  "tab1",
+						Label: "Tab 1",
+						Components: []ComponentConfig{
+							{Type: TypeTextInput, Name: "field1"},
+							{Type: TypeCheckbox, Name: "field1"},
+						},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "nome de componente duplicado",
+		},
 	}
 
 	for _, tt := range tests {
@@ -421,3 +670,20 @@ func TestTabsConfig_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestCollectRules(t *testing.T) {
+	comps := []ComponentConfig{
+		{Name: "start"},
+		{Name: "end", Rules: []Rule{
+			{Type: RuleDateAfter, Other: "start"},
+			{Type: RuleRequiredIf, Field: "start", Other: "end"},
+		}},
+	}
+	extra := []Rule{{Type: RuleOneOfRequired, Fields: []string{"start", "end"}}}
+
+	assert.Equal(t, []Rule{
+		{Type: RuleDateAfter, Field: "end", Other: "start"},
+		{Type: RuleRequiredIf, Field: "start", Other: "end"},
+		{Type: RuleOneOfRequired, Fields: []string{"start", "end"}},
+	}, CollectRules(comps, extra))
+}
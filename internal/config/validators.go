@@ -0,0 +1,136 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Named validators accepted in options.validate.
+const (
+	ValidatorEmail      = "email"
+	ValidatorURL        = "url"
+	ValidatorHostname   = "hostname"
+	ValidatorIPv4       = "ipv4"
+	ValidatorIPv6       = "ipv6"
+	ValidatorCIDR       = "cidr"
+	ValidatorPort       = "port"
+	ValidatorSemver     = "semver"
+	ValidatorUUID       = "uuid"
+	ValidatorInt        = "int"   // Accepts a range, e.g. int:1..10
+	ValidatorFloat      = "float" // Accepts a range, e.g. float:0..1.5
+	ValidatorJSON       = "json"
+	ValidatorDuration   = "duration"
+	ValidatorCron       = "cron"
+	ValidatorPathExists = "path_exists"
+
+	// Brazilian documents, checked with or without punctuation
+	ValidatorCPF   = "cpf"   // Check digits of the CPF
+	ValidatorCNPJ  = "cnpj"  // Check digits of the CNPJ, numeric or alphanumeric
+	ValidatorCEP   = "cep"   // Eight-digit postal code
+	ValidatorPhone = "phone" // Area code and landline or mobile number
+)
+
+// ValidatorNames returns the accepted validator names, in documentation order.
+func ValidatorNames() []string {
+	return []string{
+		ValidatorEmail, ValidatorURL, ValidatorHostname, ValidatorIPv4, ValidatorIPv6,
+		ValidatorCIDR, ValidatorPort, ValidatorSemver, ValidatorUUID, ValidatorInt,
+		ValidatorFloat, ValidatorJSON, ValidatorDuration, ValidatorCron, ValidatorPathExists,
+		ValidatorCPF, ValidatorCNPJ, ValidatorCEP, ValidatorPhone,
+	}
+}
+
+// ValidatorSpec is one parsed entry of options.validate. Min and Max bound
+// the int and float validators; nil means unbounded.
+type ValidatorSpec struct {
+	Name string
+	Min  *float64
+	Max  *float64
+}
+
+// ParseValidator parses "name" or, for int and float, "name:min..max" where
+// either bound may be omitted.
+func ParseValidator(spec string) (ValidatorSpec, error) {
+	name, bounds, ranged := strings.Cut(strings.TrimSpace(spec), ":")
+	v := ValidatorSpec{Name: name}
+
+	known := false
+	for _, n := range ValidatorNames() {
+		if n == name {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return v, fmt.Errorf("validador desconhecido: %s (válidos: %s)", name, strings.Join(ValidatorNames(), ", "))
+	}
+	if !ranged {
+		return v, nil
+	}
+
+	if name != ValidatorInt && name != ValidatorFloat {
+		return v, fmt.Errorf("validador %s não aceita intervalo", name)
+	}
+	low, high, ok := strings.Cut(bounds, "..")
+	if !ok {
+		return v, fmt.Errorf("intervalo inválido em %s: use %s:min..max", spec, name)
+	}
+	var err error
+	if v.Min, err = parseBound(low); err != nil {
+		return v, fmt.Errorf("intervalo inválido em %s: %w", spec, err)
+	}
+	if v.Max, err = parseBound(high); err != nil {
+		return v, fmt.Errorf("intervalo inválido em %s: %w", spec, err)
+	}
+	if v.Min != nil && v.Max != nil && *v.Min > *v.Max {
+		return v, fmt.Errorf("intervalo inválido em %s: mínimo maior que o máximo", spec)
+	}
+	return v, nil
+}
+
+// parseBound parses one side of a range; empty means unbounded.
+func parseBound(s string) (*float64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("limite não numérico: %s", s)
+	}
+	return &f, nil
+}
+
+// ParseValidators parses the value of options.validate: a single spec or a
+// list of specs.
+func ParseValidators(value interface{}) ([]ValidatorSpec, error) {
+	var specs []string
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		specs = []string{v}
+	case []string:
+		specs = v
+	case []interface{}:
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("validate deve conter apenas nomes de validadores, recebido: %v", item)
+			}
+			specs = append(specs, s)
+		}
+	default:
+		return nil, fmt.Errorf("validate deve ser um nome ou uma lista de nomes de validadores")
+	}
+
+	result := make([]ValidatorSpec, 0, len(specs))
+	for _, s := range specs {
+		v, err := ParseValidator(s)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValidator(t *testing.T) {
+	one, ten, half := 1.0, 10.0, 0.5
+
+	tests := []struct {
+		spec    string
+		want    ValidatorSpec
+		wantErr string
+	}{
+		{spec: "email", want: ValidatorSpec{Name: ValidatorEmail}},
+		{spec: " cron ", want: ValidatorSpec{Name: ValidatorCron}},
+		{spec: "int:1..10", want: ValidatorSpec{Name: ValidatorInt, Min: &one, Max: &ten}},
+		{spec: "int:1..", want: ValidatorSpec{Name: ValidatorInt, Min: &one}},
+		{spec: "float:..0.5", want: ValidatorSpec{Name: ValidatorFloat, Max: &half}},
+		{spec: "mail", wantErr: "validador desconhecido: mail"},
+		{spec: "port:1..10", wantErr: "validador port não aceita intervalo"},
+		{spec: "int:1-10", wantErr: "use int:min..max"},
+		{spec: "float:a..1", wantErr: "limite não numérico: a"},
+		{spec: "int:10..1", wantErr: "mínimo maior que o máximo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseValidator(tt.spec)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseValidators(t *testing.T) {
+	specs, err := ParseValidators("uuid")
+	require.NoError(t, err)
+	assert.Equal(t, []ValidatorSpec{{Name: ValidatorUUID}}, specs)
+
+	specs, err = ParseValidators([]interface{}{"port", "int:1..100"})
+	require.NoError(t, err)
+	assert.Len(t, specs, 2)
+
+	specs, err = ParseValidators(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, specs)
+
+	_, err = ParseValidators([]interface{}{"port", 80})
+	assert.Error(t, err)
+
+	_, err = ParseValidators(map[string]interface{}{"int": "1..2"})
+	assert.Error(t, err)
+}
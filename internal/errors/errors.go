@@ -84,6 +84,7 @@ const (
 	ErrConcurrencyIssue
 	ErrTimeout
 	ErrResourceExhausted
+	ErrCancelled
 )
 
 // String returns the string representation of ErrorCode
@@ -147,6 +148,8 @@ func (ec ErrorCode) String() string {
 		return "TIMEOUT"
 	case ErrResourceExhausted:
 		return "RESOURCE_EXHAUSTED"
+	case ErrCancelled:
+		return "CANCELLED"
 	default:
 		return fmt.Sprintf("UNKNOWN_ERROR_%d", int(ec))
 	}
@@ -359,7 +362,7 @@ func isRetryable(code ErrorCode) bool {
 		return true
 	case ErrComponentValidationFailed, ErrValidationFailed:
 		return false
-	case ErrMemoryAllocationFailed, ErrResourceExhausted:
+	case ErrMemoryAllocationFailed, ErrResourceExhausted, ErrCancelled:
 		return false
 	default:
 		return true // Most errors are retryable by default
@@ -392,6 +395,16 @@ func NewFileError(message, filePath string) *AppError {
 	return NewAppError(ErrFileOperationFailed, message, filePath, SeverityError)
 }
 
+// NewCancelledError creates the error returned when the user cancels a TUI
+func NewCancelledError(message string) *AppError {
+	return NewAppError(ErrCancelled, message, "user", SeverityInfo)
+}
+
+// NewTimeoutError creates the error returned when a TUI times out without a submit
+func NewTimeoutError(message string) *AppError {
+	return NewAppError(ErrTimeout, message, "timeout", SeverityWarning)
+}
+
 // NewNetworkError creates a network-related error
 func NewNetworkError(message string) *AppError {
 	return NewAppError(ErrNetworkOperationFailed, message, "network", SeverityWarning)
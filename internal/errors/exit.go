@@ -0,0 +1,53 @@
+package errors
+
+import (
+	stderrors "errors"
+)
+
+// Process exit codes of the shantilly runner commands
+const (
+	ExitSubmitted        = 0   // The result was submitted
+	ExitConfigError      = 1   // Configuration, usage or runtime error
+	ExitValidationFailed = 2   // Values failed validation in non-interactive mode
+	ExitTimeout          = 124 // The timeout expired without a submit, as in timeout(1)
+	ExitCancelled        = 130 // The user cancelled (Esc/Ctrl+C), as in 128+SIGINT
+)
+
+// ExitCoder is implemented by errors that select the process exit code
+type ExitCoder interface {
+	ExitCode() int
+}
+
+// ExitCode maps the error code to the process exit code
+func (ec ErrorCode) ExitCode() int {
+	switch ec {
+	case ErrValidationFailed, ErrValidationCrossFieldFailed, ErrValidationBusinessRuleFailed,
+		ErrComponentValidationFailed:
+		return ExitValidationFailed
+	case ErrTimeout:
+		return ExitTimeout
+	case ErrCancelled:
+		return ExitCancelled
+	default:
+		return ExitConfigError
+	}
+}
+
+// ExitCode implements ExitCoder using the error code
+func (e *AppError) ExitCode() int {
+	return e.Code.ExitCode()
+}
+
+// ExitCodeOf returns the process exit code for err: ExitSubmitted for nil,
+// the code chosen by the first ExitCoder in the chain, or ExitConfigError
+func ExitCodeOf(err error) int {
+	if err == nil {
+		return ExitSubmitted
+	}
+
+	var coder ExitCoder
+	if stderrors.As(err, &coder) {
+		return coder.ExitCode()
+	}
+	return ExitConfigError
+}
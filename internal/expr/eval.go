@@ -0,0 +1,367 @@
+package expr
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// node is a node of the syntax tree.
+type node interface {
+	eval(values map[string]interface{}) (interface{}, error)
+}
+
+// literalNode is a string, number, boolean or null literal.
+type literalNode struct {
+	value interface{}
+}
+
+func (n *literalNode) eval(map[string]interface{}) (interface{}, error) {
+	return n.value, nil
+}
+
+// fieldNode is a reference to the value of a field.
+type fieldNode struct {
+	name string
+}
+
+func (n *fieldNode) eval(values map[string]interface{}) (interface{}, error) {
+	return values[n.name], nil
+}
+
+// listNode is a list literal.
+type listNode struct {
+	items []node
+}
+
+func (n *listNode) eval(values map[string]interface{}) (interface{}, error) {
+	list := make([]interface{}, 0, len(n.items))
+	for _, item := range n.items {
+		v, err := item.eval(values)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	return list, nil
+}
+
+// notNode negates the truthiness of its operand.
+type notNode struct {
+	pos     int
+	operand node
+}
+
+func (n *notNode) eval(values map[string]interface{}) (interface{}, error) {
+	v, err := n.operand.eval(values)
+	if err != nil {
+		return nil, err
+	}
+	return !Truthy(v), nil
+}
+
+// negNode negates a number.
+type negNode struct {
+	pos     int
+	operand node
+}
+
+func (n *negNode) eval(values map[string]interface{}) (interface{}, error) {
+	v, err := n.operand.eval(values)
+	if err != nil {
+		return nil, err
+	}
+	f, ok := Numeric(v)
+	if !ok {
+		return nil, &Error{Pos: n.pos, Msg: fmt.Sprintf("operador - requer um número, recebido %s", typeName(v))}
+	}
+	return -f, nil
+}
+
+// callNode calls a builtin function.
+type callNode struct {
+	name string
+	pos  int
+	fn   function
+	args []node
+}
+
+func (n *callNode) eval(values map[string]interface{}) (interface{}, error) {
+	if n.name == ifFunction {
+		cond, err := n.args[0].eval(values)
+		if err != nil {
+			return nil, err
+		}
+		if Truthy(cond) {
+			return n.args[1].eval(values)
+		}
+		return n.args[2].eval(values)
+	}
+
+	args := make([]interface{}, len(n.args))
+	for i, arg := range n.args {
+		v, err := arg.eval(values)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	v, err := n.fn.call(args)
+	if err != nil {
+		return nil, &Error{Pos: n.pos, Msg: fmt.Sprintf("%s: %v", n.name, err)}
+	}
+	return v, nil
+}
+
+// binaryNode applies a binary operator. && and || short-circuit.
+type binaryNode struct {
+	op          string
+	pos         int
+	left, right node
+}
+
+func (n *binaryNode) eval(values map[string]interface{}) (interface{}, error) {
+	left, err := n.left.eval(values)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "&&":
+		if !Truthy(left) {
+			return false, nil
+		}
+	case "||":
+		if Truthy(left) {
+			return true, nil
+		}
+	}
+
+	right, err := n.right.eval(values)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "&&", "||":
+		return Truthy(right), nil
+	case "==":
+		return Equal(left, right), nil
+	case "!=":
+		return !Equal(left, right), nil
+	case "<", "<=", ">", ">=":
+		return n.compare(left, right)
+	case "in":
+		return n.member(left, right)
+	case "+":
+		return n.add(left, right)
+	default: // "-", "*", "/", "%"
+		return n.arithmetic(left, right)
+	}
+}
+
+// compare orders two values as numbers when both hold one, so a text input
+// holding "10" is above 5 and "10" is above "9"; other texts are ordered
+// lexicographically, which also suits ISO dates.
+func (n *binaryNode) compare(left, right interface{}) (interface{}, error) {
+	var c int
+	x, xok := Numeric(left)
+	y, yok := Numeric(right)
+	ls, lok := left.(string)
+	rs, rok := right.(string)
+	switch {
+	case xok && yok:
+		switch {
+		case x < y:
+			c = -1
+		case x > y:
+			c = 1
+		}
+	case lok && rok:
+		c = strings.Compare(ls, rs)
+	default:
+		return nil, &Error{Pos: n.pos, Msg: fmt.Sprintf("não é possível comparar %s com %s", typeName(left), typeName(right))}
+	}
+
+	switch n.op {
+	case "<":
+		return c < 0, nil
+	case "<=":
+		return c <= 0, nil
+	case ">":
+		return c > 0, nil
+	default: // ">="
+		return c >= 0, nil
+	}
+}
+
+// member reports whether left is an item of the list, or a substring of the
+// text, on the right. Nothing is a member of null.
+func (n *binaryNode) member(left, right interface{}) (interface{}, error) {
+	if right == nil {
+		return false, nil
+	}
+	found, ok := contains(right, left)
+	if !ok {
+		return nil, &Error{Pos: n.pos, Msg: fmt.Sprintf("operador in requer uma lista ou texto, recebido %s", typeName(right))}
+	}
+	return found, nil
+}
+
+// add sums two numbers and concatenates anything else with a text: a text
+// holding a number is only summed with a number.
+func (n *binaryNode) add(left, right interface{}) (interface{}, error) {
+	ls, lok := left.(string)
+	rs, rok := right.(string)
+	if lok && rok {
+		return ls + rs, nil
+	}
+	if x, ok := Numeric(left); ok {
+		if y, ok := Numeric(right); ok {
+			return x + y, nil
+		}
+	}
+	if lok || rok {
+		return text(left) + text(right), nil
+	}
+	return nil, &Error{Pos: n.pos, Msg: fmt.Sprintf("operador + requer números ou textos, recebido %s e %s", typeName(left), typeName(right))}
+}
+
+// arithmetic applies -, *, / and % to two numbers.
+func (n *binaryNode) arithmetic(left, right interface{}) (interface{}, error) {
+	x, xok := Numeric(left)
+	y, yok := Numeric(right)
+	if !xok || !yok {
+		return nil, &Error{Pos: n.pos, Msg: fmt.Sprintf("operador %s requer números, recebido %s e %s", n.op, typeName(left), typeName(right))}
+	}
+	switch n.op {
+	case "-":
+		return x - y, nil
+	case "*":
+		return x * y, nil
+	}
+	if y == 0 {
+		return nil, &Error{Pos: n.pos, Msg: "divisão por zero"}
+	}
+	if n.op == "/" {
+		return x / y, nil
+	}
+	return math.Mod(x, y), nil
+}
+
+// Equal compares two values the way == does in expressions. Numbers compare
+// by value whatever their Go type, so a slider's 3.0 equals the literal 3,
+// and a text holding a number equals that number: a text input holding "10"
+// equals 10. Two texts compare exactly, so zero-padded codes and versions
+// keep their meaning: '007' is not '7'. Lists are equal when their items are.
+func Equal(a, b interface{}) bool {
+	if x, ok := number(a); ok {
+		y, ok := Numeric(b)
+		return ok && x == y
+	}
+	if y, ok := number(b); ok {
+		x, ok := Numeric(a)
+		return ok && x == y
+	}
+
+	// A []string held by a component against a literal []interface{}
+	ra, rb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if isList(ra) && isList(rb) {
+		if ra.Len() != rb.Len() {
+			return false
+		}
+		for i := 0; i < ra.Len(); i++ {
+			if !Equal(ra.Index(i).Interface(), rb.Index(i).Interface()) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// isList reports whether v is a slice or an array.
+func isList(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice || v.Kind() == reflect.Array
+}
+
+// contains reports whether item is an item of the list, or a substring of
+// the text, container. ok is false for any other container.
+func contains(container, item interface{}) (found, ok bool) {
+	if s, isString := container.(string); isString {
+		return strings.Contains(s, text(item)), true
+	}
+	rv := reflect.ValueOf(container)
+	if !isList(rv) {
+		return false, false
+	}
+	for i := 0; i < rv.Len(); i++ {
+		if Equal(rv.Index(i).Interface(), item) {
+			return true, true
+		}
+	}
+	return false, true
+}
+
+// number converts the numeric types held by components and literals.
+func number(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// Numeric converts numbers and texts holding a number, such as the value of
+// a text input, for arithmetic and ordering.
+func Numeric(v interface{}) (float64, bool) {
+	if s, ok := v.(string); ok {
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		return f, err == nil && !math.IsNaN(f) && !math.IsInf(f, 0)
+	}
+	return number(v)
+}
+
+// text formats v for concatenation; null is the empty text.
+func text(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	if f, ok := number(v); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// typeName names the type of v in error messages.
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "nulo"
+	case string:
+		return "texto"
+	case bool:
+		return "booleano"
+	}
+	if _, ok := number(v); ok {
+		return "número"
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Slice, reflect.Array:
+		return "lista"
+	case reflect.Map:
+		return "mapa"
+	}
+	return fmt.Sprintf("%T", v)
+}
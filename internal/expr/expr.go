@@ -0,0 +1,97 @@
+// Package expr implements the small expression language used by component
+// conditions such as show_if and enable_if, expression rules and business
+// validation. Expressions refer to fields by name and are evaluated against
+// the current values of a form; they are parsed once, when the configuration
+// is loaded, and cannot reach anything outside the values they are given.
+//
+// The language has literals (numbers, 'texts', true, false, null and
+// [lists]), comparisons (== != < <= > >=), membership (in, not in),
+// arithmetic (+ - * / %, with + also concatenating texts), the boolean
+// operators && || and !, also spelled and, or and not, and the functions
+// len, lower, contains, matches and if.
+package expr
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Error is a syntax or evaluation error at a position of the expression.
+type Error struct {
+	Pos int // Column of the offending token, counted from 1
+	Msg string
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	return fmt.Sprintf("posição %d: %s", e.Pos, e.Msg)
+}
+
+// Expr is a parsed expression.
+type Expr struct {
+	src    string
+	root   node
+	fields []string
+}
+
+// Parse parses src. Syntax errors, unknown functions and invalid constant
+// patterns are returned as *Error.
+func Parse(src string) (*Expr, error) {
+	p, err := newParser(src)
+	if err != nil {
+		return nil, err
+	}
+	root, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	return &Expr{src: src, root: root, fields: p.fields}, nil
+}
+
+// String returns the source of the expression.
+func (e *Expr) String() string {
+	return e.src
+}
+
+// Fields returns the fields the expression refers to, in order of first use.
+func (e *Expr) Fields() []string {
+	return e.fields
+}
+
+// Eval evaluates the expression against values, keyed by field name.
+// Fields missing from values evaluate to nil. Type errors, such as ordering
+// a text against a number, are returned as *Error.
+func (e *Expr) Eval(values map[string]interface{}) (interface{}, error) {
+	return e.root.eval(values)
+}
+
+// Bool evaluates the expression and reports whether the result is truthy.
+func (e *Expr) Bool(values map[string]interface{}) (bool, error) {
+	v, err := e.Eval(values)
+	if err != nil {
+		return false, err
+	}
+	return Truthy(v), nil
+}
+
+// Truthy reports whether v counts as true in a condition: nil, false, zero,
+// the empty string and empty lists are false, anything else is true.
+func Truthy(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	}
+	if f, ok := number(v); ok {
+		return f != 0
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len() > 0
+	}
+	return true
+}
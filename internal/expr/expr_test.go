@@ -0,0 +1,162 @@
+package expr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEval(t *testing.T) {
+	values := map[string]interface{}{
+		"env":      "prod",
+		"replicas": 3.0,
+		"tls":      true,
+		"notes":    "",
+		"files":    []string{"a.txt"},
+		"age":      "20",
+		"name":     "Ana",
+		"email":    "ana@example.com",
+	}
+
+	tests := []struct {
+		src  string
+		want bool
+	}{
+		{"env == 'prod'", true},
+		{`env == "dev"`, false},
+		{"env != 'dev'", true},
+		{"replicas == 3", true},
+		{"tls", true},
+		{"!tls", false},
+		{"not notes", true},
+		{"files", true},
+		{"missing", false},
+		{"missing == null", true},
+		{"env == 'prod' && tls == false", false},
+		{"env == 'dev' || replicas == 3", true},
+		{"env == 'prod' and (replicas == 1 or tls)", true},
+		{"!(env == 'prod')", false},
+		{"'it\\'s' == \"it's\"", true},
+		{"replicas == '3'", true},
+		{"age == 20 && age != 21 && 20 == age", true},
+		{"age >= 20 && age == 20 && age <= 20", true},
+		{"'10.0' == 10 && '10' != '10.0'", true},
+		{"'007' != '7' && '007' == 7", true},
+		{"'1.10' != '1.1' && '1e2' != '100'", true},
+		{"'7' in ['007', '7.0']", false},
+		{"'7' in [7]", true},
+		{"files == ['a.txt'] && files != ['a.txt', 'b.txt']", true},
+		{"age in [10, 20]", true},
+		{"name == 0", false},
+		{"env != 1", true},
+		{"replicas > 2 && replicas <= 3", true},
+		{"age >= 18", true},
+		{"env < 'qa'", true},
+		{"'10' > '9' && '2025-01-10' > '2025-01-09'", true},
+		{"replicas * 2 - 1 == 5", true},
+		{"-replicas == 0 - 3", true},
+		{"replicas % 2 == 1 && replicas / 2 == 1.5", true},
+		{"env + '-' + replicas == 'prod-3'", true},
+		{"age + 1 == 21", true},
+		{"env in ['dev', 'prod']", true},
+		{"env not in ['dev', 'prod']", false},
+		{"'a.txt' in files", true},
+		{"'ro' in env", true},
+		{"env in missing", false},
+		{"len(env) == 4 && len(files) == 1 && len(missing) == 0", true},
+		{"lower(name) == 'ana'", true},
+		{"contains(files, 'b.txt')", false},
+		{"contains(name, 'An')", true},
+		{"matches(email, '^[^@]+@[^@]+$')", true},
+		{"matches(notes, '.')", false},
+		{"if(env == 'prod', replicas, 1) == 3", true},
+		{"if(tls, 'https', 'http') + '://x' == 'https://x'", true},
+		{"if(notes, notes > 1, true)", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			e, err := Parse(tt.src)
+			require.NoError(t, err)
+			got, err := e.Bool(values)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		src     string
+		wantPos int
+		wantMsg string
+	}{
+		{"", 1, "expressão vazia"},
+		{"env == ", 8, "fim inesperado da expressão"},
+		{"env = 'prod'", 5, "caractere inesperado: '='"},
+		{"env == 'prod", 8, "texto sem aspas de fechamento"},
+		{"(env == 'prod'", 1, "parêntese sem fechamento"},
+		{"env 'prod'", 5, `símbolo inesperado: "prod"`},
+		{"replicas == 1.2.3", 13, "número inválido: 1.2.3"},
+		{"env in ['dev', 'prod'", 8, "lista sem colchete de fechamento"},
+		{"upper(env) == 'PROD'", 1, "função desconhecida: upper (disponíveis: contains, if, len, lower, matches)"},
+		{"len(env, 2) > 1", 1, "len espera 1 argumento(s), recebeu 2"},
+		{"matches(email, '[a-')", 1, "regex inválida em matches: error parsing regexp: missing closing ]: `[a-`"},
+		{"replicas > 1 > 0", 14, "símbolo inesperado: >"},
+		{strings.Repeat("(", 100) + "tls" + strings.Repeat(")", 100), 65, "expressão aninhada demais"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			_, err := Parse(tt.src)
+			var exprErr *Error
+			require.ErrorAs(t, err, &exprErr)
+			assert.Equal(t, tt.wantPos, exprErr.Pos)
+			assert.Equal(t, tt.wantMsg, exprErr.Msg)
+		})
+	}
+}
+
+func TestEval_Errors(t *testing.T) {
+	values := map[string]interface{}{"env": "prod", "replicas": 3.0, "tls": true}
+
+	tests := []struct {
+		src     string
+		wantPos int
+		wantMsg string
+	}{
+		{"env > 1", 5, "não é possível comparar texto com número"},
+		{"replicas - env", 10, "operador - requer números, recebido número e texto"},
+		{"replicas / (replicas - 3)", 10, "divisão por zero"},
+		{"replicas in tls", 10, "operador in requer uma lista ou texto, recebido booleano"},
+		{"-tls", 1, "operador - requer um número, recebido booleano"},
+		{"lower(replicas) == '3'", 1, "lower: requer texto, recebido número"},
+		{"tls + tls", 5, "operador + requer números ou textos, recebido booleano e booleano"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			e, err := Parse(tt.src)
+			require.NoError(t, err)
+			_, err = e.Eval(values)
+			var exprErr *Error
+			require.ErrorAs(t, err, &exprErr)
+			assert.Equal(t, tt.wantPos, exprErr.Pos)
+			assert.Equal(t, tt.wantMsg, exprErr.Msg)
+		})
+	}
+}
+
+func TestExpr_Fields(t *testing.T) {
+	e, err := Parse("env == 'prod' && (tls || env == region) && true")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"env", "tls", "region"}, e.Fields())
+	assert.Equal(t, "env == 'prod' && (tls || env == region) && true", e.String())
+
+	// Function names are not fields
+	e, err = Parse("len(name) > 2 && lower(name) in [alias]")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name", "alias"}, e.Fields())
+}
@@ -0,0 +1,105 @@
+package expr
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// function is a builtin function. Calls are checked against the arity when
+// the expression is parsed.
+type function struct {
+	arity int
+	call  func(args []interface{}) (interface{}, error)
+}
+
+// ifFunction is if(condition, then, else), which evaluates only the branch
+// chosen by the truthiness of condition; callNode handles it.
+const ifFunction = "if"
+
+// functions are the builtins available to expressions. They only compute on
+// their arguments: there is no access to files, the environment or the
+// network.
+var functions = map[string]function{
+	ifFunction: {arity: 3},
+
+	// len(x) is the number of characters of a text or items of a list
+	"len": {arity: 1, call: func(args []interface{}) (interface{}, error) {
+		switch v := args[0].(type) {
+		case nil:
+			return 0.0, nil
+		case string:
+			return float64(utf8.RuneCountInString(v)), nil
+		}
+		rv := reflect.ValueOf(args[0])
+		switch rv.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return float64(rv.Len()), nil
+		}
+		return nil, fmt.Errorf("requer texto ou lista, recebido %s", typeName(args[0]))
+	}},
+
+	// lower(s) is s in lower case
+	"lower": {arity: 1, call: func(args []interface{}) (interface{}, error) {
+		s, err := textArg(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return strings.ToLower(s), nil
+	}},
+
+	// contains(x, item) reports whether item is in the list or text x
+	"contains": {arity: 2, call: func(args []interface{}) (interface{}, error) {
+		if args[0] == nil {
+			return false, nil
+		}
+		found, ok := contains(args[0], args[1])
+		if !ok {
+			return nil, fmt.Errorf("requer texto ou lista, recebido %s", typeName(args[0]))
+		}
+		return found, nil
+	}},
+
+	// matches(s, pattern) reports whether s matches the RE2 pattern
+	"matches": {arity: 2, call: func(args []interface{}) (interface{}, error) {
+		s, err := textArg(args[0])
+		if err != nil {
+			return nil, err
+		}
+		re, ok := args[1].(*regexp.Regexp)
+		if !ok {
+			pattern, isString := args[1].(string)
+			if !isString {
+				return nil, fmt.Errorf("requer um padrão em texto, recebido %s", typeName(args[1]))
+			}
+			if re, err = regexp.Compile(pattern); err != nil {
+				return nil, fmt.Errorf("regex inválida: %w", err)
+			}
+		}
+		return re.MatchString(s), nil
+	}},
+}
+
+// functionList names the builtins for error messages.
+func functionList() string {
+	names := make([]string, 0, len(functions))
+	for name := range functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// textArg converts a text argument; null is the empty text.
+func textArg(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	}
+	return "", fmt.Errorf("requer texto, recebido %s", typeName(v))
+}
@@ -0,0 +1,394 @@
+package expr
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// maxDepth bounds the nesting of an expression, so a hostile configuration
+// can't exhaust the stack of the parser or the evaluator.
+const maxDepth = 64
+
+// tokenKind classifies the tokens of an expression.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenOp
+)
+
+// token is a lexical unit of an expression.
+type token struct {
+	kind  tokenKind
+	text  string      // Operator or identifier as written
+	value interface{} // Literal value of numbers and strings
+	pos   int
+}
+
+// Keywords spelling operators.
+var keywords = map[string]string{
+	"and": "&&",
+	"or":  "||",
+	"not": "!",
+	"in":  "in",
+}
+
+// Operators, longest first so "==" is not read as "=".
+var operators = []string{
+	"==", "!=", "<=", ">=", "&&", "||",
+	"<", ">", "!", "+", "-", "*", "/", "%", "(", ")", "[", "]", ",",
+}
+
+// lex splits src into tokens.
+func lex(src string) ([]token, error) {
+	var tokens []token
+	runes := []rune(src)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '\'' || r == '"':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				b.WriteRune(runes[j])
+			}
+			if j >= len(runes) {
+				return nil, &Error{Pos: pos, Msg: "texto sem aspas de fechamento"}
+			}
+			tokens = append(tokens, token{kind: tokenString, value: b.String(), pos: pos})
+			i = j + 1
+
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			text := string(runes[i:j])
+			f, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, &Error{Pos: pos, Msg: fmt.Sprintf("número inválido: %s", text)}
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: text, value: f, pos: pos})
+			i = j
+
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_') {
+				j++
+			}
+			text := string(runes[i:j])
+			if op := keywords[text]; op != "" {
+				tokens = append(tokens, token{kind: tokenOp, text: op, pos: pos})
+			} else {
+				tokens = append(tokens, token{kind: tokenIdent, text: text, pos: pos})
+			}
+			i = j
+
+		default:
+			op := ""
+			for _, candidate := range operators {
+				if strings.HasPrefix(string(runes[i:]), candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, &Error{Pos: pos, Msg: fmt.Sprintf("caractere inesperado: %q", r)}
+			}
+			tokens = append(tokens, token{kind: tokenOp, text: op, pos: pos})
+			i += len([]rune(op))
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(runes) + 1}), nil
+}
+
+// parser is a recursive descent parser over the tokens of an expression.
+// From lowest to highest precedence: ||, &&, comparisons and in, + and -,
+// *, / and %, unary ! and -.
+type parser struct {
+	tokens []token
+	next   int
+	depth  int
+	fields []string
+	seen   map[string]bool
+}
+
+// newParser tokenizes src.
+func newParser(src string) (*parser, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	return &parser{tokens: tokens, seen: make(map[string]bool)}, nil
+}
+
+// parse parses the whole expression.
+func (p *parser) parse() (node, error) {
+	if p.peek().kind == tokenEOF {
+		return nil, &Error{Pos: 1, Msg: "expressão vazia"}
+	}
+	n, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.unexpected(t)
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+// accept consumes the next token if it is one of the operators ops.
+func (p *parser) accept(ops ...string) (token, bool) {
+	t := p.peek()
+	if t.kind != tokenOp {
+		return t, false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			p.next++
+			return t, true
+		}
+	}
+	return t, false
+}
+
+// unexpected returns the error for a token that doesn't fit the grammar.
+func (p *parser) unexpected(t token) error {
+	if t.kind == tokenEOF {
+		return &Error{Pos: t.pos, Msg: "fim inesperado da expressão"}
+	}
+	text := t.text
+	if t.kind == tokenString {
+		text = fmt.Sprintf("%q", t.value)
+	}
+	return &Error{Pos: t.pos, Msg: fmt.Sprintf("símbolo inesperado: %s", text)}
+}
+
+// binary parses a left-associative chain of the operators ops over operands
+// parsed by operand.
+func (p *parser) binary(operand func() (node, error), ops ...string) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t, ok := p.accept(ops...)
+		if !ok {
+			return left, nil
+		}
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: t.text, pos: t.pos, left: left, right: right}
+	}
+}
+
+func (p *parser) or() (node, error) {
+	return p.binary(p.and, "||")
+}
+
+func (p *parser) and() (node, error) {
+	return p.binary(p.comparison, "&&")
+}
+
+// comparison parses at most one comparison: a < b < c is rejected.
+func (p *parser) comparison() (node, error) {
+	left, err := p.additive()
+	if err != nil {
+		return nil, err
+	}
+
+	// "not in" is the only place ! follows an operand
+	if t := p.peek(); t.kind == tokenOp && t.text == "!" {
+		if next := p.tokens[p.next+1]; next.kind == tokenOp && next.text == "in" {
+			p.next += 2
+			right, err := p.additive()
+			if err != nil {
+				return nil, err
+			}
+			return &notNode{pos: t.pos, operand: &binaryNode{op: "in", pos: next.pos, left: left, right: right}}, nil
+		}
+	}
+
+	t, ok := p.accept("==", "!=", "<", "<=", ">", ">=", "in")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.additive()
+	if err != nil {
+		return nil, err
+	}
+	return &binaryNode{op: t.text, pos: t.pos, left: left, right: right}, nil
+}
+
+func (p *parser) additive() (node, error) {
+	return p.binary(p.multiplicative, "+", "-")
+}
+
+func (p *parser) multiplicative() (node, error) {
+	return p.binary(p.unary, "*", "/", "%")
+}
+
+func (p *parser) unary() (node, error) {
+	t, ok := p.accept("!", "-")
+	if !ok {
+		return p.primary()
+	}
+	if p.depth++; p.depth > maxDepth {
+		return nil, &Error{Pos: t.pos, Msg: "expressão aninhada demais"}
+	}
+	defer func() { p.depth-- }()
+
+	operand, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	if t.text == "-" {
+		return &negNode{pos: t.pos, operand: operand}, nil
+	}
+	return &notNode{pos: t.pos, operand: operand}, nil
+}
+
+func (p *parser) primary() (node, error) {
+	t := p.peek()
+	switch t.kind {
+	case tokenNumber, tokenString:
+		p.next++
+		return &literalNode{value: t.value}, nil
+
+	case tokenIdent:
+		p.next++
+		switch t.text {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "null":
+			return &literalNode{value: nil}, nil
+		}
+		if _, ok := p.accept("("); ok {
+			return p.call(t)
+		}
+		if !p.seen[t.text] {
+			p.seen[t.text] = true
+			p.fields = append(p.fields, t.text)
+		}
+		return &fieldNode{name: t.text}, nil
+
+	case tokenOp:
+		switch t.text {
+		case "(":
+			p.next++
+			n, err := p.nested(t, p.or)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.close(t, ")", "parêntese sem fechamento"); err != nil {
+				return nil, err
+			}
+			return n, nil
+
+		case "[":
+			p.next++
+			items, err := p.list(t, "]", "lista sem colchete de fechamento")
+			if err != nil {
+				return nil, err
+			}
+			return &listNode{items: items}, nil
+		}
+	}
+	return nil, p.unexpected(t)
+}
+
+// call parses the arguments of a call to the function named by t, whose
+// opening parenthesis was consumed, and checks them against its signature.
+func (p *parser) call(t token) (node, error) {
+	fn, ok := functions[t.text]
+	if !ok {
+		return nil, &Error{Pos: t.pos, Msg: fmt.Sprintf("função desconhecida: %s (disponíveis: %s)", t.text, functionList())}
+	}
+	args, err := p.list(t, ")", "chamada sem parêntese de fechamento")
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != fn.arity {
+		return nil, &Error{Pos: t.pos, Msg: fmt.Sprintf("%s espera %d argumento(s), recebeu %d", t.text, fn.arity, len(args))}
+	}
+
+	// Constant patterns are compiled once, reporting mistakes at load time
+	if t.text == "matches" {
+		if lit, ok := args[1].(*literalNode); ok {
+			pattern, isString := lit.value.(string)
+			if !isString {
+				return nil, &Error{Pos: t.pos, Msg: "matches espera um padrão em texto"}
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, &Error{Pos: t.pos, Msg: fmt.Sprintf("regex inválida em matches: %v", err)}
+			}
+			args[1] = &literalNode{value: re}
+		}
+	}
+	return &callNode{name: t.text, pos: t.pos, fn: fn, args: args}, nil
+}
+
+// list parses comma-separated expressions up to the closing operator; open
+// is the token that started the list.
+func (p *parser) list(open token, closing, unclosed string) ([]node, error) {
+	var items []node
+	if _, ok := p.accept(closing); ok {
+		return items, nil
+	}
+	for {
+		item, err := p.nested(open, p.or)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if _, ok := p.accept(","); !ok {
+			break
+		}
+	}
+	if err := p.close(open, closing, unclosed); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// nested parses with parse one level deeper than open.
+func (p *parser) nested(open token, parse func() (node, error)) (node, error) {
+	if p.depth++; p.depth > maxDepth {
+		return nil, &Error{Pos: open.pos, Msg: "expressão aninhada demais"}
+	}
+	defer func() { p.depth-- }()
+	return parse()
+}
+
+// close consumes the closing operator of a group opened by open.
+func (p *parser) close(open token, closing, unclosed string) error {
+	if _, ok := p.accept(closing); ok {
+		return nil
+	}
+	if next := p.peek(); next.kind != tokenEOF {
+		return p.unexpected(next)
+	}
+	return &Error{Pos: open.pos, Msg: unclosed}
+}
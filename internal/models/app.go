@@ -247,6 +247,15 @@ func (app *AppModel) initializeView() error {
 			app.activeModel = tabsModel
 		}
 
+	case MenuView:
+		if len(app.config.Menus) > 0 {
+			menuModel, err := NewMenuModel(&app.config.Menus[0], app.theme)
+			if err != nil {
+				return fmt.Errorf("erro ao criar modelo de menu: %w", err)
+			}
+			app.activeModel = menuModel
+		}
+
 	default:
 		return fmt.Errorf("tipo de visão não suportado: %s", app.currentView.String())
 	}
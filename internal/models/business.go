@@ -0,0 +1,116 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/helton/shantilly/internal/components"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/expr"
+	"github.com/helton/shantilly/internal/styles"
+)
+
+// businessCheck evaluates the custom_validator expression of
+// BusinessValidation on the submitted values and keeps the failure until a
+// field is edited.
+type businessCheck struct {
+	validator *expr.Expr
+	failure   string // Message of the last run, empty when it passed
+}
+
+// newBusinessCheck parses the validator of cfg. It returns nil when business
+// validation is disabled or declares no validator.
+func newBusinessCheck(cfg config.BusinessValidation) (*businessCheck, error) {
+	validator, err := cfg.Validator()
+	if err != nil || validator == nil {
+		return nil, err
+	}
+	return &businessCheck{validator: validator}, nil
+}
+
+// run evaluates the validator on values and records the failure. It returns
+// true when the validator holds.
+func (c *businessCheck) run(values map[string]interface{}) bool {
+	c.failure = c.check(values)
+	return c.failure == ""
+}
+
+// check returns the failure message for values, "" when the validator holds.
+// A validator that fails to evaluate doesn't hold.
+func (c *businessCheck) check(values map[string]interface{}) string {
+	ok, err := components.ValidationContext{ComponentValues: values}.Bool(c.validator)
+	if err != nil {
+		return fmt.Sprintf("Não foi possível avaliar a regra de negócio: %v", err)
+	}
+	if !ok {
+		return fmt.Sprintf("Regra de negócio não atendida: %s", c.validator)
+	}
+	return ""
+}
+
+// clear forgets the failure of the last run; the validator may depend on
+// any field.
+func (c *businessCheck) clear() {
+	c.failure = ""
+}
+
+// pending reports whether the failure of the last run is still shown.
+func (c *businessCheck) pending() bool {
+	return c.failure != ""
+}
+
+// view renders the failure of the last run.
+func (c *businessCheck) view(theme *styles.Theme) []string {
+	return []string{theme.Error.Render("✗ " + c.failure)}
+}
+
+// validationErrors evaluates the validator on values and returns the failure
+// in the format of the validation pipeline, for non-interactive runs.
+func (c *businessCheck) validationErrors(values map[string]interface{}) []components.ValidationError {
+	msg := c.check(values)
+	if msg == "" {
+		return nil
+	}
+	return []components.ValidationError{{
+		Code:     "BUSINESS_RULE",
+		Message:  msg,
+		Severity: "error",
+		Context: map[string]interface{}{
+			"expression":     c.validator.String(),
+			"related_fields": c.validator.Fields(),
+		},
+	}}
+}
+
+// SetBusinessValidation enables the custom_validator of cfg. When enabled,
+// the values of ToMap must satisfy the expression before the form is
+// submitted; a failure is shown below the components. Every field the
+// expression refers to must be a component of the form, so a typo fails
+// here rather than on every submit.
+func (m *FormModel) SetBusinessValidation(cfg config.BusinessValidation) error {
+	check, err := newBusinessCheck(cfg)
+	if err != nil {
+		return fmt.Errorf("erro ao carregar validação de negócio: %w", err)
+	}
+	if check != nil {
+		names := make(map[string]bool, len(m.components))
+		for _, comp := range m.components {
+			names[comp.Name()] = true
+		}
+		for _, field := range check.validator.Fields() {
+			if !names[field] {
+				return fmt.Errorf("erro ao carregar validação de negócio: custom_validator: campo inexistente: %s", field)
+			}
+		}
+	}
+	m.business = check
+	return nil
+}
+
+// followsBusinessRules runs the business check, if enabled, on the current
+// values.
+func (m *FormModel) followsBusinessRules() bool {
+	if m.business == nil {
+		return true
+	}
+	return m.business.run(m.ToMap())
+}
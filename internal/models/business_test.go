@@ -0,0 +1,76 @@
+package models
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupBusinessForm creates a form whose submitted values must satisfy the
+// given custom_validator.
+func setupBusinessForm(t *testing.T, validator string) *FormModel {
+	cfg := &config.FormConfig{
+		Components: []config.ComponentConfig{
+			{Type: config.TypeTextInput, Name: "replicas", Default: "5"},
+			{Type: config.TypeCheckbox, Name: "ha"},
+		},
+	}
+	m, err := NewFormModel(cfg, styles.DefaultTheme())
+	require.NoError(t, err)
+	require.NoError(t, m.SetBusinessValidation(config.BusinessValidation{Enabled: true, CustomValidator: validator}))
+	return m
+}
+
+func TestFormModel_BusinessBlocksSubmit(t *testing.T) {
+	m := setupBusinessForm(t, "replicas <= 3 || ha")
+
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.False(t, isQuit(cmd))
+	assert.Contains(t, ansi.Strip(m.View()), "✗ Regra de negócio não atendida: replicas <= 3 || ha")
+
+	// Editing any field takes the failure off until the next submit
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	m.Update(tea.KeyPressMsg{Code: tea.KeySpace})
+	assert.NotContains(t, ansi.Strip(m.View()), "Regra de negócio")
+
+	_, cmd = m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.True(t, isQuit(cmd))
+	assert.True(t, m.Submitted())
+}
+
+func TestFormModel_BusinessValidate(t *testing.T) {
+	m := setupBusinessForm(t, "replicas * 2 > 20")
+
+	errs := m.Validate()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "BUSINESS_RULE", errs[0].Code)
+	assert.Equal(t, "Regra de negócio não atendida: replicas * 2 > 20", errs[0].Message)
+	assert.Equal(t, []string{"replicas"}, errs[0].Context["related_fields"])
+
+	require.NoError(t, m.ApplyValues(map[string]interface{}{"replicas": "muitas"}))
+	errs = m.Validate()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Não foi possível avaliar a regra de negócio: posição 10: operador * requer números, recebido texto e número", errs[0].Message)
+}
+
+func TestFormModel_SetBusinessValidation(t *testing.T) {
+	m, err := NewFormModel(&config.FormConfig{
+		Components: []config.ComponentConfig{{Type: config.TypeCheckbox, Name: "agree"}},
+	}, styles.DefaultTheme())
+	require.NoError(t, err)
+
+	assert.NoError(t, m.SetBusinessValidation(config.BusinessValidation{CustomValidator: "agree"}))
+	assert.Nil(t, m.business, "disabled by default")
+
+	err = m.SetBusinessValidation(config.BusinessValidation{Enabled: true, CustomValidator: "agree =="})
+	assert.EqualError(t, err, "erro ao carregar validação de negócio: custom_validator inválido: posição 9: fim inesperado da expressão")
+
+	err = m.SetBusinessValidation(config.BusinessValidation{Enabled: true, CustomValidator: "agree && agreed"})
+	assert.EqualError(t, err, "erro ao carregar validação de negócio: custom_validator: campo inexistente: agreed")
+	assert.Nil(t, m.business)
+}
@@ -0,0 +1,281 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/v2/spinner"
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/helton/shantilly/internal/components"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/styles"
+)
+
+// commandResultMsg carries the outcome of a validate_command run.
+type commandResultMsg struct {
+	check   *commandCheck // The check that started the run
+	field   string
+	value   string
+	seq     int    // Run number, to drop results of superseded runs
+	message string // Field error, empty when the value was accepted
+}
+
+// commandCheck runs the external validators of options.validate_command
+// when a field loses focus or the form is submitted, and keeps their
+// verdict until the field is edited.
+type commandCheck struct {
+	commands   map[string]*config.ValidateCommand
+	checked    map[string]string // Value of the last finished run, by field
+	errors     map[string]string // Error of the last finished run, by field
+	running    map[string]int    // Run number of the run in progress, by field
+	seq        int
+	spinner    spinner.Model
+	spinning   bool
+	submitting bool // A submit waits for the runs in progress
+}
+
+// newCommandCheck returns nil when no component declares a command.
+func newCommandCheck(commands map[string]*config.ValidateCommand, theme *styles.Theme) *commandCheck {
+	if len(commands) == 0 {
+		return nil
+	}
+	return &commandCheck{
+		commands: commands,
+		checked:  make(map[string]string),
+		errors:   make(map[string]string),
+		running:  make(map[string]int),
+		spinner:  spinner.New(spinner.WithSpinner(spinner.Dot), spinner.WithStyle(theme.Help)),
+	}
+}
+
+// start runs the command of comp in the background, unless it has none,
+// is empty or was already checked with its current value.
+func (c *commandCheck) start(comp components.Component) tea.Cmd {
+	name := comp.Name()
+	command, ok := c.commands[name]
+	if !ok {
+		return nil
+	}
+	value := fmt.Sprint(comp.Value())
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	if checked, ok := c.checked[name]; ok && checked == value {
+		return nil
+	}
+	if _, ok := c.running[name]; ok {
+		return nil
+	}
+
+	c.seq++
+	seq := c.seq
+	c.running[name] = seq
+	run := func() tea.Msg {
+		return commandResultMsg{check: c, field: name, value: value, seq: seq, message: runValidateCommand(command, value)}
+	}
+
+	if c.spinning {
+		return run
+	}
+	c.spinning = true
+	return tea.Batch(run, c.spinner.Tick)
+}
+
+// finish records a result. It returns true when a submit was waiting for
+// it and no other run is in progress, so the submit can be retried.
+func (c *commandCheck) finish(msg commandResultMsg) bool {
+	if seq, ok := c.running[msg.field]; !ok || seq != msg.seq {
+		return false
+	}
+	delete(c.running, msg.field)
+	c.checked[msg.field] = msg.value
+	if msg.message != "" {
+		c.errors[msg.field] = msg.message
+	}
+
+	if c.submitting && !c.busy() {
+		c.submitting = false
+		return true
+	}
+	return false
+}
+
+// verify starts the commands of the fields not yet checked with their
+// current values. It returns true when every field passed; otherwise the
+// returned command waits for the runs, whose results retry the submit.
+func (c *commandCheck) verify(comps []components.Component) (bool, tea.Cmd) {
+	var cmds []tea.Cmd
+	for _, comp := range comps {
+		if cmd := c.start(comp); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	if c.busy() {
+		c.submitting = true
+		return false, tea.Batch(cmds...)
+	}
+	return !c.pending(), nil
+}
+
+// verified reports whether every field was checked with its current value
+// and passed, without starting any run.
+func (c *commandCheck) verified(comps []components.Component) bool {
+	for _, comp := range comps {
+		if _, ok := c.commands[comp.Name()]; !ok {
+			continue
+		}
+		value := fmt.Sprint(comp.Value())
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		if checked, ok := c.checked[comp.Name()]; !ok || checked != value {
+			return false
+		}
+	}
+	return !c.pending()
+}
+
+// spin advances the spinner while runs are in progress.
+func (c *commandCheck) spin(msg spinner.TickMsg) tea.Cmd {
+	if !c.busy() {
+		c.spinning = false
+		return nil
+	}
+	var cmd tea.Cmd
+	c.spinner, cmd = c.spinner.Update(msg)
+	return cmd
+}
+
+// show puts the recorded errors back on the components, since IsValid
+// clears the error of a component whose own checks pass.
+func (c *commandCheck) show(comps []components.Component) {
+	for _, comp := range comps {
+		if msg, ok := c.errors[comp.Name()]; ok && comp.GetError() == "" {
+			comp.SetError(msg)
+		}
+	}
+}
+
+// clear forgets the verdict on an edited field and drops its run in
+// progress, if any.
+func (c *commandCheck) clear(name string) {
+	delete(c.checked, name)
+	delete(c.errors, name)
+	delete(c.running, name)
+	if c.submitting && !c.busy() {
+		c.submitting = false
+	}
+}
+
+// pending reports whether a field was rejected by its command.
+func (c *commandCheck) pending() bool {
+	return len(c.errors) > 0
+}
+
+// failed reports whether the named field was rejected by its command.
+func (c *commandCheck) failed(name string) bool {
+	_, ok := c.errors[name]
+	return ok
+}
+
+// busy reports whether a command is running.
+func (c *commandCheck) busy() bool {
+	return len(c.running) > 0
+}
+
+// decorate adds the spinner below the view of a field being checked.
+func (c *commandCheck) decorate(name, view string) string {
+	if _, ok := c.running[name]; !ok {
+		return view
+	}
+	return view + "\n" + c.spinner.View() + " Verificando..."
+}
+
+// validationErrors runs the commands synchronously on values, for
+// non-interactive runs.
+func (c *commandCheck) validationErrors(values map[string]interface{}) []components.ValidationError {
+	names := make([]string, 0, len(c.commands))
+	for name := range c.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []components.ValidationError
+	for _, name := range names {
+		command := c.commands[name]
+		value := fmt.Sprint(values[name])
+		if values[name] == nil || strings.TrimSpace(value) == "" {
+			continue
+		}
+		if msg := runValidateCommand(command, value); msg != "" {
+			result = append(result, components.ValidationError{
+				Code:     "COMMAND_REJECTED",
+				Message:  msg,
+				Field:    name,
+				Severity: "error",
+				Context:  map[string]interface{}{"command": command.Args},
+			})
+		}
+	}
+	return result
+}
+
+// runValidateCommand runs command on value and returns the field error, or
+// "" when the value was accepted. A JSON object with a non-empty "error" on
+// stdout rejects the value whatever the exit status; otherwise a non-zero
+// exit does, with the first line of stderr or stdout as the message.
+func runValidateCommand(command *config.ValidateCommand, value string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), command.Timeout)
+	defer cancel()
+
+	args := command.Expand(value)
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = time.Second // Don't wait on children that keep the pipes open
+	err := cmd.Run()
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Sprintf("A validação não respondeu em %s", command.Timeout)
+	}
+
+	var response struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(bytes.TrimSpace(stdout.Bytes()), &response) == nil && response.Error != "" {
+		return response.Error
+	}
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return fmt.Sprintf("Não foi possível executar a validação: %v", err)
+	}
+	if err != nil {
+		if line := firstLine(stderr.String()); line != "" {
+			return line
+		}
+		if line := firstLine(stdout.String()); line != "" {
+			return line
+		}
+		return "Valor recusado pela validação"
+	}
+	return ""
+}
+
+// firstLine returns the first non-blank line of s, trimmed.
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,194 @@
+package models
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checkUserScript writes a validator that rejects "admin" with a JSON error
+// and "root" with a non-zero exit.
+func checkUserScript(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "check-user.sh")
+	script := `#!/bin/sh
+case "$1" in
+  admin) echo '{"error": "Usuário já existe"}' ;;
+  root) echo "nome reservado" >&2; exit 1 ;;
+  *) echo '{}' ;;
+esac
+`
+	require.NoError(t, os.WriteFile(path, []byte(script), 0o755))
+	return path
+}
+
+// commandResults runs cmd, expanding batches, and returns the results of
+// the validate_command runs it carries.
+func commandResults(cmd tea.Cmd) []commandResultMsg {
+	if cmd == nil {
+		return nil
+	}
+	switch msg := cmd().(type) {
+	case commandResultMsg:
+		return []commandResultMsg{msg}
+	case tea.BatchMsg:
+		var results []commandResultMsg
+		for _, c := range msg {
+			results = append(results, commandResults(c)...)
+		}
+		return results
+	}
+	return nil
+}
+
+// deliver feeds the results carried by cmd back into m and returns the
+// command of the last update.
+func deliver(m tea.Model, cmd tea.Cmd) tea.Cmd {
+	var last tea.Cmd
+	for _, result := range commandResults(cmd) {
+		_, last = m.Update(result)
+	}
+	return last
+}
+
+func TestRunValidateCommand(t *testing.T) {
+	script := checkUserScript(t)
+
+	tests := []struct {
+		name    string
+		args    []string
+		timeout time.Duration
+		value   string
+		want    string
+	}{
+		{"accepted", []string{script, "{{value}}"}, time.Second, "maria", ""},
+		{"json error", []string{script, "{{value}}"}, time.Second, "admin", "Usuário já existe"},
+		{"exit status with stderr", []string{script, "{{value}}"}, time.Second, "root", "nome reservado"},
+		{"exit status without output", []string{"false"}, time.Second, "x", "Valor recusado pela validação"},
+		{"timeout", []string{"sleep", "5"}, 100 * time.Millisecond, "x", "A validação não respondeu em 100ms"},
+		{"missing program", []string{"./nao-existe.sh"}, time.Second, "x", "Não foi possível executar a validação: fork/exec ./nao-existe.sh: no such file or directory"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runValidateCommand(&config.ValidateCommand{Args: tt.args, Timeout: tt.timeout}, tt.value)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// userForm returns a form whose first field is checked by checkUserScript.
+func userForm(t *testing.T, user string) *FormModel {
+	t.Helper()
+	cfg := &config.FormConfig{Components: []config.ComponentConfig{
+		{Type: config.TypeTextInput, Name: "user", Default: user, Options: map[string]interface{}{
+			"validate_command": []interface{}{checkUserScript(t), "{{value}}"},
+		}},
+		{Type: config.TypeTextInput, Name: "email"},
+	}}
+	m, err := NewFormModel(cfg, styles.DefaultTheme())
+	require.NoError(t, err)
+	return m
+}
+
+func TestFormModel_CommandValidationOnSubmit(t *testing.T) {
+	m := userForm(t, "admin")
+
+	// The submit waits for the command
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.False(t, m.Submitted())
+	view := ansi.Strip(m.View())
+	assert.Contains(t, view, "Verificando...")
+	assert.Contains(t, view, "Verificando os valores antes de submeter...")
+
+	cmd = deliver(m, cmd)
+	assert.False(t, isQuit(cmd))
+	assert.False(t, m.Submitted())
+	assert.Equal(t, "Usuário já existe", m.components[0].GetError())
+	assert.Contains(t, ansi.Strip(m.View()), "Corrija os campos destacados")
+
+	// A rejected value is not checked again until the field is edited
+	_, cmd = m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.Nil(t, cmd)
+
+	m.Update(tea.KeyPressMsg{Code: 'a', Text: "a"})
+	assert.Empty(t, m.components[0].GetError())
+
+	_, cmd = m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	cmd = deliver(m, cmd)
+	assert.True(t, isQuit(cmd))
+	assert.True(t, m.Submitted())
+}
+
+func TestFormModel_CommandValidationOnBlur(t *testing.T) {
+	m := userForm(t, "root")
+
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	results := commandResults(cmd)
+	require.Len(t, results, 1)
+	m.Update(results[0])
+	assert.Contains(t, ansi.Strip(m.View()), "nome reservado")
+
+	// Leaving the field again with the same value doesn't rerun the command
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift})
+	_, cmd = m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Empty(t, commandResults(cmd))
+
+	// The result of a run superseded by an edit is dropped
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift})
+	m.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	_, cmd = m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	results = commandResults(cmd)
+	require.Len(t, results, 1)
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift})
+	m.Update(tea.KeyPressMsg{Code: 'y', Text: "y"})
+	m.Update(results[0])
+	assert.Empty(t, m.components[0].GetError())
+	assert.False(t, m.commands.busy())
+}
+
+func TestFormModel_CommandValidationNonInteractive(t *testing.T) {
+	m := userForm(t, "admin")
+
+	errs := m.Validate()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "COMMAND_REJECTED", errs[0].Code)
+	assert.Equal(t, "user", errs[0].Field)
+	assert.Equal(t, "Usuário já existe", errs[0].Message)
+}
+
+func TestTabsModel_CommandValidation(t *testing.T) {
+	cfg := &config.TabsConfig{Tabs: []config.TabConfig{
+		{Name: "conta", Label: "Conta", Components: []config.ComponentConfig{
+			{Type: config.TypeTextInput, Name: "user", Default: "admin", Options: map[string]interface{}{
+				"validate_command": []interface{}{checkUserScript(t), "{{value}}"},
+			}},
+		}},
+		{Name: "perfil", Label: "Perfil", Components: []config.ComponentConfig{
+			{Type: config.TypeTextInput, Name: "bio"},
+		}},
+	}}
+	m, err := NewTabsModel(cfg, styles.DefaultTheme())
+	require.NoError(t, err)
+	m.SetStandalone(true)
+	m.switchTab(1)
+
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	cmd = deliver(m, cmd)
+	assert.False(t, isQuit(cmd))
+	assert.Equal(t, 0, m.activeTab)
+	assert.Contains(t, ansi.Strip(m.View()), "Usuário já existe")
+
+	errs := m.Validate()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "conta.user", errs[0].Field)
+}
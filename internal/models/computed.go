@@ -0,0 +1,21 @@
+package models
+
+import "github.com/helton/shantilly/internal/components"
+
+// recompute updates the computed components of comps from the current
+// values, in declaration order, so a computed component sees the fresh
+// value of the computed components declared above it.
+func recompute(comps []components.Component) {
+	var values map[string]interface{}
+	for _, comp := range comps {
+		computed, ok := comp.(*components.Computed)
+		if !ok {
+			continue
+		}
+		if values == nil {
+			values = valuesOf(comps, nil)
+		}
+		computed.Recompute(values)
+		values[computed.Name()] = computed.Value()
+	}
+}
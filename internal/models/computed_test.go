@@ -0,0 +1,93 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hostComponents derive the FQDN and the URL of a host.
+func hostComponents() []config.ComponentConfig {
+	return []config.ComponentConfig{
+		{Type: config.TypeTextInput, Name: "hostname", Label: "Host", Default: "web"},
+		{Type: config.TypeTextInput, Name: "domain", Label: "Domínio", Default: "example.com"},
+		{Type: config.TypeComputed, Name: "fqdn", Label: "FQDN", Compute: "hostname + '.' + domain"},
+		{Type: config.TypeComputed, Name: "url", Label: "URL", Compute: "'https://' + fqdn"},
+	}
+}
+
+func TestFormModel_Computed(t *testing.T) {
+	m, err := NewFormModel(&config.FormConfig{Components: hostComponents()}, styles.DefaultTheme())
+	require.NoError(t, err)
+
+	// Computed from the defaults, before any edit
+	assert.Contains(t, ansi.Strip(m.View()), "web.example.com")
+
+	// Every keystroke recomputes, including fields derived from other computed ones
+	m.Update(tea.KeyPressMsg{Code: '1', Text: "1"})
+	assert.Contains(t, ansi.Strip(m.View()), "https://web1.example.com")
+
+	// Computed fields are skipped by the focus
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Equal(t, 0, m.focusIndex)
+
+	data, err := m.ToJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"hostname": "web1", "domain": "example.com", "fqdn": "web1.example.com", "url": "https://web1.example.com"}`, string(data))
+}
+
+func TestFormModel_ComputedApplyValues(t *testing.T) {
+	m, err := NewFormModel(&config.FormConfig{Components: hostComponents()}, styles.DefaultTheme())
+	require.NoError(t, err)
+
+	require.NoError(t, m.ApplyValues(map[string]interface{}{"hostname": "db", "domain": "corp.local"}))
+	assert.Equal(t, "https://db.corp.local", m.ToMap()["url"])
+
+	// Computed values given as input are ignored
+	require.NoError(t, m.ApplyValues(map[string]interface{}{"fqdn": "x"}))
+	assert.Equal(t, "db.corp.local", m.ToMap()["fqdn"])
+
+	env := map[string]string{"SHANTILLY_VALUE_HOSTNAME": "cache", "SHANTILLY_VALUE_URL": "x"}
+	require.NoError(t, m.ApplyEnvValues(func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}))
+	assert.Equal(t, "https://cache.corp.local", m.ToMap()["url"])
+}
+
+func TestFormModel_ComputedRoundTrip(t *testing.T) {
+	first, err := NewFormModel(&config.FormConfig{Components: hostComponents()}, styles.DefaultTheme())
+	require.NoError(t, err)
+	require.NoError(t, first.ApplyValues(map[string]interface{}{"hostname": "db"}))
+	output, err := first.ToJSON()
+	require.NoError(t, err)
+
+	// The output of a run, fed back as with --values
+	var values map[string]interface{}
+	require.NoError(t, json.Unmarshal(output, &values))
+	second, err := NewFormModel(&config.FormConfig{Components: hostComponents()}, styles.DefaultTheme())
+	require.NoError(t, err)
+	require.NoError(t, second.ApplyValues(values))
+
+	data, err := second.ToJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, string(output), string(data))
+}
+
+func TestTabsModel_Computed(t *testing.T) {
+	m, err := NewTabsModel(&config.TabsConfig{Tabs: []config.TabConfig{
+		{Name: "host", Label: "Host", Components: hostComponents()},
+	}}, styles.DefaultTheme())
+	require.NoError(t, err)
+	m.SetStandalone(true)
+
+	require.NoError(t, m.ApplyValues(map[string]interface{}{"host.hostname": "api"}))
+	assert.Equal(t, "api.example.com", m.ToMap()["host"].(map[string]interface{})["fqdn"])
+}
@@ -0,0 +1,242 @@
+package models
+
+import (
+	"log"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/helton/shantilly/internal/components"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/expr"
+	"github.com/helton/shantilly/internal/styles"
+)
+
+// conditionCheck evaluates the show_if and enable_if expressions of a list
+// of components against their current values. Hidden components are not
+// rendered, focused nor validated; disabled ones are rendered read-only.
+// A nil check has no conditions.
+type conditionCheck struct {
+	conditions map[string]config.Conditions
+	hidden     map[string]bool
+	disabled   map[string]bool
+	omitHidden bool // Hidden components are left out of the values
+}
+
+// newConditionCheck parses the conditions of cfgs and evaluates them on the
+// values of comps.
+func newConditionCheck(cfgs []config.ComponentConfig, comps []components.Component, omitHidden bool) (*conditionCheck, error) {
+	conditions, err := config.CollectConditions(cfgs)
+	if err != nil {
+		return nil, err
+	}
+	c := &conditionCheck{
+		conditions: conditions,
+		hidden:     make(map[string]bool),
+		disabled:   make(map[string]bool),
+		omitHidden: omitHidden,
+	}
+	c.refresh(comps)
+	return c, nil
+}
+
+// refresh evaluates the conditions on the current values of comps. Hidden
+// components count as empty for the conditions of the others, so a chain of
+// follow-up questions hides as a whole; passes repeat until it settles.
+func (c *conditionCheck) refresh(comps []components.Component) {
+	if c == nil || len(c.conditions) == 0 {
+		return
+	}
+
+	hidden := make(map[string]bool)
+	values := valuesOf(comps, hidden)
+	for pass := 0; pass <= len(comps); pass++ {
+		next := make(map[string]bool)
+		for name, conds := range c.conditions {
+			if conds.ShowIf != nil && !holds(conds.ShowIf, name, values) {
+				next[name] = true
+			}
+		}
+		settled := len(next) == len(hidden)
+		for name := range next {
+			settled = settled && hidden[name]
+		}
+		hidden = next
+		values = valuesOf(comps, hidden)
+		if settled {
+			break
+		}
+	}
+
+	disabled := make(map[string]bool)
+	for name, conds := range c.conditions {
+		if conds.EnableIf != nil && !holds(conds.EnableIf, name, values) {
+			disabled[name] = true
+		}
+	}
+	c.hidden = hidden
+	c.disabled = disabled
+}
+
+// holds evaluates a condition of the named component. A condition that
+// fails to evaluate doesn't hold.
+func holds(e *expr.Expr, name string, values map[string]interface{}) bool {
+	ok, err := e.Bool(values)
+	if err != nil {
+		log.Printf("erro ao avaliar condição do componente %s (%s): %v", name, e, err)
+		return false
+	}
+	return ok
+}
+
+// shown reports whether the named component is visible.
+func (c *conditionCheck) shown(name string) bool {
+	return c == nil || !c.hidden[name]
+}
+
+// enabled reports whether the named component is visible and editable.
+func (c *conditionCheck) enabled(name string) bool {
+	return c == nil || (!c.hidden[name] && !c.disabled[name])
+}
+
+// visible returns the components of comps that are not hidden.
+func (c *conditionCheck) visible(comps []components.Component) []components.Component {
+	if c == nil || len(c.hidden) == 0 {
+		return comps
+	}
+	var result []components.Component
+	for _, comp := range comps {
+		if c.shown(comp.Name()) {
+			result = append(result, comp)
+		}
+	}
+	return result
+}
+
+// active returns the components of comps the user can edit, the ones that
+// are validated.
+func (c *conditionCheck) active(comps []components.Component) []components.Component {
+	if c == nil || (len(c.hidden) == 0 && len(c.disabled) == 0) {
+		return comps
+	}
+	var result []components.Component
+	for _, comp := range comps {
+		if c.enabled(comp.Name()) {
+			result = append(result, comp)
+		}
+	}
+	return result
+}
+
+// values returns the values of comps by name, without the hidden ones when
+// omit_hidden is set.
+func (c *conditionCheck) values(comps []components.Component) map[string]interface{} {
+	if c != nil && c.omitHidden {
+		return valuesOf(c.visible(comps), nil)
+	}
+	return valuesOf(comps, nil)
+}
+
+// render dims the view of a disabled component.
+func (c *conditionCheck) render(theme *styles.Theme, comp components.Component, view string) string {
+	if c.enabled(comp.Name()) {
+		return view
+	}
+	return theme.Help.Render(ansi.Strip(view))
+}
+
+// valuesOf returns the values of comps by name; hidden ones are nil.
+func valuesOf(comps []components.Component, hidden map[string]bool) map[string]interface{} {
+	values := make(map[string]interface{}, len(comps))
+	for _, comp := range comps {
+		if hidden[comp.Name()] {
+			values[comp.Name()] = nil
+			continue
+		}
+		values[comp.Name()] = comp.Value()
+	}
+	return values
+}
+
+// onFields keeps the errors reported on fields among comps.
+func onFields(errs []components.ValidationError, comps []components.Component) []components.ValidationError {
+	names := make(map[string]bool, len(comps))
+	for _, comp := range comps {
+		names[comp.Name()] = true
+	}
+	var result []components.ValidationError
+	for _, e := range errs {
+		if names[e.Field] {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// focusable reports whether the component at index can take focus now.
+func (m *FormModel) focusable(index int) bool {
+	comp := m.components[index]
+	return comp.CanFocus() && m.conditions.enabled(comp.Name())
+}
+
+// valuesChanged recomputes the computed fields and reevaluates the
+// conditions after the values changed, moving focus away from a component
+// that was hidden or disabled.
+func (m *FormModel) valuesChanged() {
+	recompute(m.components)
+	m.conditions.refresh(m.components)
+	if m.focusIndex < 0 || !m.focusable(m.focusIndex) {
+		m.focusNext()
+	}
+}
+
+// focusable reports whether the component at index can take focus now.
+func (m *LayoutModel) focusable(index int) bool {
+	comp := m.components[index]
+	return comp.CanFocus() && m.conditions.enabled(comp.Name())
+}
+
+// valuesChanged recomputes the computed fields and reevaluates the
+// conditions after the values changed, moving focus away from a component
+// that was hidden or disabled.
+func (m *LayoutModel) valuesChanged() {
+	recompute(m.components)
+	m.conditions.refresh(m.components)
+	if m.focusIndex < 0 || !m.focusable(m.focusIndex) {
+		m.focusNext()
+	}
+}
+
+// focusable reports whether component index of the tab at tabIndex can take
+// focus now.
+func (t *TabsModel) focusable(tabIndex, index int) bool {
+	tab := &t.tabs[tabIndex]
+	comp := tab.Components[index]
+	return comp.CanFocus() && tab.conditions.enabled(comp.Name())
+}
+
+// valuesChanged recomputes the computed fields and reevaluates the
+// conditions of every tab after the values changed, moving focus away from
+// components that were hidden or disabled.
+func (t *TabsModel) valuesChanged() {
+	for i := range t.tabs {
+		tab := &t.tabs[i]
+		recompute(tab.Components)
+		tab.conditions.refresh(tab.Components)
+		if tab.focusIndex >= 0 && t.focusable(i, tab.focusIndex) {
+			continue
+		}
+
+		if i == t.activeTab {
+			t.setActiveFocus(false)
+		}
+		tab.focusIndex = -1
+		for j := range tab.Components {
+			if t.focusable(i, j) {
+				tab.focusIndex = j
+				break
+			}
+		}
+		if i == t.activeTab {
+			t.setActiveFocus(true)
+		}
+	}
+}
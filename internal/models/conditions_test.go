@@ -0,0 +1,97 @@
+package models
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// deployComponents asks for a region and a zone only in production, and
+// enables TLS only when a region is chosen.
+func deployComponents() []config.ComponentConfig {
+	return []config.ComponentConfig{
+		{Type: config.TypeRadioGroup, Name: "env", Label: "Ambiente", Default: "dev", Options: map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{"id": "dev", "label": "Desenvolvimento"},
+				map[string]interface{}{"id": "prod", "label": "Produção"},
+			},
+		}},
+		{Type: config.TypeTextInput, Name: "region", Label: "Região", Required: true, ShowIf: "env == 'prod'"},
+		{Type: config.TypeTextInput, Name: "zone", Label: "Zona", Default: "a", ShowIf: "region == 'us'"},
+		{Type: config.TypeCheckbox, Name: "tls", Label: "TLS", EnableIf: "region"},
+	}
+}
+
+func TestFormModel_Conditions(t *testing.T) {
+	m, err := NewFormModel(&config.FormConfig{Components: deployComponents()}, styles.DefaultTheme())
+	require.NoError(t, err)
+
+	// Hidden components are not rendered, focused nor validated
+	view := ansi.Strip(m.View())
+	assert.NotContains(t, view, "Região")
+	assert.Contains(t, view, "TLS")
+	assert.True(t, m.CanSubmit())
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Equal(t, 0, m.focusIndex)
+
+	// Values of hidden components are kept unless omit_hidden is set
+	assert.Equal(t, map[string]interface{}{"env": "dev", "region": "", "zone": "a", "tls": false}, m.ToMap())
+
+	// Choosing production shows the follow-up question
+	m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	m.Update(tea.KeyPressMsg{Code: tea.KeySpace})
+	assert.Contains(t, ansi.Strip(m.View()), "Região")
+	assert.NotContains(t, ansi.Strip(m.View()), "Zona")
+	assert.False(t, m.CanSubmit())
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Equal(t, 1, m.focusIndex)
+	m.Update(tea.KeyPressMsg{Code: 'u', Text: "u"})
+	m.Update(tea.KeyPressMsg{Code: 's', Text: "s"})
+	assert.Contains(t, ansi.Strip(m.View()), "Zona")
+	assert.True(t, m.CanSubmit())
+
+	// The enabled checkbox now takes focus
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Equal(t, 3, m.focusIndex)
+}
+
+func TestFormModel_ConditionsHideChains(t *testing.T) {
+	m, err := NewFormModel(&config.FormConfig{Components: deployComponents(), OmitHidden: true}, styles.DefaultTheme())
+	require.NoError(t, err)
+
+	// The zone depends on a region that is itself hidden
+	require.NoError(t, m.ApplyValues(map[string]interface{}{"region": "us"}))
+	assert.Equal(t, map[string]interface{}{"env": "dev", "tls": false}, m.ToMap())
+	assert.Empty(t, m.Validate())
+
+	require.NoError(t, m.ApplyValues(map[string]interface{}{"env": "prod"}))
+	assert.Equal(t, map[string]interface{}{"env": "prod", "region": "us", "zone": "a", "tls": false}, m.ToMap())
+}
+
+func TestTabsModel_Conditions(t *testing.T) {
+	m, err := NewTabsModel(&config.TabsConfig{OmitHidden: true, Tabs: []config.TabConfig{
+		{Name: "deploy", Label: "Deploy", Components: deployComponents()},
+	}}, styles.DefaultTheme())
+	require.NoError(t, err)
+	m.SetStandalone(true)
+
+	assert.Empty(t, m.Validate())
+	assert.Equal(t, map[string]interface{}{"deploy": map[string]interface{}{"env": "dev", "tls": false}}, m.ToMap())
+
+	// Shown but empty, the required region blocks the submit
+	require.NoError(t, m.ApplyValues(map[string]interface{}{"deploy.env": "prod"}))
+	errs := m.Validate()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "deploy.region", errs[0].Field)
+
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.False(t, isQuit(cmd))
+	assert.Equal(t, 1, m.tabs[0].focusIndex)
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/charmbracelet/bubbles/v2/spinner"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/helton/shantilly/internal/components"
@@ -26,6 +27,16 @@ type FormModel struct {
 	height      int
 	submitted   bool
 	quitting    bool
+	timedOut    bool              // True when the timeout expired without a submit
+	timer       *countdown        // Nil when the configuration has no timeout
+	labels      map[string]string // Labels for the summary, by component name
+	schema      *schemaCheck      // Nil unless SchemaValidation is enabled
+	shape       shapeFunc         // Document of the schema check, see SetSchemaDocument
+	business    *businessCheck    // Nil unless BusinessValidation declares a custom_validator
+	rules       *ruleCheck        // Nil when the configuration declares no rules
+	commands    *commandCheck     // Nil when no component declares validate_command
+	live        *liveValidation   // Debounced validation and cached validity
+	conditions  *conditionCheck   // show_if and enable_if of the components
 
 	// Error management integration
 	errorManager *errors.ErrorManager
@@ -45,30 +56,34 @@ func NewFormModel(cfg *config.FormConfig, theme *styles.Theme) (*FormModel, erro
 	if err != nil {
 		return nil, fmt.Errorf("erro ao criar componentes: %w", err)
 	}
-
-	// Find first focusable component
-	focusIndex := -1
-	for i, comp := range comps {
-		if comp.CanFocus() {
-			focusIndex = i
-			break
-		}
+	commands, err := config.CollectValidateCommands(cfg.Components)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar componentes: %w", err)
+	}
+	recompute(comps)
+	conditions, err := newConditionCheck(cfg.Components, comps, cfg.OmitHidden)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar componentes: %w", err)
 	}
 
 	m := &FormModel{
 		title:       cfg.Title,
 		description: cfg.Description,
 		components:  comps,
-		focusIndex:  focusIndex,
+		focusIndex:  -1,
 		theme:       theme,
 		width:       80,
 		height:      24,
+		timer:       newCountdown(cfg.Timeout, cfg.OnTimeout),
+		labels:      summaryLabels(cfg.Components),
+		rules:       newRuleCheck(config.CollectRules(cfg.Components, cfg.Rules)),
+		commands:    newCommandCheck(commands, theme),
+		live:        defaultLiveValidation(),
+		conditions:  conditions,
 	}
 
-	// Set initial focus
-	if focusIndex >= 0 {
-		m.components[focusIndex].SetFocus(true)
-	}
+	// Set initial focus on the first focusable component
+	m.focusNext()
 
 	return m, nil
 }
@@ -94,6 +109,9 @@ func (m *FormModel) SetAppModel(appModel *AppModel) {
 
 // Init implements tea.Model.
 func (m *FormModel) Init() tea.Cmd {
+	if m.timer != nil {
+		return m.timer.start()
+	}
 	return nil
 }
 
@@ -113,6 +131,27 @@ func (m *FormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case timeoutTickMsg:
+		return m.handleTimeout(msg)
+
+	case validateTickMsg:
+		if msg.live == m.live {
+			m.live.tick(msg, m.conditions.active(m.components))
+		}
+		return m, nil
+
+	case commandResultMsg:
+		if m.commands != nil && msg.check == m.commands && m.commands.finish(msg) {
+			return m, m.submit()
+		}
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.commands != nil {
+			return m, m.commands.spin(msg)
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c", "esc":
@@ -120,22 +159,17 @@ func (m *FormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 
 		case "tab":
+			cmd := m.leaveField()
 			m.focusNext()
-			return m, nil
+			return m, cmd
 
 		case "shift+tab":
+			cmd := m.leaveField()
 			m.focusPrev()
-			return m, nil
+			return m, cmd
 
 		case "enter":
-			// Check if form can be submitted
-			if m.CanSubmit() {
-				m.submitted = true
-				return m, tea.Quit
-			}
-			// If not valid, validate all to show errors
-			m.validateAll()
-			return m, nil
+			return m, m.submit()
 		}
 	}
 
@@ -146,6 +180,24 @@ func (m *FormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if updatedModel, ok := updated.(components.Component); ok {
 			m.components[m.focusIndex] = updatedModel
 
+			// An edited field gets fresh rule, schema and business checks on the next submit
+			if _, ok := msg.(tea.KeyPressMsg); ok {
+				if m.rules != nil {
+					m.rules.clear(updatedModel.Name(), m.components)
+				}
+				if m.schema != nil {
+					m.schema.clear(updatedModel.Name())
+				}
+				if m.business != nil {
+					m.business.clear()
+				}
+				if m.commands != nil {
+					m.commands.clear(updatedModel.Name())
+				}
+				m.valuesChanged()
+				cmd = tea.Batch(cmd, m.live.edited(updatedModel, m.conditions.active(m.components)))
+			}
+
 			// Update AppModel state if available
 			if m.appModel != nil {
 				// Update form state in AppModel
@@ -207,6 +259,11 @@ func (m *FormModel) View() string {
 		return ""
 	}
 
+	// Once submitted, only a compact summary remains (in the scrollback when inline)
+	if m.submitted {
+		return renderSummary(m.theme, m.title, summaryEntries(m.conditions.visible(m.components), m.labels))
+	}
+
 	var sections []string
 
 	// Title
@@ -219,12 +276,30 @@ func (m *FormModel) View() string {
 		sections = append(sections, m.theme.Description.Render(m.description))
 	}
 
+	// Cached validity: components are revalidated after edits, not on every render
+	canSubmit := m.live.allValid(m.conditions.active(m.components))
+	if m.rules != nil {
+		m.rules.show(m.components)
+	}
+	if m.schema != nil {
+		m.schema.show(m.components, "")
+	}
+	if m.commands != nil {
+		m.commands.show(m.components)
+	}
+
 	// Components (without individual borders - only the form container has a border)
 	for i, comp := range m.components {
-		view := comp.View()
+		if !m.conditions.shown(comp.Name()) {
+			continue
+		}
+		view := m.conditions.render(m.theme, comp, comp.View())
+		if m.commands != nil {
+			view = m.commands.decorate(comp.Name(), view)
+		}
 
 		// Apply consistent border-based focus indicator (same as LayoutModel)
-		if i == m.focusIndex && comp.CanFocus() {
+		if i == m.focusIndex && m.focusable(i) {
 			view = m.theme.BorderActive.Render(view)
 		} else {
 			view = m.theme.Border.Render(view)
@@ -234,7 +309,16 @@ func (m *FormModel) View() string {
 	}
 
 	// Submit help
-	if m.CanSubmit() {
+	if m.schema != nil && m.schema.pending() {
+		sections = append(sections, m.schema.view(m.theme)...)
+		sections = append(sections, m.theme.Error.Render("Os valores não atendem ao schema"))
+	} else if m.business != nil && m.business.pending() {
+		sections = append(sections, m.business.view(m.theme)...)
+	} else if (m.rules != nil && m.rules.pending()) || (m.commands != nil && m.commands.pending()) {
+		sections = append(sections, m.theme.Error.Render("Corrija os campos destacados"))
+	} else if m.commands != nil && m.commands.submitting {
+		sections = append(sections, m.theme.Help.Render("Verificando os valores antes de submeter..."))
+	} else if canSubmit {
 		sections = append(sections, m.theme.Help.Render("Pressione Enter para submeter"))
 	} else {
 		sections = append(sections, m.theme.Error.Render("Complete todos os campos obrigatórios"))
@@ -243,6 +327,11 @@ func (m *FormModel) View() string {
 	// Navigation help
 	sections = append(sections, m.theme.Help.Render("Tab/Shift+Tab: Navegar | Esc: Sair"))
 
+	// Timeout countdown
+	if m.timer != nil {
+		sections = append(sections, m.timer.view(m.theme))
+	}
+
 	// Don't apply border to container since individual components now have borders
 	return lipgloss.JoinVertical(lipgloss.Left, sections...)
 }
@@ -257,12 +346,13 @@ func (m *FormModel) focusNext() {
 	start := m.focusIndex + 1
 	for i := 0; i < len(m.components); i++ {
 		idx := (start + i) % len(m.components)
-		if m.components[idx].CanFocus() {
+		if m.focusable(idx) {
 			m.focusIndex = idx
 			m.components[idx].SetFocus(true)
 			return
 		}
 	}
+	m.focusIndex = -1
 }
 
 // focusPrev moves focus to the previous focusable component.
@@ -282,18 +372,19 @@ func (m *FormModel) focusPrev() {
 		if idx < 0 {
 			idx += len(m.components)
 		}
-		if m.components[idx].CanFocus() {
+		if m.focusable(idx) {
 			m.focusIndex = idx
 			m.components[idx].SetFocus(true)
 			return
 		}
 	}
+	m.focusIndex = -1
 }
 
-// CanSubmit returns true if all components are valid.
+// CanSubmit returns true if all visible, enabled components are valid.
 func (m *FormModel) CanSubmit() bool {
 	allValid := true
-	for _, comp := range m.components {
+	for _, comp := range m.conditions.active(m.components) {
 		if !comp.IsValid() {
 			allValid = false
 
@@ -312,11 +403,85 @@ func (m *FormModel) CanSubmit() bool {
 	return allValid
 }
 
+// submit quits with the values when every check passes, or shows the
+// errors. Command validators run last; while they run the submit waits and
+// is retried when their results arrive.
+func (m *FormModel) submit() tea.Cmd {
+	if m.CanSubmit() && m.followsRules() && m.conformsToSchema() && m.followsBusinessRules() {
+		if m.commands == nil {
+			m.submitted = true
+			return tea.Quit
+		}
+		ok, cmd := m.commands.verify(m.conditions.active(m.components))
+		if ok {
+			m.submitted = true
+			return tea.Quit
+		}
+		if cmd != nil {
+			return cmd
+		}
+	}
+	// If not valid, validate all to show errors
+	m.validateAll()
+	return nil
+}
+
+// leaveField marks the focused component as touched as focus moves away
+// from it and starts its command validator, if its own checks pass.
+func (m *FormModel) leaveField() tea.Cmd {
+	if m.focusIndex < 0 {
+		return nil
+	}
+	comp := m.components[m.focusIndex]
+	m.live.left(comp, m.conditions.active(m.components))
+	if m.commands == nil || !comp.IsValid() {
+		return nil
+	}
+	return m.commands.start(comp)
+}
+
 // validateAll validates all components to trigger error display.
 func (m *FormModel) validateAll() {
-	for _, comp := range m.components {
-		comp.IsValid()
+	m.live.attempt(m.conditions.active(m.components))
+	if m.rules != nil {
+		m.rules.run(m.conditions.active(m.components), m.ToMap())
+	}
+	if m.schema != nil {
+		m.schema.show(m.components, "")
+	}
+	if m.commands != nil {
+		m.commands.show(m.components)
+	}
+}
+
+// handleTimeout advances the countdown. On expiry the current values are
+// submitted when on_timeout is submit_defaults and they pass validation;
+// otherwise the form quits as timed out.
+func (m *FormModel) handleTimeout(msg timeoutTickMsg) (tea.Model, tea.Cmd) {
+	if m.timer == nil {
+		return m, nil
+	}
+
+	expired, cmd := m.timer.update(msg)
+	if !expired {
+		return m, cmd
 	}
+
+	// There is no time left to wait for command validators: only values
+	// they already accepted are submitted
+	if m.timer.submitsDefaults() && m.CanSubmit() && m.followsRules() && m.conformsToSchema() && m.followsBusinessRules() &&
+		(m.commands == nil || m.commands.verified(m.conditions.active(m.components))) {
+		m.submitted = true
+	} else {
+		m.timedOut = true
+		m.quitting = true
+	}
+	return m, tea.Quit
+}
+
+// TimedOut returns true if the timeout expired without a submit.
+func (m *FormModel) TimedOut() bool {
+	return m.timedOut
 }
 
 // Submitted returns true if the form was successfully submitted.
@@ -327,13 +492,7 @@ func (m *FormModel) Submitted() bool {
 // ToJSON serializes the form data to JSON.
 // Returns a JSON byte array with component names as keys and values.
 func (m *FormModel) ToJSON() ([]byte, error) {
-	data := make(map[string]interface{})
-
-	for _, comp := range m.components {
-		data[comp.Name()] = comp.Value()
-	}
-
-	jsonData, err := json.MarshalIndent(data, "", "  ")
+	jsonData, err := json.MarshalIndent(m.ToMap(), "", "  ")
 	if err != nil {
 		return nil, fmt.Errorf("erro ao serializar dados: %w", err)
 	}
@@ -342,12 +501,7 @@ func (m *FormModel) ToJSON() ([]byte, error) {
 }
 
 // ToMap returns the form data as a map for programmatic access.
+// Hidden components are left out when omit_hidden is set.
 func (m *FormModel) ToMap() map[string]interface{} {
-	data := make(map[string]interface{})
-
-	for _, comp := range m.components {
-		data[comp.Name()] = comp.Value()
-	}
-
-	return data
+	return m.conditions.values(m.components)
 }
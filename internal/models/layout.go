@@ -1,8 +1,10 @@
 package models
 
 import (
+	"encoding/json"
 	"fmt"
 
+	"github.com/charmbracelet/bubbles/v2/spinner"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/helton/shantilly/internal/components"
@@ -11,7 +13,8 @@ import (
 )
 
 // LayoutModel orchestrates components in a horizontal or vertical layout.
-// It manages focus navigation and responsive resizing.
+// It manages focus navigation, responsive resizing, validation aggregation
+// and JSON serialization.
 type LayoutModel struct {
 	title       string
 	description string
@@ -21,7 +24,16 @@ type LayoutModel struct {
 	theme       *styles.Theme
 	width       int
 	height      int
+	submitted   bool
 	quitting    bool
+	timedOut    bool              // True when the timeout expired without a submit
+	timer       *countdown        // Nil when the configuration has no timeout
+	labels      map[string]string // Labels for the summary, by component name
+	rules       *ruleCheck        // Nil when no component declares rules
+	schema      *schemaCheck      // Nil unless SchemaValidation is enabled
+	commands    *commandCheck     // Nil when no component declares validate_command
+	live        *liveValidation   // Debounced validation and cached validity
+	conditions  *conditionCheck   // show_if and enable_if of the components
 }
 
 // NewLayoutModel creates a new LayoutModel from configuration.
@@ -35,14 +47,14 @@ func NewLayoutModel(cfg *config.LayoutConfig, theme *styles.Theme) (*LayoutModel
 	if err != nil {
 		return nil, fmt.Errorf("erro ao criar componentes: %w", err)
 	}
-
-	// Find first focusable component
-	focusIndex := -1
-	for i, comp := range comps {
-		if comp.CanFocus() {
-			focusIndex = i
-			break
-		}
+	commands, err := config.CollectValidateCommands(cfg.Components)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar componentes: %w", err)
+	}
+	recompute(comps)
+	conditions, err := newConditionCheck(cfg.Components, comps, cfg.OmitHidden)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar componentes: %w", err)
 	}
 
 	m := &LayoutModel{
@@ -50,22 +62,29 @@ func NewLayoutModel(cfg *config.LayoutConfig, theme *styles.Theme) (*LayoutModel
 		description: cfg.Description,
 		layout:      cfg.Layout,
 		components:  comps,
-		focusIndex:  focusIndex,
+		focusIndex:  -1,
 		theme:       theme,
 		width:       80,
 		height:      24,
+		timer:       newCountdown(cfg.Timeout, cfg.OnTimeout),
+		labels:      summaryLabels(cfg.Components),
+		rules:       newRuleCheck(config.CollectRules(cfg.Components, nil)),
+		commands:    newCommandCheck(commands, theme),
+		live:        defaultLiveValidation(),
+		conditions:  conditions,
 	}
 
-	// Set initial focus
-	if focusIndex >= 0 {
-		m.components[focusIndex].SetFocus(true)
-	}
+	// Set initial focus on the first focusable component
+	m.focusNext()
 
 	return m, nil
 }
 
 // Init implements tea.Model.
 func (m *LayoutModel) Init() tea.Cmd {
+	if m.timer != nil {
+		return m.timer.start()
+	}
 	return nil
 }
 
@@ -85,6 +104,27 @@ func (m *LayoutModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case timeoutTickMsg:
+		return m.handleTimeout(msg)
+
+	case validateTickMsg:
+		if msg.live == m.live {
+			m.live.tick(msg, m.conditions.active(m.components))
+		}
+		return m, nil
+
+	case commandResultMsg:
+		if m.commands != nil && msg.check == m.commands && m.commands.finish(msg) {
+			return m, m.submit()
+		}
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.commands != nil {
+			return m, m.commands.spin(msg)
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c", "esc":
@@ -92,12 +132,17 @@ func (m *LayoutModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 
 		case "tab":
+			cmd := m.leaveField()
 			m.focusNext()
-			return m, nil
+			return m, cmd
 
 		case "shift+tab":
+			cmd := m.leaveField()
 			m.focusPrev()
-			return m, nil
+			return m, cmd
+
+		case "enter":
+			return m, m.submit()
 		}
 	}
 
@@ -107,6 +152,21 @@ func (m *LayoutModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		updated, cmd := m.components[m.focusIndex].Update(msg)
 		if updatedModel, ok := updated.(components.Component); ok {
 			m.components[m.focusIndex] = updatedModel
+
+			// An edited field gets fresh rule, schema and command checks
+			if _, ok := msg.(tea.KeyPressMsg); ok {
+				if m.rules != nil {
+					m.rules.clear(updatedModel.Name(), m.components)
+				}
+				if m.schema != nil {
+					m.schema.clear(updatedModel.Name())
+				}
+				if m.commands != nil {
+					m.commands.clear(updatedModel.Name())
+				}
+				m.valuesChanged()
+				cmd = tea.Batch(cmd, m.live.edited(updatedModel, m.conditions.active(m.components)))
+			}
 			return m, cmd
 		} else {
 			// Log error and return unchanged model
@@ -125,6 +185,11 @@ func (m *LayoutModel) View() string {
 		return ""
 	}
 
+	// Once submitted, only a compact summary remains (in the scrollback when inline)
+	if m.submitted {
+		return renderSummary(m.theme, m.title, summaryEntries(m.conditions.visible(m.components), m.labels))
+	}
+
 	var sections []string
 
 	// Title
@@ -137,6 +202,18 @@ func (m *LayoutModel) View() string {
 		sections = append(sections, m.theme.Description.Render(m.description))
 	}
 
+	// Cached validity: components are revalidated after edits, not on every render
+	canSubmit := m.live.allValid(m.conditions.active(m.components))
+	if m.rules != nil {
+		m.rules.show(m.components)
+	}
+	if m.schema != nil {
+		m.schema.show(m.components, "")
+	}
+	if m.commands != nil {
+		m.commands.show(m.components)
+	}
+
 	// Render components according to layout
 	var componentsView string
 	if m.layout == "horizontal" {
@@ -146,9 +223,28 @@ func (m *LayoutModel) View() string {
 	}
 	sections = append(sections, componentsView)
 
+	// Submit help
+	if m.schema != nil && m.schema.pending() {
+		sections = append(sections, m.schema.view(m.theme)...)
+		sections = append(sections, m.theme.Error.Render("Os valores não atendem ao schema"))
+	} else if (m.rules != nil && m.rules.pending()) || (m.commands != nil && m.commands.pending()) {
+		sections = append(sections, m.theme.Error.Render("Corrija os campos destacados"))
+	} else if m.commands != nil && m.commands.submitting {
+		sections = append(sections, m.theme.Help.Render("Verificando os valores antes de submeter..."))
+	} else if canSubmit {
+		sections = append(sections, m.theme.Help.Render("Pressione Enter para submeter"))
+	} else {
+		sections = append(sections, m.theme.Error.Render("Complete todos os campos obrigatórios"))
+	}
+
 	// Navigation help
 	sections = append(sections, m.theme.Help.Render("Tab/Shift+Tab: Navegar | Esc: Sair"))
 
+	// Timeout countdown
+	if m.timer != nil {
+		sections = append(sections, m.timer.view(m.theme))
+	}
+
 	return m.theme.Border.Render(lipgloss.JoinVertical(lipgloss.Left, sections...))
 }
 
@@ -157,10 +253,16 @@ func (m *LayoutModel) View() string {
 func (m *LayoutModel) renderHorizontal() string {
 	var views []string
 	for i, comp := range m.components {
-		view := comp.View()
+		if !m.conditions.shown(comp.Name()) {
+			continue
+		}
+		view := m.conditions.render(m.theme, comp, comp.View())
+		if m.commands != nil {
+			view = m.commands.decorate(comp.Name(), view)
+		}
 
 		// Apply border based on focus state
-		if i == m.focusIndex && comp.CanFocus() {
+		if i == m.focusIndex && m.focusable(i) {
 			view = m.theme.BorderActive.Render(view)
 		} else {
 			view = m.theme.Border.Render(view)
@@ -175,10 +277,16 @@ func (m *LayoutModel) renderHorizontal() string {
 func (m *LayoutModel) renderVertical() string {
 	var views []string
 	for i, comp := range m.components {
-		view := comp.View()
+		if !m.conditions.shown(comp.Name()) {
+			continue
+		}
+		view := m.conditions.render(m.theme, comp, comp.View())
+		if m.commands != nil {
+			view = m.commands.decorate(comp.Name(), view)
+		}
 
 		// Apply border based on focus state
-		if i == m.focusIndex && comp.CanFocus() {
+		if i == m.focusIndex && m.focusable(i) {
 			view = m.theme.BorderActive.Render(view)
 		} else {
 			view = m.theme.Border.Render(view)
@@ -198,12 +306,13 @@ func (m *LayoutModel) focusNext() {
 	start := m.focusIndex + 1
 	for i := 0; i < len(m.components); i++ {
 		idx := (start + i) % len(m.components)
-		if m.components[idx].CanFocus() {
+		if m.focusable(idx) {
 			m.focusIndex = idx
 			m.components[idx].SetFocus(true)
 			return
 		}
 	}
+	m.focusIndex = -1
 }
 
 // focusPrev moves focus to the previous focusable component.
@@ -223,10 +332,125 @@ func (m *LayoutModel) focusPrev() {
 		if idx < 0 {
 			idx += len(m.components)
 		}
-		if m.components[idx].CanFocus() {
+		if m.focusable(idx) {
 			m.focusIndex = idx
 			m.components[idx].SetFocus(true)
 			return
 		}
 	}
+	m.focusIndex = -1
+}
+
+// CanSubmit returns true if all visible, enabled components are valid.
+func (m *LayoutModel) CanSubmit() bool {
+	allValid := true
+	for _, comp := range m.conditions.active(m.components) {
+		if !comp.IsValid() {
+			allValid = false
+		}
+	}
+	return allValid
+}
+
+// submit quits with the values when every check passes, or shows the
+// errors. Command validators run last; while they run the submit waits and
+// is retried when their results arrive.
+func (m *LayoutModel) submit() tea.Cmd {
+	if m.CanSubmit() && m.followsRules() && m.conformsToSchema() {
+		if m.commands == nil {
+			m.submitted = true
+			return tea.Quit
+		}
+		ok, cmd := m.commands.verify(m.conditions.active(m.components))
+		if ok {
+			m.submitted = true
+			return tea.Quit
+		}
+		if cmd != nil {
+			return cmd
+		}
+	}
+	// If not valid, validate all to show errors
+	m.validateAll()
+	return nil
+}
+
+// leaveField marks the focused component as touched as focus moves away
+// from it and starts its command validator, if its own checks pass.
+func (m *LayoutModel) leaveField() tea.Cmd {
+	if m.focusIndex < 0 {
+		return nil
+	}
+	comp := m.components[m.focusIndex]
+	m.live.left(comp, m.conditions.active(m.components))
+	if m.commands == nil || !comp.IsValid() {
+		return nil
+	}
+	return m.commands.start(comp)
+}
+
+// validateAll validates all components to trigger error display.
+func (m *LayoutModel) validateAll() {
+	m.live.attempt(m.conditions.active(m.components))
+	if m.rules != nil {
+		m.rules.run(m.conditions.active(m.components), m.ToMap())
+	}
+	if m.schema != nil {
+		m.schema.show(m.components, "")
+	}
+	if m.commands != nil {
+		m.commands.show(m.components)
+	}
+}
+
+// handleTimeout advances the countdown. On expiry the current values are
+// submitted when on_timeout is submit_defaults and they pass validation;
+// otherwise the layout quits as timed out.
+func (m *LayoutModel) handleTimeout(msg timeoutTickMsg) (tea.Model, tea.Cmd) {
+	if m.timer == nil {
+		return m, nil
+	}
+
+	expired, cmd := m.timer.update(msg)
+	if !expired {
+		return m, cmd
+	}
+
+	// There is no time left to wait for command validators: only values
+	// they already accepted are submitted
+	if m.timer.submitsDefaults() && m.CanSubmit() && m.followsRules() && m.conformsToSchema() &&
+		(m.commands == nil || m.commands.verified(m.conditions.active(m.components))) {
+		m.submitted = true
+	} else {
+		m.timedOut = true
+		m.quitting = true
+	}
+	return m, tea.Quit
+}
+
+// TimedOut returns true if the timeout expired without a submit.
+func (m *LayoutModel) TimedOut() bool {
+	return m.timedOut
+}
+
+// Submitted returns true if the layout was successfully submitted.
+func (m *LayoutModel) Submitted() bool {
+	return m.submitted
+}
+
+// ToJSON serializes the layout data to JSON.
+// Returns a JSON byte array with component names as keys and values.
+func (m *LayoutModel) ToJSON() ([]byte, error) {
+	jsonData, err := json.MarshalIndent(m.ToMap(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao serializar dados: %w", err)
+	}
+
+	return jsonData, nil
+}
+
+// ToMap returns the layout data as a map for programmatic access.
+// Hidden components are left out when omit_hidden is set.
+func (m *LayoutModel) ToMap() map[string]interface{} {
+	return m.conditions.values(m.components)
 }
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
@@ -620,7 +621,7 @@ func TestLayoutModel_ComponentInteraction(t *testing.T) {
 	keyMsg := tea.KeyPressMsg{Text: "test", Code: 't'}
 	model, cmd := lm.Update(keyMsg)
 	assert.Equal(t, lm, model)
-	assert.Nil(t, cmd)
+	assert.NotNil(t, cmd) // Debounced revalidation of the edited field
 
 	// The component should have received the message
 	// (We can't easily test the internal state change, but we can
@@ -814,3 +815,42 @@ func TestLayoutModel_ErrorSimulation(t *testing.T) {
 		assert.Equal(t, -1, lm.focusIndex) // Should remain -1
 	})
 }
+
+func TestLayoutModel_Submit(t *testing.T) {
+	theme := styles.DefaultTheme()
+	cfg := &config.LayoutConfig{
+		Layout: "horizontal",
+		Components: []config.ComponentConfig{
+			{Name: "host", Type: config.TypeTextInput, Required: true},
+			{Name: "tls", Type: config.TypeCheckbox},
+		},
+	}
+
+	lm, err := NewLayoutModel(cfg, theme)
+	require.NoError(t, err)
+	assert.False(t, lm.CanSubmit())
+
+	// Enter with an invalid field shows errors instead of submitting
+	_, cmd := lm.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.False(t, lm.Submitted())
+	assert.NotEmpty(t, lm.components[0].GetError())
+	assert.Contains(t, lm.View(), "Complete todos os campos obrigatórios")
+
+	for _, r := range "db.local" {
+		lm.Update(tea.KeyPressMsg{Text: string(r), Code: r})
+	}
+	assert.True(t, lm.CanSubmit())
+
+	_, cmd = lm.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	assert.True(t, lm.Submitted())
+
+	assert.Equal(t, map[string]interface{}{"host": "db.local", "tls": false}, lm.ToMap())
+
+	jsonData, err := lm.ToJSON()
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(jsonData, &decoded))
+	assert.Equal(t, "db.local", decoded["host"])
+}
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/helton/shantilly/internal/components"
+	"github.com/helton/shantilly/internal/config"
+)
+
+// validateTickMsg fires when the debounce delay after an edit has passed.
+type validateTickMsg struct {
+	live *liveValidation // The validation that scheduled the tick
+	seq  int             // Edit the tick belongs to; older ticks are dropped
+}
+
+// liveValidation validates the components as the user types, following
+// ValidationConfig.Component: after each edit it waits DebounceMs before
+// revalidating, and shows errors only on fields the user has touched until
+// a submit is attempted. The validity of every component is cached, so
+// rendering doesn't revalidate the whole form.
+type liveValidation struct {
+	realTime  bool
+	debounce  time.Duration
+	touched   map[components.Component]bool
+	valid     map[components.Component]bool // Nil until the first refresh
+	seq       int
+	attempted bool // A submit was attempted: every error is shown
+}
+
+// newLiveValidation returns the validation described by cfg.
+func newLiveValidation(cfg config.ComponentValidation) *liveValidation {
+	return &liveValidation{
+		realTime: cfg.RealTime,
+		debounce: time.Duration(cfg.DebounceMs) * time.Millisecond,
+		touched:  make(map[components.Component]bool),
+	}
+}
+
+// defaultLiveValidation returns the validation of the default application
+// configuration.
+func defaultLiveValidation() *liveValidation {
+	return newLiveValidation(config.DefaultConfig().Validation.Component)
+}
+
+// edited marks comp as touched and schedules the revalidation of comps
+// once the user stops typing for the debounce delay.
+func (v *liveValidation) edited(comp components.Component, comps []components.Component) tea.Cmd {
+	v.touched[comp] = true
+	v.seq++
+	if v.debounce <= 0 {
+		v.refresh(comps)
+		return nil
+	}
+	tick := validateTickMsg{live: v, seq: v.seq}
+	return tea.Tick(v.debounce, func(time.Time) tea.Msg {
+		return tick
+	})
+}
+
+// left marks comp as touched when focus moves away from it, showing its
+// errors right away in real-time mode.
+func (v *liveValidation) left(comp components.Component, comps []components.Component) {
+	v.touched[comp] = true
+	v.refresh(comps)
+}
+
+// tick revalidates comps if msg belongs to the latest edit.
+func (v *liveValidation) tick(msg validateTickMsg, comps []components.Component) {
+	if msg.seq == v.seq {
+		v.refresh(comps)
+	}
+}
+
+// attempt records a submit attempt and revalidates comps, showing every
+// error from now on.
+func (v *liveValidation) attempt(comps []components.Component) {
+	v.attempted = true
+	v.refresh(comps)
+}
+
+// refresh validates comps, caches the results and hides the errors the
+// user should not see yet.
+func (v *liveValidation) refresh(comps []components.Component) {
+	if v.valid == nil {
+		v.valid = make(map[components.Component]bool, len(comps))
+	}
+	for _, comp := range comps {
+		v.valid[comp] = comp.IsValid()
+		if !v.shows(comp) {
+			comp.SetError("")
+		}
+	}
+}
+
+// shows reports whether the errors of comp are displayed: after a submit
+// attempt, or in real-time mode once the user touched it.
+func (v *liveValidation) shows(comp components.Component) bool {
+	return v.attempted || (v.realTime && v.touched[comp])
+}
+
+// allValid returns the cached validity of comps. Components missing from
+// the cache, on the first call or when show_if just revealed them, are
+// validated now rather than counted as valid.
+func (v *liveValidation) allValid(comps []components.Component) bool {
+	var missing []components.Component
+	for _, comp := range comps {
+		if _, ok := v.valid[comp]; !ok {
+			missing = append(missing, comp)
+		}
+	}
+	if len(missing) > 0 {
+		v.refresh(missing)
+	}
+	for _, comp := range comps {
+		if !v.valid[comp] {
+			return false
+		}
+	}
+	return true
+}
+
+// SetComponentValidation replaces the real-time validation settings of the
+// form, typically those of the application configuration.
+func (m *FormModel) SetComponentValidation(cfg config.ComponentValidation) {
+	m.live = newLiveValidation(cfg)
+}
+
+// SetComponentValidation replaces the real-time validation settings of the
+// layout, typically those of the application configuration.
+func (m *LayoutModel) SetComponentValidation(cfg config.ComponentValidation) {
+	m.live = newLiveValidation(cfg)
+}
+
+// SetComponentValidation replaces the real-time validation settings of
+// every tab, typically those of the application configuration.
+func (t *TabsModel) SetComponentValidation(cfg config.ComponentValidation) {
+	t.live = newLiveValidation(cfg)
+}
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/helton/shantilly/internal/components"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingComponent counts the validations of the wrapped component.
+type countingComponent struct {
+	components.Component
+	calls int
+}
+
+func (c *countingComponent) IsValid() bool {
+	c.calls++
+	return c.Component.IsValid()
+}
+
+// liveForm returns a form with two required fields, the first one requiring
+// at least three characters.
+func liveForm(t *testing.T, cfg config.ComponentValidation) *FormModel {
+	t.Helper()
+	m, err := NewFormModel(&config.FormConfig{Components: []config.ComponentConfig{
+		{Type: config.TypeTextInput, Name: "user", Required: true, Options: map[string]interface{}{"min_length": 3}},
+		{Type: config.TypeTextInput, Name: "email", Required: true},
+	}}, styles.DefaultTheme())
+	require.NoError(t, err)
+	m.SetComponentValidation(cfg)
+	return m
+}
+
+func TestFormModel_LiveValidationDebounce(t *testing.T) {
+	m := liveForm(t, config.ComponentValidation{RealTime: true, DebounceMs: 300})
+
+	// Untouched fields don't show their errors
+	view := ansi.Strip(m.View())
+	assert.NotContains(t, view, "Este campo é obrigatório")
+	assert.Contains(t, view, "Complete todos os campos obrigatórios")
+
+	// The error waits for the debounce delay
+	_, cmd := m.Update(tea.KeyPressMsg{Code: 'a', Text: "a"})
+	assert.NotNil(t, cmd)
+	m.Update(tea.KeyPressMsg{Code: 'b', Text: "b"})
+	assert.Empty(t, m.components[0].GetError())
+
+	// A tick of a superseded edit is dropped
+	m.Update(validateTickMsg{live: m.live, seq: 1})
+	assert.Empty(t, m.components[0].GetError())
+
+	m.Update(validateTickMsg{live: m.live, seq: 2})
+	assert.NotEmpty(t, m.components[0].GetError())
+	assert.Empty(t, m.components[1].GetError())
+
+	// Leaving a field shows its errors right away
+	m.Update(tea.KeyPressMsg{Code: 'c', Text: "c"})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Empty(t, m.components[0].GetError())
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Equal(t, "Este campo é obrigatório", m.components[1].GetError())
+}
+
+func TestFormModel_LiveValidationOnSubmitOnly(t *testing.T) {
+	m := liveForm(t, config.ComponentValidation{RealTime: false})
+
+	m.Update(tea.KeyPressMsg{Code: 'a', Text: "a"})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Empty(t, m.components[0].GetError())
+	assert.NotContains(t, ansi.Strip(m.View()), "Este campo é obrigatório")
+
+	// A submit attempt shows every error, including untouched fields
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.False(t, isQuit(cmd))
+	assert.NotEmpty(t, m.components[0].GetError())
+	assert.Equal(t, "Este campo é obrigatório", m.components[1].GetError())
+}
+
+func TestFormModel_LiveValidationRevealedField(t *testing.T) {
+	m, err := NewFormModel(&config.FormConfig{Components: deployComponents()}, styles.DefaultTheme())
+	require.NoError(t, err)
+	m.SetComponentValidation(config.ComponentValidation{RealTime: true, DebounceMs: 300})
+	assert.Contains(t, ansi.Strip(m.View()), "Pressione Enter para submeter")
+
+	// Choosing production reveals the required region before the debounce
+	// tick revalidates: the form is not submittable meanwhile
+	m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeySpace})
+	assert.NotNil(t, cmd)
+	view := ansi.Strip(m.View())
+	assert.Contains(t, view, "Região")
+	assert.Contains(t, view, "Complete todos os campos obrigatórios")
+	assert.NotContains(t, view, "Este campo é obrigatório", "the untouched field hides its error")
+}
+
+func TestFormModel_ViewUsesCachedValidity(t *testing.T) {
+	m := liveForm(t, config.ComponentValidation{RealTime: true})
+	counted := &countingComponent{Component: m.components[1]}
+	m.components[1] = counted
+
+	m.View()
+	calls := counted.calls
+	for i := 0; i < 5; i++ {
+		m.View()
+	}
+	assert.Equal(t, calls, counted.calls)
+
+	// Without debounce, edits revalidate right away
+	_, cmd := m.Update(tea.KeyPressMsg{Code: 'a', Text: "a"})
+	assert.Nil(t, cmd)
+	assert.Greater(t, counted.calls, calls)
+}
+
+func TestTabsModel_LiveValidation(t *testing.T) {
+	m, err := NewTabsModel(&config.TabsConfig{Tabs: []config.TabConfig{
+		{Name: "conta", Label: "Conta", Components: []config.ComponentConfig{
+			{Type: config.TypeTextInput, Name: "user", Required: true},
+		}},
+		{Name: "perfil", Label: "Perfil", Components: []config.ComponentConfig{
+			{Type: config.TypeTextInput, Name: "bio", Required: true},
+		}},
+	}}, styles.DefaultTheme())
+	require.NoError(t, err)
+	m.SetStandalone(true)
+	m.SetComponentValidation(config.ComponentValidation{RealTime: true})
+
+	// Leaving the tab touches its focused field
+	m.Update(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModCtrl})
+	assert.Equal(t, 1, m.activeTab)
+	assert.Equal(t, "Este campo é obrigatório", m.tabs[0].Components[0].GetError())
+	assert.Empty(t, m.tabs[1].Components[0].GetError())
+
+	m.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	assert.Empty(t, m.tabs[1].Components[0].GetError())
+	assert.False(t, m.live.allValid(m.activeComponents()))
+}
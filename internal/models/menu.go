@@ -0,0 +1,460 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/styles"
+)
+
+// defaultMenuPageSize is used when neither the configuration nor the
+// terminal height define how many items fit on a page.
+const defaultMenuPageSize = 10
+
+// menuChromeHeight is the number of lines reserved for title, filter,
+// pagination and help when the page size is derived from the window height.
+const menuChromeHeight = 10
+
+// menuMatch is an item that survived the current filter.
+type menuMatch struct {
+	index     int   // Index into MenuModel.items
+	score     int   // Higher is better
+	positions []int // Rune positions that matched the filter
+}
+
+// MenuModel implements a selectable list with fuzzy filtering and pagination.
+// It supports single selection (Enter picks the item under the cursor) and
+// multi selection (Space toggles items, Enter confirms the set).
+type MenuModel struct {
+	title       string
+	description string
+	items       []string
+	multiSelect bool
+	filter      string
+	matches     []menuMatch
+	cursor      int          // Index into matches
+	selected    map[int]bool // Keys are indexes into items
+	pageSize    int
+	fixedPage   bool // True when page_size was set in the configuration
+	theme       *styles.Theme
+	width       int
+	height      int
+	submitted   bool
+	quitting    bool
+}
+
+// NewMenuModel creates a new MenuModel from configuration.
+func NewMenuModel(cfg *config.MenuConfig, theme *styles.Theme) (*MenuModel, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("erro de validação da configuração do menu: %w", err)
+	}
+
+	m := &MenuModel{
+		title:       cfg.Title,
+		description: cfg.Description,
+		items:       append([]string(nil), cfg.Items...),
+		multiSelect: cfg.MultiSelect,
+		selected:    make(map[int]bool),
+		pageSize:    defaultMenuPageSize,
+		theme:       theme,
+		width:       80,
+		height:      24,
+	}
+
+	if cfg.PageSize > 0 {
+		m.pageSize = cfg.PageSize
+		m.fixedPage = true
+	}
+
+	m.applyFilter()
+
+	return m, nil
+}
+
+// Init implements tea.Model.
+func (m *MenuModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (m *MenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		if !m.fixedPage {
+			m.pageSize = max(1, msg.Height-menuChromeHeight)
+		}
+		return m, nil
+
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+
+		case "esc":
+			// First Esc clears the filter, second one leaves the menu
+			if m.filter != "" {
+				m.setFilter("")
+				return m, nil
+			}
+			m.quitting = true
+			return m, tea.Quit
+
+		case "enter":
+			if len(m.matches) == 0 && len(m.selected) == 0 {
+				return m, nil
+			}
+			m.submitted = true
+			return m, tea.Quit
+
+		case "up", "ctrl+p", "ctrl+k":
+			m.moveCursor(-1)
+			return m, nil
+
+		case "down", "ctrl+n", "ctrl+j":
+			m.moveCursor(1)
+			return m, nil
+
+		case "pgup", "left":
+			m.moveCursor(-m.pageSize)
+			return m, nil
+
+		case "pgdown", "right":
+			m.moveCursor(m.pageSize)
+			return m, nil
+
+		case "home":
+			m.cursor = 0
+			return m, nil
+
+		case "end":
+			m.cursor = max(0, len(m.matches)-1)
+			return m, nil
+
+		case "space", "tab":
+			if m.multiSelect {
+				m.toggleCurrent()
+				return m, nil
+			}
+
+		case "ctrl+a":
+			if m.multiSelect {
+				m.toggleAllVisible()
+				return m, nil
+			}
+
+		case "backspace":
+			if m.filter != "" {
+				runes := []rune(m.filter)
+				m.setFilter(string(runes[:len(runes)-1]))
+			}
+			return m, nil
+
+		case "ctrl+u":
+			m.setFilter("")
+			return m, nil
+		}
+
+		// Printable characters extend the filter
+		if msg.Text != "" && msg.Mod&^tea.ModShift == 0 {
+			m.setFilter(m.filter + msg.Text)
+		}
+	}
+
+	return m, nil
+}
+
+// View implements tea.Model.
+func (m *MenuModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	// Once submitted, only a compact summary remains (in the scrollback when inline)
+	if m.submitted {
+		label := m.title
+		if label == "" {
+			label = "Seleção"
+		}
+		return renderSummary(m.theme, "", []summaryEntry{{label: label, value: m.Selected()}})
+	}
+
+	var sections []string
+
+	// Title
+	if m.title != "" {
+		sections = append(sections, m.theme.Title.Render(m.title))
+	}
+
+	// Description
+	if m.description != "" {
+		sections = append(sections, m.theme.Description.Render(m.description))
+	}
+
+	// Filter line
+	if m.filter != "" {
+		sections = append(sections, m.theme.Label.Render("Filtro: "+m.filter))
+	} else {
+		sections = append(sections, m.theme.Help.Render("Digite para filtrar..."))
+	}
+
+	// Items of the current page
+	sections = append(sections, m.renderItems())
+
+	// Pagination
+	if pages := m.pageCount(); pages > 1 {
+		sections = append(sections, m.theme.Help.Render(
+			fmt.Sprintf("Página %d/%d (%d itens)", m.currentPage()+1, pages, len(m.matches))))
+	}
+
+	// Navigation help
+	if m.multiSelect {
+		sections = append(sections, m.theme.Help.Render(
+			fmt.Sprintf("↑/↓: Navegar | Espaço: Marcar | Ctrl+A: Marcar todos | Enter: Confirmar (%d) | Esc: Sair", len(m.selected))))
+	} else {
+		sections = append(sections, m.theme.Help.Render("↑/↓: Navegar | ←/→: Página | Enter: Selecionar | Esc: Sair"))
+	}
+
+	return m.theme.Border.Render(lipgloss.JoinVertical(lipgloss.Left, sections...))
+}
+
+// renderItems renders the items visible on the current page.
+func (m *MenuModel) renderItems() string {
+	if len(m.matches) == 0 {
+		return m.theme.Error.Render("Nenhum item corresponde ao filtro")
+	}
+
+	start := m.currentPage() * m.pageSize
+	end := min(len(m.matches), start+m.pageSize)
+
+	var lines []string
+	for i := start; i < end; i++ {
+		match := m.matches[i]
+
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "▶ "
+		}
+
+		if m.multiSelect {
+			if m.selected[match.index] {
+				prefix += m.theme.CheckboxChecked.Render("[✓]") + " "
+			} else {
+				prefix += m.theme.CheckboxUnchecked.Render("[ ]") + " "
+			}
+		}
+
+		style := m.theme.RadioUnselected
+		if i == m.cursor {
+			style = m.theme.RadioSelected
+		}
+
+		lines = append(lines, prefix+m.highlight(m.items[match.index], match.positions, style))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// highlight renders an item underlining the runes that matched the filter.
+func (m *MenuModel) highlight(item string, positions []int, style lipgloss.Style) string {
+	if len(positions) == 0 {
+		return style.Render(item)
+	}
+
+	matched := make(map[int]bool, len(positions))
+	for _, p := range positions {
+		matched[p] = true
+	}
+
+	var b strings.Builder
+	for i, r := range []rune(item) {
+		if matched[i] {
+			b.WriteString(style.Underline(true).Render(string(r)))
+		} else {
+			b.WriteString(style.Render(string(r)))
+		}
+	}
+	return b.String()
+}
+
+// setFilter updates the filter text and recomputes the visible items.
+func (m *MenuModel) setFilter(filter string) {
+	m.filter = filter
+	m.applyFilter()
+}
+
+// applyFilter recomputes matches for the current filter and resets the cursor.
+func (m *MenuModel) applyFilter() {
+	m.matches = m.matches[:0]
+	for i, item := range m.items {
+		score, positions, ok := fuzzyMatch(m.filter, item)
+		if ok {
+			m.matches = append(m.matches, menuMatch{index: i, score: score, positions: positions})
+		}
+	}
+
+	// Best matches first; the original order breaks ties
+	if m.filter != "" {
+		sort.SliceStable(m.matches, func(a, b int) bool {
+			return m.matches[a].score > m.matches[b].score
+		})
+	}
+
+	m.cursor = 0
+}
+
+// moveCursor moves the cursor by delta, clamped to the visible items.
+func (m *MenuModel) moveCursor(delta int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.cursor = max(0, min(len(m.matches)-1, m.cursor+delta))
+}
+
+// toggleCurrent toggles the selection of the item under the cursor.
+func (m *MenuModel) toggleCurrent() {
+	if m.cursor >= len(m.matches) {
+		return
+	}
+	index := m.matches[m.cursor].index
+	if m.selected[index] {
+		delete(m.selected, index)
+	} else {
+		m.selected[index] = true
+	}
+}
+
+// toggleAllVisible selects every visible item, or clears them if all are selected.
+func (m *MenuModel) toggleAllVisible() {
+	allSelected := true
+	for _, match := range m.matches {
+		if !m.selected[match.index] {
+			allSelected = false
+			break
+		}
+	}
+
+	for _, match := range m.matches {
+		if allSelected {
+			delete(m.selected, match.index)
+		} else {
+			m.selected[match.index] = true
+		}
+	}
+}
+
+// currentPage returns the zero-based page that contains the cursor.
+func (m *MenuModel) currentPage() int {
+	return m.cursor / m.pageSize
+}
+
+// pageCount returns the number of pages for the visible items.
+func (m *MenuModel) pageCount() int {
+	return (len(m.matches) + m.pageSize - 1) / m.pageSize
+}
+
+// Submitted returns true if the user confirmed a selection.
+func (m *MenuModel) Submitted() bool {
+	return m.submitted
+}
+
+// Selected returns the chosen items in their original order.
+// In single selection mode it contains at most one item: the one under the cursor.
+// In multi selection mode it falls back to the item under the cursor when
+// nothing was explicitly marked.
+func (m *MenuModel) Selected() []string {
+	if m.multiSelect && len(m.selected) > 0 {
+		result := make([]string, 0, len(m.selected))
+		for i, item := range m.items {
+			if m.selected[i] {
+				result = append(result, item)
+			}
+		}
+		return result
+	}
+
+	if m.cursor < len(m.matches) {
+		return []string{m.items[m.matches[m.cursor].index]}
+	}
+	return []string{}
+}
+
+// ToMap returns the selection as a map for programmatic access.
+// The "selected" key holds a string in single mode and a list in multi mode.
+func (m *MenuModel) ToMap() map[string]interface{} {
+	selected := m.Selected()
+
+	if m.multiSelect {
+		return map[string]interface{}{"selected": selected}
+	}
+
+	value := ""
+	if len(selected) > 0 {
+		value = selected[0]
+	}
+	return map[string]interface{}{"selected": value}
+}
+
+// ToJSON serializes the selection to JSON.
+func (m *MenuModel) ToJSON() ([]byte, error) {
+	jsonData, err := json.MarshalIndent(m.ToMap(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao serializar dados: %w", err)
+	}
+	return jsonData, nil
+}
+
+// fuzzyMatch reports whether every rune of pattern appears in text in order,
+// ignoring case. The score rewards consecutive runs and matches at word starts,
+// so "dpl" ranks "deploy" above "data pipeline".
+func fuzzyMatch(pattern, text string) (int, []int, bool) {
+	if pattern == "" {
+		return 0, nil, true
+	}
+
+	patternRunes := []rune(strings.ToLower(pattern))
+	textRunes := []rune(text)
+
+	var positions []int
+	score := 0
+	p := 0
+	prev := -2
+
+	for i, r := range textRunes {
+		if p == len(patternRunes) {
+			break
+		}
+		if unicode.ToLower(r) != patternRunes[p] {
+			continue
+		}
+
+		score++
+		if i == prev+1 {
+			score += 3 // Consecutive match
+		}
+		if i == 0 || !unicode.IsLetter(textRunes[i-1]) && !unicode.IsDigit(textRunes[i-1]) {
+			score += 2 // Start of a word
+		}
+
+		positions = append(positions, i)
+		prev = i
+		p++
+	}
+
+	if p < len(patternRunes) {
+		return 0, nil, false
+	}
+
+	// Prefer shorter items when scores tie
+	score -= len(textRunes) / 10
+
+	return score, positions, true
+}
@@ -0,0 +1,183 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupMenu is a helper to create a MenuModel for tests.
+func setupMenu(t *testing.T, multi bool, items ...string) *MenuModel {
+	cfg := &config.MenuConfig{
+		Title:       "Escolha",
+		Items:       items,
+		MultiSelect: multi,
+	}
+	m, err := NewMenuModel(cfg, styles.DefaultTheme())
+	require.NoError(t, err)
+	return m
+}
+
+// typeText sends each rune of text as a key press.
+func typeText(m *MenuModel, text string) {
+	for _, r := range text {
+		m.Update(tea.KeyPressMsg{Text: string(r), Code: r})
+	}
+}
+
+func TestNewMenuModel(t *testing.T) {
+	theme := styles.DefaultTheme()
+
+	t.Run("valid menu", func(t *testing.T) {
+		m, err := NewMenuModel(&config.MenuConfig{Items: []string{"a", "b"}}, theme)
+		require.NoError(t, err)
+		assert.Len(t, m.matches, 2)
+		assert.Equal(t, defaultMenuPageSize, m.pageSize)
+		assert.False(t, m.Submitted())
+	})
+
+	t.Run("custom page size", func(t *testing.T) {
+		m, err := NewMenuModel(&config.MenuConfig{Items: []string{"a"}, PageSize: 3}, theme)
+		require.NoError(t, err)
+		assert.Equal(t, 3, m.pageSize)
+
+		m.Update(tea.WindowSizeMsg{Width: 80, Height: 50})
+		assert.Equal(t, 3, m.pageSize, "configured page size must not follow the window")
+	})
+
+	t.Run("empty menu", func(t *testing.T) {
+		m, err := NewMenuModel(&config.MenuConfig{}, theme)
+		assert.Error(t, err)
+		assert.Nil(t, m)
+	})
+}
+
+func TestMenuModel_SingleSelect(t *testing.T) {
+	m := setupMenu(t, false, "alpha", "beta", "gamma")
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	assert.NotNil(t, cmd)
+	assert.True(t, m.Submitted())
+	assert.Equal(t, map[string]interface{}{"selected": "beta"}, m.ToMap())
+
+	jsonData, err := m.ToJSON()
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(jsonData, &decoded))
+	assert.Equal(t, "beta", decoded["selected"])
+}
+
+func TestMenuModel_MultiSelect(t *testing.T) {
+	m := setupMenu(t, true, "alpha", "beta", "gamma")
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	m.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	assert.True(t, m.Submitted())
+	assert.Equal(t, []string{"alpha", "gamma"}, m.Selected())
+	assert.Empty(t, m.filter, "space must toggle, not filter, in multi mode")
+
+	t.Run("falls back to cursor when nothing is marked", func(t *testing.T) {
+		m := setupMenu(t, true, "alpha", "beta")
+		m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+		assert.Equal(t, map[string]interface{}{"selected": []string{"beta"}}, m.ToMap())
+	})
+
+	t.Run("ctrl+a toggles all visible items", func(t *testing.T) {
+		m := setupMenu(t, true, "alpha", "beta")
+		m.Update(tea.KeyPressMsg{Code: 'a', Mod: tea.ModCtrl})
+		assert.Equal(t, []string{"alpha", "beta"}, m.Selected())
+		m.Update(tea.KeyPressMsg{Code: 'a', Mod: tea.ModCtrl})
+		assert.Empty(t, m.selected)
+	})
+}
+
+func TestMenuModel_Filter(t *testing.T) {
+	m := setupMenu(t, false, "data pipeline", "deploy", "docs")
+
+	typeText(m, "dpl")
+	require.Len(t, m.matches, 2)
+	assert.Equal(t, "deploy", m.items[m.matches[0].index], "consecutive matches rank first")
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyBackspace})
+	assert.Equal(t, "dp", m.filter)
+
+	typeText(m, "zz")
+	assert.Empty(t, m.matches)
+	assert.Contains(t, m.View(), "Nenhum item")
+
+	// Enter without matches must not submit
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.False(t, m.Submitted())
+
+	// Esc clears the filter before quitting
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	assert.Nil(t, cmd)
+	assert.Empty(t, m.filter)
+	assert.Len(t, m.matches, 3)
+
+	_, cmd = m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	assert.NotNil(t, cmd)
+	assert.False(t, m.Submitted())
+	assert.Empty(t, m.View())
+}
+
+func TestMenuModel_Pagination(t *testing.T) {
+	items := make([]string, 25)
+	for i := range items {
+		items[i] = string(rune('a' + i))
+	}
+	m := setupMenu(t, false, items...)
+	m.pageSize = 10
+
+	assert.Equal(t, 3, m.pageCount())
+	assert.Contains(t, m.View(), "Página 1/3")
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyPgDown})
+	assert.Equal(t, 10, m.cursor)
+	assert.Equal(t, 1, m.currentPage())
+	assert.Contains(t, m.View(), "Página 2/3")
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnd})
+	assert.Equal(t, 24, m.cursor)
+	m.Update(tea.KeyPressMsg{Code: tea.KeyPgDown})
+	assert.Equal(t, 24, m.cursor, "cursor is clamped to the last item")
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyHome})
+	assert.Equal(t, 0, m.cursor)
+
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 15})
+	assert.Equal(t, 5, m.pageSize)
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern, text string
+		match         bool
+		positions     []int
+	}{
+		{"", "anything", true, nil},
+		{"abc", "aXbXc", true, []int{0, 2, 4}},
+		{"ABC", "abc", true, []int{0, 1, 2}},
+		{"cba", "abc", false, nil},
+		{"ção", "Configuração", true, []int{9, 10, 11}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.text, func(t *testing.T) {
+			_, positions, ok := fuzzyMatch(tt.pattern, tt.text)
+			assert.Equal(t, tt.match, ok)
+			assert.Equal(t, tt.positions, positions)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package models
+
+import (
+	"github.com/helton/shantilly/internal/components"
+	"github.com/helton/shantilly/internal/config"
+)
+
+// ruleCheck evaluates the declarative cross-field rules of a configuration
+// and keeps the broken ones on their fields until a related field is edited.
+type ruleCheck struct {
+	rules   []config.Rule
+	errors  map[string]string   // Message of the first broken rule, by field
+	related map[string][]string // Fields each recorded error depends on
+}
+
+// newRuleCheck returns nil when there are no rules.
+func newRuleCheck(rules []config.Rule) *ruleCheck {
+	if len(rules) == 0 {
+		return nil
+	}
+	return &ruleCheck{rules: rules, errors: make(map[string]string), related: make(map[string][]string)}
+}
+
+// run evaluates the rules on values, records the broken ones and shows them
+// on comps. Rules on fields outside comps, such as hidden ones, are ignored.
+// It returns true when every rule holds.
+func (c *ruleCheck) run(comps []components.Component, values map[string]interface{}) bool {
+	c.errors = make(map[string]string)
+	c.related = make(map[string][]string)
+
+	broken := onFields(c.validationErrors(values), comps)
+	for _, e := range broken {
+		if _, seen := c.errors[e.Field]; seen {
+			continue
+		}
+		c.errors[e.Field] = e.Message
+		c.related[e.Field], _ = e.Context["related_fields"].([]string)
+	}
+
+	c.show(comps)
+	return len(broken) == 0
+}
+
+// show puts the recorded errors back on the components, since IsValid
+// clears the error of a component whose own checks pass.
+func (c *ruleCheck) show(comps []components.Component) {
+	for _, comp := range comps {
+		if msg, ok := c.errors[comp.Name()]; ok && comp.GetError() == "" {
+			comp.SetError(msg)
+		}
+	}
+}
+
+// clear forgets the errors that depend on an edited field and takes them
+// off the components until they are revalidated.
+func (c *ruleCheck) clear(name string, comps []components.Component) {
+	for field, refs := range c.related {
+		for _, ref := range refs {
+			if ref == name {
+				delete(c.errors, field)
+				delete(c.related, field)
+				hideError(comps, field)
+				break
+			}
+		}
+	}
+}
+
+// hideError removes the error shown on the named component.
+func hideError(comps []components.Component, name string) {
+	for _, comp := range comps {
+		if comp.Name() == name {
+			comp.SetError("")
+		}
+	}
+}
+
+// pending reports whether broken rules from the last run are still shown.
+func (c *ruleCheck) pending() bool {
+	return len(c.errors) > 0
+}
+
+// failed reports whether a broken rule is shown on the named field.
+func (c *ruleCheck) failed(name string) bool {
+	_, ok := c.errors[name]
+	return ok
+}
+
+// validationErrors evaluates the rules on values without recording them.
+func (c *ruleCheck) validationErrors(values map[string]interface{}) []components.ValidationError {
+	return components.EvaluateRules(c.rules, components.ValidationContext{ComponentValues: values})
+}
+
+// followsRules evaluates the rules, if any, on the current values of the form.
+func (m *FormModel) followsRules() bool {
+	if m.rules == nil {
+		return true
+	}
+	return m.rules.run(m.conditions.active(m.components), m.ToMap())
+}
+
+// followsRules evaluates the rules, if any, on the current values of the layout.
+func (m *LayoutModel) followsRules() bool {
+	if m.rules == nil {
+		return true
+	}
+	return m.rules.run(m.conditions.active(m.components), m.ToMap())
+}
+
+// followsRules evaluates the rules of every tab on the values of that tab.
+func (t *TabsModel) followsRules() bool {
+	ok := true
+	for i := range t.tabs {
+		tab := &t.tabs[i]
+		if tab.rules == nil {
+			continue
+		}
+		if !tab.rules.run(tab.conditions.active(tab.Components), tab.conditions.values(tab.Components)) {
+			ok = false
+		}
+	}
+	return ok
+}
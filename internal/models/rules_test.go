@@ -0,0 +1,132 @@
+package models
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// passwordComponents returns a password field and a confirmation that must
+// match it, focused on the confirmation.
+func passwordComponents() []config.ComponentConfig {
+	return []config.ComponentConfig{
+		{Type: config.TypeTextInput, Name: "password", Default: "s3cret"},
+		{Type: config.TypeTextInput, Name: "confirm", Default: "secret", Rules: []config.Rule{
+			{Type: config.RuleEquals, Other: "password", Message: "Senhas não coincidem"},
+		}},
+	}
+}
+
+func TestFormModel_RulesBlockSubmit(t *testing.T) {
+	m, err := NewFormModel(&config.FormConfig{Components: passwordComponents()}, styles.DefaultTheme())
+	require.NoError(t, err)
+
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.False(t, isQuit(cmd))
+	assert.False(t, m.Submitted())
+	assert.Equal(t, "Senhas não coincidem", m.components[1].GetError())
+
+	view := ansi.Strip(m.View())
+	assert.Contains(t, view, "Senhas não coincidem")
+	assert.Contains(t, view, "Corrija os campos destacados")
+
+	require.NoError(t, m.ApplyValues(map[string]interface{}{"confirm": "s3cret"}))
+	_, cmd = m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.True(t, isQuit(cmd))
+	assert.True(t, m.Submitted())
+}
+
+func TestFormModel_RuleErrorClearedOnRelatedEdit(t *testing.T) {
+	cfg := &config.FormConfig{
+		Components: []config.ComponentConfig{
+			{Type: config.TypeTextInput, Name: "email"},
+			{Type: config.TypeTextInput, Name: "phone"},
+			{Type: config.TypeTextInput, Name: "nick"},
+		},
+		Rules: []config.Rule{{Type: config.RuleOneOfRequired, Fields: []string{"email", "phone"}}},
+	}
+	m, err := NewFormModel(cfg, styles.DefaultTheme())
+	require.NoError(t, err)
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.Equal(t, "Preencha pelo menos um dos campos: email, phone", m.components[0].GetError())
+
+	// Editing an unrelated field keeps the error
+	m.focusIndex = 2
+	m.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	m.View()
+	assert.NotEmpty(t, m.components[0].GetError())
+
+	// Editing a field the rule depends on clears it
+	m.focusIndex = 1
+	m.Update(tea.KeyPressMsg{Code: '1', Text: "1"})
+	m.View()
+	assert.Empty(t, m.components[0].GetError())
+}
+
+func TestFormModel_RulesValidate(t *testing.T) {
+	m, err := NewFormModel(&config.FormConfig{Components: passwordComponents()}, styles.DefaultTheme())
+	require.NoError(t, err)
+
+	errs := m.Validate()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "RULE_EQUALS", errs[0].Code)
+	assert.Equal(t, "confirm", errs[0].Field)
+	assert.Equal(t, "Senhas não coincidem", errs[0].Message)
+}
+
+func TestLayoutModel_RulesBlockSubmit(t *testing.T) {
+	m, err := NewLayoutModel(&config.LayoutConfig{Layout: "vertical", Components: passwordComponents()}, styles.DefaultTheme())
+	require.NoError(t, err)
+
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.False(t, isQuit(cmd))
+	assert.Equal(t, "Senhas não coincidem", m.components[1].GetError())
+	assert.Contains(t, ansi.Strip(m.View()), "Corrija os campos destacados")
+	assert.Len(t, m.Validate(), 1)
+}
+
+func TestTabsModel_RulesJumpToField(t *testing.T) {
+	cfg := &config.TabsConfig{Tabs: []config.TabConfig{
+		{Name: "profile", Label: "Perfil", Components: []config.ComponentConfig{{Type: config.TypeTextInput, Name: "name"}}},
+		{Name: "access", Label: "Acesso", Components: passwordComponents()},
+	}}
+	m, err := NewTabsModel(cfg, styles.DefaultTheme())
+	require.NoError(t, err)
+	m.SetStandalone(true)
+
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.False(t, isQuit(cmd))
+	assert.Equal(t, 1, m.activeTab)
+	assert.Equal(t, 1, m.tabs[1].focusIndex)
+	assert.Contains(t, ansi.Strip(m.View()), "Senhas não coincidem")
+
+	errs := m.Validate()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "access.confirm", errs[0].Field)
+}
+
+func TestTabsModel_RuleErrorsKeptByUpdate(t *testing.T) {
+	cfg := &config.TabsConfig{Tabs: []config.TabConfig{
+		{Name: "access", Label: "Acesso", Components: passwordComponents()},
+	}}
+	m, err := NewTabsModel(cfg, styles.DefaultTheme())
+	require.NoError(t, err)
+	m.SetStandalone(true)
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	confirm := m.tabs[0].Components[1]
+	require.Equal(t, "Senhas não coincidem", confirm.GetError())
+
+	// Revalidation clears the component error; Update puts the rule back,
+	// so rendering has nothing to fix
+	m.Update(validateTickMsg{live: m.live, seq: m.live.seq})
+	assert.Equal(t, "Senhas não coincidem", confirm.GetError())
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Equal(t, "Senhas não coincidem", confirm.GetError())
+}
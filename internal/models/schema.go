@@ -0,0 +1,264 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/helton/shantilly/internal/components"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/schema"
+	"github.com/helton/shantilly/internal/styles"
+)
+
+// schemaCheck validates the submitted values against the JSON Schema of
+// SchemaValidation and keeps the violations until the fields are edited.
+type schemaCheck struct {
+	schema  *schema.Schema
+	errors  map[string]string // First violation of each component, by name
+	general []string          // Violations not tied to a component
+}
+
+// newSchemaCheck loads the schema referenced by cfg. It returns nil when
+// schema validation is disabled.
+func newSchemaCheck(cfg config.SchemaValidation) (*schemaCheck, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if cfg.SchemaPath == "" {
+		return nil, fmt.Errorf("schema_path é obrigatório quando a validação por schema está habilitada")
+	}
+
+	s, err := schema.Load(cfg.SchemaPath, cfg.StrictSchema)
+	if err != nil {
+		return nil, err
+	}
+	return &schemaCheck{schema: s, errors: make(map[string]string)}, nil
+}
+
+// run validates doc and records each violation under the component it
+// belongs to, among the qualified names in fields ("tab.field" for tabs).
+// It returns true when doc conforms to the schema.
+func (c *schemaCheck) run(doc map[string]interface{}, fields map[string]bool) bool {
+	c.errors = make(map[string]string)
+	c.general = nil
+
+	violations := c.schema.Validate(doc)
+	for _, v := range violations {
+		name, ok := owner(v.Path, fields)
+		if !ok {
+			c.general = append(c.general, v.Error())
+			continue
+		}
+		if _, seen := c.errors[name]; !seen {
+			c.errors[name] = violationMessage(v, name)
+		}
+	}
+	return len(violations) == 0
+}
+
+// show puts the recorded violations back on the components, since IsValid
+// clears the error of a component whose own checks pass. prefix qualifies
+// the component names, as in the tab of a TabsModel.
+func (c *schemaCheck) show(comps []components.Component, prefix string) {
+	for _, comp := range comps {
+		if msg, ok := c.errors[qualified(prefix, comp.Name())]; ok && comp.GetError() == "" {
+			comp.SetError(msg)
+		}
+	}
+}
+
+// clear forgets the violation of an edited component. Document-level
+// violations may depend on any field, so they are dropped too.
+func (c *schemaCheck) clear(name string) {
+	delete(c.errors, name)
+	c.general = nil
+}
+
+// failed reports whether the component with the qualified name has a
+// violation from the last run.
+func (c *schemaCheck) failed(name string) bool {
+	_, ok := c.errors[name]
+	return ok
+}
+
+// pending reports whether violations from the last run are still shown.
+func (c *schemaCheck) pending() bool {
+	return len(c.errors) > 0 || len(c.general) > 0
+}
+
+// view renders the violations not tied to a component.
+func (c *schemaCheck) view(theme *styles.Theme) []string {
+	lines := make([]string, len(c.general))
+	for i, msg := range c.general {
+		lines[i] = theme.Error.Render("✗ " + msg)
+	}
+	return lines
+}
+
+// validationErrors validates doc and returns the violations in the format
+// of the validation pipeline, for non-interactive runs. Violations are
+// reported on their component when fields has it.
+func (c *schemaCheck) validationErrors(doc map[string]interface{}, fields map[string]bool) []components.ValidationError {
+	var result []components.ValidationError
+	for _, v := range c.schema.Validate(doc) {
+		field := v.Field
+		if name, ok := owner(v.Path, fields); ok {
+			field = name
+		}
+		result = append(result, components.ValidationError{
+			Code:     "SCHEMA_VIOLATION",
+			Message:  violationMessage(v, field),
+			Field:    field,
+			Severity: "error",
+		})
+	}
+	return result
+}
+
+// owner returns the longest of fields that path starts with, so the
+// violation at "tags[0]" belongs to the component "tags" and the one at
+// "server.host" to "server.host" rather than to "server".
+func owner(path string, fields map[string]bool) (string, bool) {
+	for path != "" {
+		if fields[path] {
+			return path, true
+		}
+		cut := strings.LastIndexAny(path, ".[")
+		if cut < 0 {
+			break
+		}
+		path = path[:cut]
+	}
+	return "", false
+}
+
+// fieldNames returns the names of values qualified by prefix, as the keys
+// of the set passed to run.
+func fieldNames(values map[string]interface{}, prefix string, fields map[string]bool) map[string]bool {
+	if fields == nil {
+		fields = make(map[string]bool, len(values))
+	}
+	for name := range values {
+		fields[qualified(prefix, name)] = true
+	}
+	return fields
+}
+
+// qualified prepends prefix to name as "prefix.name", when prefix is set.
+func qualified(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// violationMessage formats a violation for the component name: nested
+// paths such as "tags[0]" are kept, the field name itself is not repeated.
+func violationMessage(v schema.Violation, name string) string {
+	if v.Path == name {
+		return v.Message
+	}
+	return v.Error()
+}
+
+// SetSchemaValidation enables the JSON Schema check described by cfg.
+// When enabled, the values of ToMap must conform to the schema before the
+// form is submitted; violations are shown on the offending components.
+func (m *FormModel) SetSchemaValidation(cfg config.SchemaValidation) error {
+	check, err := newSchemaCheck(cfg)
+	if err != nil {
+		return fmt.Errorf("erro ao carregar validação por schema: %w", err)
+	}
+	m.schema = check
+	return nil
+}
+
+// shapeFunc turns the flat values of a form into the document its schema
+// describes.
+type shapeFunc func(map[string]interface{}) map[string]interface{}
+
+// SetSchemaDocument makes the schema check validate shape(values) instead
+// of the flat values of ToMap, as for forms generated from a JSON Schema,
+// whose document nests the dotted component names ("server.host").
+// Violations are still shown on the components their paths lead to.
+func (m *FormModel) SetSchemaDocument(shape func(map[string]interface{}) map[string]interface{}) {
+	m.shape = shape
+}
+
+// schemaDocument returns the document the schema check validates and the
+// component names its violations may belong to.
+func (m *FormModel) schemaDocument() (map[string]interface{}, map[string]bool) {
+	values := m.ToMap()
+	fields := fieldNames(values, "", nil)
+	if m.shape != nil {
+		return m.shape(values), fields
+	}
+	return values, fields
+}
+
+// conformsToSchema runs the schema check, if enabled, on the current values.
+func (m *FormModel) conformsToSchema() bool {
+	if m.schema == nil {
+		return true
+	}
+	ok := m.schema.run(m.schemaDocument())
+	m.schema.show(m.components, "")
+	return ok
+}
+
+// SetSchemaValidation enables the JSON Schema check described by cfg.
+// When enabled, the values of ToMap must conform to the schema before the
+// layout is submitted; violations are shown on the offending components.
+func (m *LayoutModel) SetSchemaValidation(cfg config.SchemaValidation) error {
+	check, err := newSchemaCheck(cfg)
+	if err != nil {
+		return fmt.Errorf("erro ao carregar validação por schema: %w", err)
+	}
+	m.schema = check
+	return nil
+}
+
+// conformsToSchema runs the schema check, if enabled, on the current values.
+func (m *LayoutModel) conformsToSchema() bool {
+	if m.schema == nil {
+		return true
+	}
+	values := m.ToMap()
+	ok := m.schema.run(values, fieldNames(values, "", nil))
+	m.schema.show(m.components, "")
+	return ok
+}
+
+// SetSchemaValidation enables the JSON Schema check described by cfg.
+// When enabled, the nested {tab: {field: value}} document of ToMap must
+// conform to the schema before the tabs are submitted; violations are shown
+// on the offending components, whose paths start with the tab name.
+func (t *TabsModel) SetSchemaValidation(cfg config.SchemaValidation) error {
+	check, err := newSchemaCheck(cfg)
+	if err != nil {
+		return fmt.Errorf("erro ao carregar validação por schema: %w", err)
+	}
+	t.schema = check
+	return nil
+}
+
+// conformsToSchema runs the schema check, if enabled, on the current values.
+func (t *TabsModel) conformsToSchema() bool {
+	if t.schema == nil {
+		return true
+	}
+	ok := t.schema.run(t.ToMap(), t.schemaFields())
+	for i := range t.tabs {
+		t.schema.show(t.tabs[i].Components, t.tabs[i].Name)
+	}
+	return ok
+}
+
+// schemaFields returns the components of every tab as "tab.field".
+func (t *TabsModel) schemaFields() map[string]bool {
+	fields := make(map[string]bool)
+	for _, tab := range t.tabs {
+		fieldNames(tab.conditions.values(tab.Components), tab.Name, fields)
+	}
+	return fields
+}
@@ -0,0 +1,226 @@
+package models
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/schema"
+	"github.com/helton/shantilly/internal/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSchemaForm creates a form whose submitted values must conform to
+// the given JSON Schema.
+func setupSchemaForm(t *testing.T, schema string, strict bool) *FormModel {
+	path := filepath.Join(t.TempDir(), "contract.json")
+	require.NoError(t, os.WriteFile(path, []byte(schema), 0o600))
+
+	cfg := &config.FormConfig{
+		Components: []config.ComponentConfig{
+			{Type: config.TypeTextInput, Name: "user", Default: "Maria"},
+			{Type: config.TypeCheckbox, Name: "agree"},
+		},
+	}
+	m, err := NewFormModel(cfg, styles.DefaultTheme())
+	require.NoError(t, err)
+	require.NoError(t, m.SetSchemaValidation(config.SchemaValidation{
+		Enabled:      true,
+		SchemaPath:   path,
+		StrictSchema: strict,
+	}))
+	return m
+}
+
+const userSchema = `{
+  "type": "object",
+  "properties": {
+    "user": {"type": "string", "pattern": "^[a-z]+$"},
+    "agree": {"const": true}
+  }
+}`
+
+func TestFormModel_SchemaBlocksSubmit(t *testing.T) {
+	m := setupSchemaForm(t, userSchema, false)
+
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.False(t, isQuit(cmd))
+	assert.False(t, m.Submitted())
+
+	assert.Equal(t, "não corresponde ao padrão ^[a-z]+$", m.components[0].GetError())
+	assert.Equal(t, "deve ser igual a true", m.components[1].GetError())
+
+	view := ansi.Strip(m.View())
+	assert.Contains(t, view, "não corresponde ao padrão")
+	assert.Contains(t, view, "Os valores não atendem ao schema")
+	assert.Equal(t, "deve ser igual a true", m.components[1].GetError(), "errors survive the render")
+
+	require.NoError(t, m.ApplyValues(map[string]interface{}{"user": "maria", "agree": true}))
+	_, cmd = m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.True(t, isQuit(cmd))
+	assert.True(t, m.Submitted())
+}
+
+func TestFormModel_SchemaErrorClearedOnEdit(t *testing.T) {
+	m := setupSchemaForm(t, userSchema, false)
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.NotEmpty(t, m.components[0].GetError())
+
+	// The focused text input is edited
+	m.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	m.View()
+	assert.Empty(t, m.components[0].GetError())
+	assert.Equal(t, "deve ser igual a true", m.components[1].GetError())
+}
+
+func TestFormModel_SchemaValidate(t *testing.T) {
+	m := setupSchemaForm(t, `{"properties": {"user": {"minLength": 6}}}`, true)
+
+	errs := m.Validate()
+	require.Len(t, errs, 2)
+	assert.Equal(t, "user", errs[0].Field)
+	assert.Equal(t, "SCHEMA_VIOLATION", errs[0].Code)
+	assert.Equal(t, "deve ter no mínimo 6 caracteres", errs[0].Message)
+	assert.Equal(t, "agree", errs[1].Field)
+	assert.Equal(t, "campo não declarado no schema", errs[1].Message)
+}
+
+func TestFormModel_SchemaDocumentViolation(t *testing.T) {
+	m := setupSchemaForm(t, `{"required": ["email"], "minProperties": 3}`, false)
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.False(t, m.Submitted())
+	view := ansi.Strip(m.View())
+	assert.Contains(t, view, "email: campo obrigatório ausente")
+	assert.Contains(t, view, "deve ter no mínimo 3 campos")
+}
+
+func TestFormModel_SetSchemaValidation(t *testing.T) {
+	m, err := NewFormModel(&config.FormConfig{
+		Components: []config.ComponentConfig{{Type: config.TypeCheckbox, Name: "agree"}},
+	}, styles.DefaultTheme())
+	require.NoError(t, err)
+
+	assert.NoError(t, m.SetSchemaValidation(config.SchemaValidation{}))
+	assert.Nil(t, m.schema, "disabled by default")
+
+	assert.Error(t, m.SetSchemaValidation(config.SchemaValidation{Enabled: true}))
+	assert.Error(t, m.SetSchemaValidation(config.SchemaValidation{Enabled: true, SchemaPath: "missing.json"}))
+}
+
+func TestLayoutModel_SchemaBlocksSubmit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "contract.json")
+	require.NoError(t, os.WriteFile(path, []byte(userSchema), 0o600))
+
+	m, err := NewLayoutModel(&config.LayoutConfig{
+		Layout: "vertical",
+		Components: []config.ComponentConfig{
+			{Type: config.TypeTextInput, Name: "user", Default: "Maria"},
+		},
+	}, styles.DefaultTheme())
+	require.NoError(t, err)
+	require.NoError(t, m.SetSchemaValidation(config.SchemaValidation{Enabled: true, SchemaPath: path}))
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.False(t, m.Submitted())
+	assert.Equal(t, "não corresponde ao padrão ^[a-z]+$", m.components[0].GetError())
+	assert.Contains(t, ansi.Strip(m.View()), "Os valores não atendem ao schema")
+
+	errs := m.Validate()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "user", errs[0].Field)
+	assert.Equal(t, "SCHEMA_VIOLATION", errs[0].Code)
+}
+
+func TestTabsModel_SchemaNestedByTab(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "contract.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+  "properties": {
+    "account": {"properties": {"username": {"type": "string", "minLength": 3}}},
+    "terms": {"required": ["accept", "signature"]}
+  }
+}`), 0o600))
+
+	m := setupTabs(t)
+	require.NoError(t, m.SetSchemaValidation(config.SchemaValidation{Enabled: true, SchemaPath: path}))
+	require.NoError(t, m.ApplyValues(map[string]interface{}{"terms": map[string]interface{}{"accept": true}}))
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.False(t, m.Submitted())
+	assert.Equal(t, "deve ter no mínimo 3 caracteres", m.tabs[0].Components[1].GetError(), "account.username is the username of the account tab")
+	assert.Equal(t, 0, m.activeTab, "jumps to the tab of the violation")
+
+	view := ansi.Strip(m.View())
+	assert.Contains(t, view, "Conta ✗")
+	assert.Contains(t, view, "terms.signature: campo obrigatório ausente")
+
+	errs := m.Validate()
+	require.Len(t, errs, 2)
+	assert.Equal(t, "account.username", errs[0].Field)
+	assert.Equal(t, "deve ter no mínimo 3 caracteres", errs[0].Message)
+	assert.Equal(t, "terms", errs[1].Field)
+	assert.Equal(t, "terms.signature: campo obrigatório ausente", errs[1].Message)
+}
+
+func TestFormModel_SchemaNestedDocument(t *testing.T) {
+	generated, err := schema.FormFromSchema([]byte(`{
+  "type": "object",
+  "properties": {
+    "server": {
+      "type": "object",
+      "properties": {
+        "host": {"type": "string"},
+        "port": {"type": "integer"}
+      }
+    }
+  }
+}`))
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "contract.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+  "type": "object",
+  "required": ["server"],
+  "properties": {
+    "server": {
+      "type": "object",
+      "required": ["host"],
+      "properties": {
+        "host": {"type": "string", "minLength": 3},
+        "port": {"type": "integer", "maximum": 100}
+      },
+      "additionalProperties": false
+    }
+  },
+  "additionalProperties": false
+}`), 0o600))
+
+	m, err := NewFormModel(generated.Config, styles.DefaultTheme())
+	require.NoError(t, err)
+	require.NoError(t, m.SetSchemaValidation(config.SchemaValidation{Enabled: true, SchemaPath: path}))
+	m.SetSchemaDocument(generated.Shape)
+	require.NoError(t, m.ApplyValues(map[string]interface{}{"server.host": "db", "server.port": "8080"}))
+
+	// The nested document is checked, not the flat "server.host" keys
+	errs := m.Validate()
+	require.Len(t, errs, 2)
+	assert.Equal(t, "server.host", errs[0].Field)
+	assert.Equal(t, "deve ter no mínimo 3 caracteres", errs[0].Message)
+	assert.Equal(t, "server.port", errs[1].Field)
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.False(t, m.Submitted())
+	for _, comp := range m.components {
+		if comp.Name() == "server.host" {
+			assert.Equal(t, "deve ter no mínimo 3 caracteres", comp.GetError())
+		}
+	}
+
+	require.NoError(t, m.ApplyValues(map[string]interface{}{"server.host": "db01", "server.port": "80"}))
+	assert.Empty(t, m.Validate())
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.True(t, m.Submitted())
+}
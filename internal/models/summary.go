@@ -0,0 +1,90 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/helton/shantilly/internal/components"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/styles"
+)
+
+// summaryEntry is one answer of the compact summary.
+type summaryEntry struct {
+	label string
+	value interface{}
+}
+
+// summaryLabels maps component names to their labels, falling back to the
+// name. Static text components are left out, since they hold no answer.
+func summaryLabels(cfgs []config.ComponentConfig) map[string]string {
+	labels := make(map[string]string, len(cfgs))
+	for _, cfg := range cfgs {
+		if cfg.Type == config.TypeText {
+			continue
+		}
+		label := cfg.Label
+		if label == "" {
+			label = cfg.Name
+		}
+		labels[cfg.Name] = label
+	}
+	return labels
+}
+
+// summaryEntries lists the answers of comps in display order.
+func summaryEntries(comps []components.Component, labels map[string]string) []summaryEntry {
+	var entries []summaryEntry
+	for _, comp := range comps {
+		if label, ok := labels[comp.Name()]; ok {
+			entries = append(entries, summaryEntry{label: label, value: comp.Value()})
+		}
+	}
+	return entries
+}
+
+// renderSummary renders the compact summary that a submitted TUI leaves in
+// the scrollback when displayed inline: the title and one line per answer.
+func renderSummary(theme *styles.Theme, title string, entries []summaryEntry) string {
+	// One line per answer, so the label loses the margin it has above inputs
+	label := theme.Label.UnsetMargins()
+
+	var lines []string
+	if title != "" {
+		lines = append(lines, theme.Title.UnsetMargins().Render(title))
+	}
+	for _, e := range entries {
+		lines = append(lines, theme.CheckboxChecked.Render("✓")+" "+
+			label.Render(e.label+":")+" "+summaryValue(e.value))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// summaryValue formats an answer for a human reader.
+func summaryValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "—"
+	case bool:
+		if val {
+			return "sim"
+		}
+		return "não"
+	case string:
+		if val == "" {
+			return "—"
+		}
+		// Multi-line answers (textarea) are shown on one line
+		return strings.ReplaceAll(val, "\n", " ⏎ ")
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case []string:
+		if len(val) == 0 {
+			return "—"
+		}
+		return strings.Join(val, ", ")
+	default:
+		return summaryValue(fmt.Sprint(val))
+	}
+}
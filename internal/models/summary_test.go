@@ -0,0 +1,54 @@
+package models
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummaryValue(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{true, "sim"},
+		{false, "não"},
+		{"", "—"},
+		{"linha 1\nlinha 2", "linha 1 ⏎ linha 2"},
+		{float64(8080), "8080"},
+		{2.5, "2.5"},
+		{[]string{"a", "b"}, "a, b"},
+		{[]string{}, "—"},
+		{nil, "—"},
+		{42, "42"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, summaryValue(tt.value))
+	}
+}
+
+func TestFormModel_SubmittedViewShowsSummary(t *testing.T) {
+	m := setupValuesForm(t)
+	m.title = "Cadastro"
+	require.NoError(t, m.ApplyValues(map[string]interface{}{"name": "maria", "agree": true, "plan": "pro"}))
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.True(t, m.Submitted())
+
+	view := ansi.Strip(m.View())
+	assert.Contains(t, view, "Cadastro")
+	assert.Contains(t, view, "name: maria")
+	assert.Contains(t, view, "agree: sim")
+	assert.Contains(t, view, "plan: pro")
+	assert.NotContains(t, view, "Tab/Shift+Tab", "navigation help is gone")
+}
+
+func TestMenuModel_SubmittedViewShowsSummary(t *testing.T) {
+	m := setupMenu(t, false, "staging", "production")
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.True(t, m.Submitted())
+	assert.Equal(t, "✓ Escolha: staging", ansi.Strip(m.View()))
+}
@@ -1,9 +1,10 @@
 package models
 
 import (
+	"encoding/json"
 	"fmt"
-	"strings"
 
+	"github.com/charmbracelet/bubbles/v2/spinner"
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/helton/shantilly/internal/components"
@@ -12,7 +13,8 @@ import (
 )
 
 // TabsModel orchestrates components in a tabbed interface.
-// It manages tab navigation and component focus within tabs.
+// It manages tab navigation, component focus within tabs, validation across
+// all tabs and serialization of the nested {tab: {field: value}} result.
 type TabsModel struct {
 	name       string
 	label      string
@@ -22,6 +24,14 @@ type TabsModel struct {
 	errorMsg   string
 	focused    bool
 	initialTab int
+	width      int
+	height     int
+	submitted  bool
+	quitting   bool
+	attempted  bool            // True after a submit attempt, enables error markers on tab headers
+	standalone bool            // Drives a whole program, see SetStandalone
+	live       *liveValidation // Debounced validation and cached validity of every tab
+	schema     *schemaCheck    // Nil unless SchemaValidation is enabled
 }
 
 // TabData represents a single tab with its components
@@ -29,12 +39,18 @@ type TabData struct {
 	Name       string
 	Label      string
 	Components []components.Component
+
+	focusIndex int               // Index of the focused component, -1 when none can focus
+	labels     map[string]string // Labels for the summary, by component name
+	rules      *ruleCheck        // Nil when no component of the tab declares rules
+	commands   *commandCheck     // Nil when no component of the tab declares validate_command
+	conditions *conditionCheck   // show_if and enable_if of the components of the tab
 }
 
 // NewTabsModel creates a new TabsModel from configuration.
 func NewTabsModel(cfg *config.TabsConfig, theme *styles.Theme) (*TabsModel, error) {
-	if len(cfg.Tabs) == 0 {
-		return nil, fmt.Errorf("tabs deve conter pelo menos uma aba")
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("erro de validação da configuração das abas: %w", err)
 	}
 
 	tabs := make([]TabData, 0, len(cfg.Tabs))
@@ -45,11 +61,33 @@ func NewTabsModel(cfg *config.TabsConfig, theme *styles.Theme) (*TabsModel, erro
 		if err != nil {
 			return nil, fmt.Errorf("erro ao criar componentes para aba %s: %w", tabCfg.Name, err)
 		}
+		commands, err := config.CollectValidateCommands(tabCfg.Components)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao criar componentes para aba %s: %w", tabCfg.Name, err)
+		}
+		recompute(components)
+		conditions, err := newConditionCheck(tabCfg.Components, components, cfg.OmitHidden)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao criar componentes para aba %s: %w", tabCfg.Name, err)
+		}
 
 		tabData := TabData{
 			Name:       tabCfg.Name,
 			Label:      tabCfg.Label,
 			Components: components,
+			focusIndex: -1,
+			labels:     summaryLabels(tabCfg.Components),
+			rules:      newRuleCheck(config.CollectRules(tabCfg.Components, nil)),
+			commands:   newCommandCheck(commands, theme),
+			conditions: conditions,
+		}
+
+		// Remember the first focusable component of each tab
+		for i, comp := range components {
+			if comp.CanFocus() && conditions.enabled(comp.Name()) {
+				tabData.focusIndex = i
+				break
+			}
 		}
 
 		tabs = append(tabs, tabData)
@@ -62,112 +100,254 @@ func NewTabsModel(cfg *config.TabsConfig, theme *styles.Theme) (*TabsModel, erro
 		activeTab:  0,
 		theme:      theme,
 		initialTab: 0,
+		width:      80,
+		height:     24,
+		live:       defaultLiveValidation(),
 	}
 
 	return t, nil
 }
 
+// SetFocus gives or takes the keyboard focus of the model, and with it the
+// focus of the focused component of the active tab.
+func (t *TabsModel) SetFocus(focused bool) {
+	t.focused = focused
+	t.setActiveFocus(focused)
+}
+
+// SetStandalone makes the model drive a whole program, as the tabs and serve
+// commands do: it takes the focus, Esc and Ctrl+C quit, Enter submits and
+// Ctrl+←/→ switch tabs so arrows and letters reach the fields. An embedded
+// model switches tabs with ←/→ or h/l and leaves quitting to its parent.
+func (t *TabsModel) SetStandalone(standalone bool) {
+	t.standalone = standalone
+	t.SetFocus(standalone)
+}
+
 // Init implements tea.Model.
 func (t *TabsModel) Init() tea.Cmd {
-	// Initialize all components in the active tab
+	// Initialize components of every tab, not only the visible one
 	var cmds []tea.Cmd
-	for _, comp := range t.tabs[t.activeTab].Components {
-		cmds = append(cmds, comp.Init())
+	for _, tab := range t.tabs {
+		for _, comp := range tab.Components {
+			cmds = append(cmds, comp.Init())
+		}
 	}
 	return tea.Batch(cmds...)
 }
 
 // Update implements tea.Model.
 func (t *TabsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if !t.focused {
-		// Even if not focused, propagate messages to active tab components
-		if t.activeTab >= 0 && t.activeTab < len(t.tabs) {
-			for i, comp := range t.tabs[t.activeTab].Components {
-				updated, cmd := comp.Update(msg)
-				if updatedModel, ok := updated.(components.Component); ok {
-					t.tabs[t.activeTab].Components[i] = updatedModel
-					return t, cmd
-				}
+	if wsm, ok := msg.(tea.WindowSizeMsg); ok {
+		// Propagate window size to the components of every tab
+		t.width = wsm.Width
+		t.height = wsm.Height
+		for _, tab := range t.tabs {
+			for _, comp := range tab.Components {
+				comp.Update(wsm)
 			}
 		}
 		return t, nil
 	}
 
 	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "left", "h":
-			if t.activeTab > 0 {
-				t.activeTab--
+	case validateTickMsg:
+		if msg.live == t.live {
+			t.live.tick(msg, t.activeComponents())
+			t.showErrors()
+			t.dropErrorMsg()
+		}
+		return t, nil
+
+	case commandResultMsg:
+		for i := range t.tabs {
+			if commands := t.tabs[i].commands; commands != nil && msg.check == commands {
+				if commands.finish(msg) {
+					return t, t.submit()
+				}
+				commands.show(t.tabs[i].Components)
 			}
-		case "right", "l":
-			if t.activeTab < len(t.tabs)-1 {
-				t.activeTab++
+		}
+		return t, nil
+
+	case spinner.TickMsg:
+		var cmds []tea.Cmd
+		for i := range t.tabs {
+			if commands := t.tabs[i].commands; commands != nil {
+				cmds = append(cmds, commands.spin(msg))
+			}
+		}
+		return t, tea.Batch(cmds...)
+	}
+
+	if !t.focused {
+		// Even if not focused, propagate messages to the active tab
+		tab := &t.tabs[t.activeTab]
+		if tab.focusIndex >= 0 {
+			updated, cmd := tab.Components[tab.focusIndex].Update(msg)
+			if updatedModel, ok := updated.(components.Component); ok {
+				tab.Components[tab.focusIndex] = updatedModel
 			}
+			return t, cmd
+		}
+		return t, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
 		case "tab":
-			// Tab navigation within the active tab
+			cmd := t.leaveField()
 			t.focusNextInActiveTab()
-			return t, nil
+			return t, cmd
+
 		case "shift+tab":
-			// Reverse tab navigation within the active tab
+			cmd := t.leaveField()
 			t.focusPrevInActiveTab()
-			return t, nil
+			return t, cmd
+		}
+		if cmd, ok := t.handleTabKey(msg.String()); ok {
+			return t, cmd
 		}
 	}
 
 	// Propagate message to focused component in active tab
-	if t.activeTab >= 0 && t.activeTab < len(t.tabs) {
-		tab := &t.tabs[t.activeTab]
-		for i, comp := range tab.Components {
-			if comp.CanFocus() {
-				updated, cmd := comp.Update(msg)
-				if updatedModel, ok := updated.(components.Component); ok {
-					tab.Components[i] = updatedModel
-					return t, cmd
+	tab := &t.tabs[t.activeTab]
+	if tab.focusIndex >= 0 {
+		updated, cmd := tab.Components[tab.focusIndex].Update(msg)
+		if updatedModel, ok := updated.(components.Component); ok {
+			tab.Components[tab.focusIndex] = updatedModel
+
+			// An edited field gets fresh rule, schema and command checks
+			if _, ok := msg.(tea.KeyPressMsg); ok {
+				if tab.rules != nil {
+					tab.rules.clear(updatedModel.Name(), tab.Components)
+				}
+				if t.schema != nil {
+					t.schema.clear(qualified(tab.Name, updatedModel.Name()))
 				}
+				if tab.commands != nil {
+					tab.commands.clear(updatedModel.Name())
+				}
+				t.valuesChanged()
+				cmd = tea.Batch(cmd, t.live.edited(updatedModel, t.activeComponents()))
 			}
 		}
+		t.showErrors()
+		t.dropErrorMsg()
+		return t, cmd
 	}
 
 	return t, nil
 }
 
+// handleTabKey handles the keys that switch tabs, submit and quit, which
+// depend on whether the model is standalone. ok is false for other keys.
+func (t *TabsModel) handleTabKey(key string) (cmd tea.Cmd, ok bool) {
+	if !t.standalone {
+		switch key {
+		case "right", "l":
+			cmd := t.leaveField()
+			t.switchTab(t.activeTab + 1)
+			return cmd, true
+
+		case "left", "h":
+			cmd := t.leaveField()
+			t.switchTab(t.activeTab - 1)
+			return cmd, true
+		}
+		return nil, false
+	}
+
+	switch key {
+	case "ctrl+c", "esc":
+		t.quitting = true
+		return tea.Quit, true
+
+	case "ctrl+right", "ctrl+pgdown":
+		cmd := t.leaveField()
+		t.switchTab(t.activeTab + 1)
+		return cmd, true
+
+	case "ctrl+left", "ctrl+pgup":
+		cmd := t.leaveField()
+		t.switchTab(t.activeTab - 1)
+		return cmd, true
+
+	case "enter":
+		return t.submit(), true
+	}
+	return nil, false
+}
+
 // View implements tea.Model.
 func (t *TabsModel) View() string {
-	var b strings.Builder
+	if t.quitting {
+		return ""
+	}
+
+	// Once submitted, only a compact summary remains (in the scrollback when inline)
+	if t.submitted {
+		var entries []summaryEntry
+		for _, tab := range t.tabs {
+			entries = append(entries, summaryEntries(tab.conditions.visible(tab.Components), tab.labels)...)
+		}
+		return renderSummary(t.theme, t.label, entries)
+	}
 
-	// Render tab headers
-	tabHeaders := t.renderTabHeaders()
-	b.WriteString(tabHeaders)
-	b.WriteString("\n")
+	var sections []string
 
-	// Render active tab content
-	if t.activeTab >= 0 && t.activeTab < len(t.tabs) {
-		activeTabContent := t.renderActiveTab()
-		b.WriteString(activeTabContent)
+	// Title, left to the parent of an embedded model
+	if t.standalone && t.label != "" {
+		sections = append(sections, t.theme.Title.Render(t.label))
 	}
 
+	// Tab headers and active tab content
+	sections = append(sections, t.renderTabHeaders())
+	sections = append(sections, t.renderActiveTab())
+
 	// Render error message if present
 	if t.errorMsg != "" {
-		b.WriteString("\n")
-		b.WriteString(t.theme.Error.Render("✗ " + t.errorMsg))
+		sections = append(sections, t.theme.Error.Render("✗ "+t.errorMsg))
+	}
+	if t.schema != nil {
+		sections = append(sections, t.schema.view(t.theme)...)
+	}
+
+	if !t.standalone {
+		return lipgloss.JoinVertical(lipgloss.Left, sections...)
 	}
 
-	return b.String()
+	// Submit help, from the cached validity
+	if t.schema != nil && t.schema.pending() {
+		sections = append(sections, t.theme.Error.Render("Os valores não atendem ao schema"))
+	} else if t.ready() {
+		sections = append(sections, t.theme.Help.Render("Pressione Enter para submeter"))
+	} else {
+		sections = append(sections, t.theme.Error.Render("Complete todos os campos obrigatórios"))
+	}
+
+	// Navigation help
+	sections = append(sections, t.theme.Help.Render("Tab/Shift+Tab: Navegar | Ctrl+←/→: Trocar aba | Esc: Sair"))
+
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
 }
 
 // renderTabHeaders renders the tab navigation header.
+// After a failed submit, tabs with invalid components are marked with ✗.
 func (t *TabsModel) renderTabHeaders() string {
 	var headers []string
 
 	for i, tab := range t.tabs {
-		var header string
+		label := tab.Label
+		if t.attempted && !t.tabReady(i) {
+			label += " ✗"
+		}
 
-		// Tab label
+		var header string
 		if i == t.activeTab {
-			header = t.theme.TabActive.Render(fmt.Sprintf(" %s ", tab.Label))
+			header = t.theme.TabActive.Render(fmt.Sprintf(" %s ", label))
 		} else {
-			header = t.theme.TabInactive.Render(fmt.Sprintf(" %s ", tab.Label))
+			header = t.theme.TabInactive.Render(fmt.Sprintf(" %s ", label))
 		}
 
 		headers = append(headers, header)
@@ -186,10 +366,16 @@ func (t *TabsModel) renderActiveTab() string {
 	var componentsView []string
 
 	for i, comp := range tab.Components {
-		view := comp.View()
+		if !tab.conditions.shown(comp.Name()) {
+			continue
+		}
+		view := tab.conditions.render(t.theme, comp, comp.View())
+		if tab.commands != nil {
+			view = tab.commands.decorate(comp.Name(), view)
+		}
 
 		// Apply border based on focus state (similar to other models)
-		if i == t.getFocusedComponentIndex() && comp.CanFocus() {
+		if i == tab.focusIndex && t.focusable(t.activeTab, i) {
 			view = t.theme.BorderActive.Render(view)
 		} else {
 			view = t.theme.Border.Render(view)
@@ -201,47 +387,278 @@ func (t *TabsModel) renderActiveTab() string {
 	return lipgloss.JoinVertical(lipgloss.Left, componentsView...)
 }
 
-// getFocusedComponentIndex returns the index of the currently focused component in the active tab.
-func (t *TabsModel) getFocusedComponentIndex() int {
-	if t.activeTab < 0 || t.activeTab >= len(t.tabs) {
-		return -1
+// setActiveFocus sets the focus state of the focused component in the active tab.
+func (t *TabsModel) setActiveFocus(focused bool) {
+	tab := &t.tabs[t.activeTab]
+	if tab.focusIndex >= 0 {
+		tab.Components[tab.focusIndex].SetFocus(focused)
 	}
+}
 
-	tab := &t.tabs[t.activeTab]
-	for i, comp := range tab.Components {
-		if comp.CanFocus() {
-			return i
-		}
+// switchTab activates the tab at index, keeping the focus it had when last visited.
+func (t *TabsModel) switchTab(index int) {
+	if index < 0 || index >= len(t.tabs) || index == t.activeTab {
+		return
 	}
-	return -1
+	t.setActiveFocus(false)
+	t.activeTab = index
+	t.setActiveFocus(true)
+}
+
+// focusComponent moves focus to component idx of the tab at tabIndex, activating that tab.
+func (t *TabsModel) focusComponent(tabIndex, idx int) {
+	t.setActiveFocus(false)
+	t.activeTab = tabIndex
+	t.tabs[tabIndex].focusIndex = idx
+	t.setActiveFocus(true)
 }
 
 // focusNextInActiveTab moves focus to the next focusable component in the active tab.
+// Past the last component it continues on the next tab, so Tab walks the whole wizard.
 func (t *TabsModel) focusNextInActiveTab() {
-	if t.activeTab < 0 || t.activeTab >= len(t.tabs) {
-		return
+	tab := &t.tabs[t.activeTab]
+	for i := tab.focusIndex + 1; i < len(tab.Components); i++ {
+		if t.focusable(t.activeTab, i) {
+			t.focusComponent(t.activeTab, i)
+			return
+		}
 	}
 
-	tab := &t.tabs[t.activeTab]
-	for _, comp := range tab.Components {
-		if comp.CanFocus() {
-			comp.SetFocus(true)
-			break
+	// Wrap to the first focusable component of the following tabs
+	for offset := 1; offset <= len(t.tabs); offset++ {
+		tabIndex := (t.activeTab + offset) % len(t.tabs)
+		for i := range t.tabs[tabIndex].Components {
+			if t.focusable(tabIndex, i) {
+				t.focusComponent(tabIndex, i)
+				return
+			}
 		}
 	}
 }
 
 // focusPrevInActiveTab moves focus to the previous focusable component in the active tab.
+// Before the first component it continues on the previous tab.
 func (t *TabsModel) focusPrevInActiveTab() {
-	if t.activeTab < 0 || t.activeTab >= len(t.tabs) {
-		return
+	tab := &t.tabs[t.activeTab]
+	for i := tab.focusIndex - 1; i >= 0; i-- {
+		if t.focusable(t.activeTab, i) {
+			t.focusComponent(t.activeTab, i)
+			return
+		}
+	}
+
+	// Wrap to the last focusable component of the preceding tabs
+	for offset := 1; offset <= len(t.tabs); offset++ {
+		tabIndex := (t.activeTab - offset + len(t.tabs)) % len(t.tabs)
+		for i := len(t.tabs[tabIndex].Components) - 1; i >= 0; i-- {
+			if t.focusable(tabIndex, i) {
+				t.focusComponent(tabIndex, i)
+				return
+			}
+		}
+	}
+}
+
+// submit quits with the values when every tab passes its checks, or jumps
+// to the first error. Command validators run last; while they run the
+// submit waits and is retried when their results arrive.
+func (t *TabsModel) submit() tea.Cmd {
+	if t.CanSubmit() && t.followsRules() && t.conformsToSchema() {
+		var cmds []tea.Cmd
+		passed := true
+		for i := range t.tabs {
+			if t.tabs[i].commands == nil {
+				continue
+			}
+			ok, cmd := t.tabs[i].commands.verify(t.tabs[i].conditions.active(t.tabs[i].Components))
+			if !ok {
+				passed = false
+			}
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		}
+		if passed {
+			t.submitted = true
+			return tea.Quit
+		}
+		if len(cmds) > 0 {
+			return tea.Batch(cmds...)
+		}
+		for i := range t.tabs {
+			if t.tabs[i].commands != nil && t.tabs[i].commands.busy() {
+				return nil
+			}
+		}
 	}
+	t.jumpToFirstInvalid()
+	return nil
+}
 
+// leaveField marks the focused component of the active tab as touched as
+// focus moves away from it and starts its command validator, if its own
+// checks pass.
+func (t *TabsModel) leaveField() tea.Cmd {
 	tab := &t.tabs[t.activeTab]
-	for i := len(tab.Components) - 1; i >= 0; i-- {
-		if tab.Components[i].CanFocus() {
-			tab.Components[i].SetFocus(true)
-			break
+	if tab.focusIndex < 0 {
+		return nil
+	}
+	comp := tab.Components[tab.focusIndex]
+	t.live.left(comp, t.activeComponents())
+	defer t.showErrors()
+	if tab.commands == nil || !comp.IsValid() {
+		return nil
+	}
+	return tab.commands.start(comp)
+}
+
+// activeComponents returns the visible, enabled components of every tab.
+func (t *TabsModel) activeComponents() []components.Component {
+	var comps []components.Component
+	for _, tab := range t.tabs {
+		comps = append(comps, tab.conditions.active(tab.Components)...)
+	}
+	return comps
+}
+
+// tabReady reports, from the cached validity, whether the tab at index has
+// no invalid component nor pending rule, schema or command error.
+func (t *TabsModel) tabReady(index int) bool {
+	tab := &t.tabs[index]
+	if t.schema != nil {
+		for _, comp := range tab.Components {
+			if t.schema.failed(qualified(tab.Name, comp.Name())) {
+				return false
+			}
+		}
+	}
+	return t.live.allValid(tab.conditions.active(tab.Components)) &&
+		(tab.rules == nil || !tab.rules.pending()) &&
+		(tab.commands == nil || !tab.commands.pending())
+}
+
+// ready reports, from the cached validity, whether every tab is ready.
+func (t *TabsModel) ready() bool {
+	for i := range t.tabs {
+		if !t.tabReady(i) {
+			return false
 		}
 	}
+	return true
+}
+
+// dropErrorMsg clears the error of the last submit once the cached
+// validity says every tab is ready.
+func (t *TabsModel) dropErrorMsg() {
+	if t.errorMsg != "" && t.ready() {
+		t.errorMsg = ""
+	}
+}
+
+// jumpToFirstInvalid activates the first tab with errors and focuses its first
+// invalid component, so the user lands right where the fix is needed.
+func (t *TabsModel) jumpToFirstInvalid() {
+	t.attempted = true
+	t.live.attempt(t.activeComponents())
+	t.followsRules()
+	defer t.showErrors()
+
+	for tabIndex := range t.tabs {
+		tab := &t.tabs[tabIndex]
+		for i, comp := range tab.Components {
+			if !tab.conditions.enabled(comp.Name()) {
+				continue
+			}
+			if comp.IsValid() && (tab.rules == nil || !tab.rules.failed(comp.Name())) &&
+				(t.schema == nil || !t.schema.failed(qualified(tab.Name, comp.Name()))) &&
+				(tab.commands == nil || !tab.commands.failed(comp.Name())) {
+				continue
+			}
+			if t.focusable(tabIndex, i) {
+				t.focusComponent(tabIndex, i)
+			} else {
+				t.switchTab(tabIndex)
+			}
+			t.errorMsg = fmt.Sprintf("Corrija os erros na aba %s", tab.Label)
+			return
+		}
+	}
+}
+
+// showErrors puts the rule, schema and command errors back on the
+// components of every tab, since IsValid clears the error of a component
+// whose own checks pass.
+func (t *TabsModel) showErrors() {
+	for i := range t.tabs {
+		tab := &t.tabs[i]
+		if tab.rules != nil {
+			tab.rules.show(tab.Components)
+		}
+		if t.schema != nil {
+			t.schema.show(tab.Components, tab.Name)
+		}
+		if tab.commands != nil {
+			tab.commands.show(tab.Components)
+		}
+	}
+}
+
+// tabValid returns true if every visible, enabled component in the tab is
+// valid and no broken rule is shown.
+func tabValid(tab *TabData) bool {
+	valid := true
+	for _, comp := range tab.conditions.active(tab.Components) {
+		if !comp.IsValid() {
+			valid = false
+		}
+	}
+	if tab.rules != nil {
+		tab.rules.show(tab.Components)
+		if tab.rules.pending() {
+			valid = false
+		}
+	}
+	if tab.commands != nil {
+		tab.commands.show(tab.Components)
+		if tab.commands.pending() {
+			valid = false
+		}
+	}
+	return valid
+}
+
+// CanSubmit returns true if the components of every tab are valid.
+func (t *TabsModel) CanSubmit() bool {
+	allValid := true
+	for i := range t.tabs {
+		if !tabValid(&t.tabs[i]) {
+			allValid = false
+		}
+	}
+	return allValid
+}
+
+// Submitted returns true if the tabs were successfully submitted.
+func (t *TabsModel) Submitted() bool {
+	return t.submitted
+}
+
+// ToMap returns the data nested by tab name, matching components.Tabs.Value().
+func (t *TabsModel) ToMap() map[string]interface{} {
+	data := make(map[string]interface{})
+
+	for _, tab := range t.tabs {
+		data[tab.Name] = tab.conditions.values(tab.Components)
+	}
+
+	return data
+}
+
+// ToJSON serializes the nested tab data to JSON.
+func (t *TabsModel) ToJSON() ([]byte, error) {
+	jsonData, err := json.MarshalIndent(t.ToMap(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao serializar dados: %w", err)
+	}
+	return jsonData, nil
 }
@@ -0,0 +1,157 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTabs is a helper to create a two-tab wizard for tests.
+func setupTabs(t *testing.T) *TabsModel {
+	cfg := &config.TabsConfig{
+		Title: "Onboarding",
+		Tabs: []config.TabConfig{
+			{
+				Name:  "account",
+				Label: "Conta",
+				Components: []config.ComponentConfig{
+					{Type: config.TypeText, Name: "intro", Label: "Dados da conta"},
+					{Type: config.TypeTextInput, Name: "username", Label: "Usuário"},
+				},
+			},
+			{
+				Name:  "terms",
+				Label: "Termos",
+				Components: []config.ComponentConfig{
+					{Type: config.TypeTextInput, Name: "company", Label: "Empresa"},
+					{Type: config.TypeCheckbox, Name: "accept", Label: "Aceito", Required: true},
+				},
+			},
+		},
+	}
+
+	m, err := NewTabsModel(cfg, styles.DefaultTheme())
+	require.NoError(t, err)
+	m.SetStandalone(true)
+	return m
+}
+
+func TestNewTabsModel(t *testing.T) {
+	theme := styles.DefaultTheme()
+
+	t.Run("valid tabs", func(t *testing.T) {
+		m := setupTabs(t)
+		assert.Len(t, m.tabs, 2)
+		assert.Equal(t, 0, m.activeTab)
+		assert.Equal(t, 1, m.tabs[0].focusIndex, "text labels are skipped")
+		assert.Equal(t, 0, m.tabs[1].focusIndex)
+		assert.False(t, m.Submitted())
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		m, err := NewTabsModel(&config.TabsConfig{}, theme)
+		assert.Error(t, err)
+		assert.Nil(t, m)
+	})
+}
+
+func TestTabsModel_Navigation(t *testing.T) {
+	m := setupTabs(t)
+
+	// Tab past the last component of a tab continues on the next one
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Equal(t, 1, m.activeTab)
+	assert.Equal(t, 0, m.tabs[1].focusIndex)
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift})
+	assert.Equal(t, 0, m.activeTab)
+	assert.Equal(t, 1, m.tabs[0].focusIndex)
+
+	// Switching tabs keeps the focus each tab had
+	m.Update(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModCtrl})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Equal(t, 1, m.tabs[1].focusIndex)
+	m.Update(tea.KeyPressMsg{Code: tea.KeyLeft, Mod: tea.ModCtrl})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModCtrl})
+	assert.Equal(t, 1, m.activeTab)
+	assert.Equal(t, 1, m.tabs[1].focusIndex)
+
+	// Plain letters reach the focused component instead of switching tabs
+	m.Update(tea.KeyPressMsg{Code: tea.KeyLeft, Mod: tea.ModCtrl})
+	typeTabsText(m, "hl")
+	assert.Equal(t, 0, m.activeTab)
+	assert.Equal(t, "hl", m.tabs[0].Components[1].Value())
+}
+
+func TestTabsModel_Embedded(t *testing.T) {
+	m := setupTabs(t)
+	m.SetStandalone(false)
+
+	// Unfocused, keys reach the active tab without switching tabs
+	m.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	assert.Equal(t, 0, m.activeTab)
+
+	m.SetFocus(true)
+	m.Update(tea.KeyPressMsg{Text: "l", Code: 'l'})
+	assert.Equal(t, 1, m.activeTab)
+	m.Update(tea.KeyPressMsg{Code: tea.KeyLeft})
+	assert.Equal(t, 0, m.activeTab)
+
+	// Esc and Enter are left to the parent
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.False(t, m.Submitted())
+	assert.NotEmpty(t, m.View())
+	assert.NotContains(t, m.View(), "Esc: Sair")
+}
+
+func TestTabsModel_Submit(t *testing.T) {
+	m := setupTabs(t)
+
+	// Required checkbox on the second tab blocks submission
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.False(t, m.Submitted())
+	assert.Equal(t, 1, m.activeTab, "jumps to the first tab with errors")
+	assert.Equal(t, 1, m.tabs[1].focusIndex, "focuses the invalid component")
+	assert.Contains(t, m.View(), "Corrija os erros na aba Termos")
+	assert.Contains(t, m.View(), "Termos ✗")
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	assert.NotEmpty(t, m.errorMsg, "kept until the edit is revalidated")
+	m.Update(validateTickMsg{live: m.live, seq: m.live.seq})
+	assert.Empty(t, m.errorMsg, "error is cleared once everything is valid")
+
+	_, cmd = m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	assert.True(t, m.Submitted())
+
+	jsonData, err := m.ToJSON()
+	require.NoError(t, err)
+	var decoded map[string]map[string]interface{}
+	require.NoError(t, json.Unmarshal(jsonData, &decoded))
+	assert.Equal(t, true, decoded["terms"]["accept"])
+	assert.Equal(t, "", decoded["account"]["username"])
+	assert.Contains(t, decoded["account"], "intro")
+}
+
+func TestTabsModel_Quit(t *testing.T) {
+	m := setupTabs(t)
+
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	assert.NotNil(t, cmd)
+	assert.False(t, m.Submitted())
+	assert.Empty(t, m.View())
+}
+
+// typeTabsText sends each rune of text as a key press.
+func typeTabsText(m *TabsModel, text string) {
+	for _, r := range text {
+		m.Update(tea.KeyPressMsg{Text: string(r), Code: r})
+	}
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/styles"
+)
+
+// timeoutTickMsg carries the time of a countdown tick.
+type timeoutTickMsg time.Time
+
+// countdown drives the timeout of a form or layout with tea.Tick.
+// The remaining time is computed from the tick timestamps against a deadline
+// taken from now, so tests can replace now and send ticks from a fake clock.
+type countdown struct {
+	timeout   time.Duration
+	onTimeout string
+	now       func() time.Time
+	deadline  time.Time
+	remaining time.Duration
+}
+
+// newCountdown returns nil when timeout is zero, meaning no timeout.
+func newCountdown(timeout time.Duration, onTimeout string) *countdown {
+	if timeout <= 0 {
+		return nil
+	}
+	if onTimeout == "" {
+		onTimeout = config.TimeoutCancel
+	}
+	return &countdown{
+		timeout:   timeout,
+		onTimeout: onTimeout,
+		now:       time.Now,
+		remaining: timeout,
+	}
+}
+
+// start sets the deadline and schedules the first tick.
+func (c *countdown) start() tea.Cmd {
+	c.deadline = c.now().Add(c.timeout)
+	c.remaining = c.timeout
+	return c.tick()
+}
+
+// tick schedules the next update, at most one second away.
+func (c *countdown) tick() tea.Cmd {
+	d := time.Second
+	if c.remaining < d {
+		d = c.remaining
+	}
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return timeoutTickMsg(t)
+	})
+}
+
+// update records a tick. It returns true once the deadline has passed,
+// otherwise the command for the next tick.
+func (c *countdown) update(msg timeoutTickMsg) (bool, tea.Cmd) {
+	c.remaining = c.deadline.Sub(time.Time(msg))
+	if c.remaining <= 0 {
+		c.remaining = 0
+		return true, nil
+	}
+	return false, c.tick()
+}
+
+// submitsDefaults reports whether the current values are submitted on expiry.
+func (c *countdown) submitsDefaults() bool {
+	return c.onTimeout == config.TimeoutSubmitDefaults
+}
+
+// view renders the footer line, highlighted in the last ten seconds.
+func (c *countdown) view(theme *styles.Theme) string {
+	secs := int(math.Ceil(c.remaining.Seconds()))
+	text := fmt.Sprintf("Tempo restante: %d:%02d", secs/60, secs%60)
+	if c.submitsDefaults() {
+		text += " (os valores atuais serão enviados)"
+	} else {
+		text += " (será cancelado)"
+	}
+
+	if secs <= 10 {
+		return theme.Error.Render(text)
+	}
+	return theme.Help.Render(text)
+}
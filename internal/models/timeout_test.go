@@ -0,0 +1,89 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTimeoutForm creates a form with a timeout driven by a fake clock
+// and returns the clock's start time.
+func setupTimeoutForm(t *testing.T, onTimeout string, required bool) (*FormModel, time.Time) {
+	cfg := &config.FormConfig{
+		Components: []config.ComponentConfig{
+			{Type: config.TypeTextInput, Name: "name", Default: "maria", Required: required},
+			{Type: config.TypeCheckbox, Name: "agree", Required: required},
+		},
+		Timeout:   30 * time.Second,
+		OnTimeout: onTimeout,
+	}
+	m, err := NewFormModel(cfg, styles.DefaultTheme())
+	require.NoError(t, err)
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.timer.now = func() time.Time { return start }
+	require.NotNil(t, m.Init(), "Init schedules the first tick")
+	return m, start
+}
+
+// isQuit reports whether cmd is tea.Quit.
+func isQuit(cmd tea.Cmd) bool {
+	if cmd == nil {
+		return false
+	}
+	_, ok := cmd().(tea.QuitMsg)
+	return ok
+}
+
+func TestFormModel_TimeoutCountdown(t *testing.T) {
+	m, start := setupTimeoutForm(t, "", false)
+	assert.Contains(t, m.View(), "Tempo restante: 0:30 (será cancelado)")
+
+	_, cmd := m.Update(timeoutTickMsg(start.Add(5500 * time.Millisecond)))
+	assert.NotNil(t, cmd, "ticks continue before the deadline")
+	assert.False(t, m.TimedOut())
+	assert.Contains(t, m.View(), "Tempo restante: 0:25")
+}
+
+func TestFormModel_TimeoutExpiry(t *testing.T) {
+	tests := []struct {
+		name          string
+		onTimeout     string
+		required      bool
+		wantSubmitted bool
+	}{
+		{"cancel", config.TimeoutCancel, false, false},
+		{"submit valid defaults", config.TimeoutSubmitDefaults, false, true},
+		{"invalid defaults are not submitted", config.TimeoutSubmitDefaults, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, start := setupTimeoutForm(t, tt.onTimeout, tt.required)
+
+			_, cmd := m.Update(timeoutTickMsg(start.Add(30 * time.Second)))
+			assert.True(t, isQuit(cmd))
+			assert.Equal(t, tt.wantSubmitted, m.Submitted())
+			assert.Equal(t, !tt.wantSubmitted, m.TimedOut())
+			if tt.wantSubmitted {
+				assert.Equal(t, "maria", m.ToMap()["name"])
+			}
+		})
+	}
+}
+
+func TestFormModel_NoTimeout(t *testing.T) {
+	m := setupValuesForm(t)
+	assert.Nil(t, m.timer)
+	assert.Nil(t, m.Init())
+	assert.NotContains(t, m.View(), "Tempo restante")
+
+	_, cmd := m.Update(timeoutTickMsg(time.Now()))
+	assert.Nil(t, cmd)
+	assert.False(t, m.TimedOut())
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"github.com/helton/shantilly/internal/components"
+)
+
+// validateComponents runs ValidateWithContext on every component with the
+// current values as context. Components that report no error but fail IsValid
+// still get an entry, so a custom component can't slip through silently.
+// When prefix is set (tabs), it is prepended to each Field as "prefix.field".
+func validateComponents(comps []components.Component, ctx components.ValidationContext, prefix string) []components.ValidationError {
+	var result []components.ValidationError
+	for _, comp := range comps {
+		errs := comp.ValidateWithContext(ctx)
+		if len(errs) == 0 && !comp.IsValid() {
+			errs = []components.ValidationError{{
+				Code:     "VALIDATION_FAILED",
+				Message:  comp.GetError(),
+				Field:    comp.Name(),
+				Severity: "error",
+			}}
+		}
+
+		for _, e := range errs {
+			if e.Field == "" {
+				e.Field = comp.Name()
+			}
+			if prefix != "" {
+				e.Field = prefix + "." + e.Field
+			}
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Validate runs the full validation pipeline without user interaction and
+// returns every error found, including broken rules and, when enabled,
+// JSON Schema violations and the failure of the business validator. Hidden
+// and disabled components are not validated. An empty result means the form
+// can be submitted.
+func (m *FormModel) Validate() []components.ValidationError {
+	active := m.conditions.active(m.components)
+	ctx := components.ValidationContext{ComponentValues: m.ToMap()}
+	result := validateComponents(active, ctx, "")
+	if m.rules != nil {
+		result = append(result, onFields(m.rules.validationErrors(ctx.ComponentValues), active)...)
+	}
+	if m.schema != nil {
+		result = append(result, m.schema.validationErrors(m.schemaDocument())...)
+	}
+	if m.business != nil {
+		result = append(result, m.business.validationErrors(ctx.ComponentValues)...)
+	}
+	if m.commands != nil {
+		result = append(result, m.commands.validationErrors(valuesOf(active, nil))...)
+	}
+	return result
+}
+
+// Validate runs the full validation pipeline without user interaction and
+// returns every error found, including broken rules and, when enabled,
+// JSON Schema violations. Hidden and disabled components are not validated.
+// An empty result means the layout can be submitted.
+func (m *LayoutModel) Validate() []components.ValidationError {
+	active := m.conditions.active(m.components)
+	ctx := components.ValidationContext{ComponentValues: m.ToMap()}
+	result := validateComponents(active, ctx, "")
+	if m.rules != nil {
+		result = append(result, onFields(m.rules.validationErrors(ctx.ComponentValues), active)...)
+	}
+	if m.schema != nil {
+		result = append(result, m.schema.validationErrors(ctx.ComponentValues, fieldNames(ctx.ComponentValues, "", nil))...)
+	}
+	if m.commands != nil {
+		result = append(result, m.commands.validationErrors(valuesOf(active, nil))...)
+	}
+	return result
+}
+
+// Validate runs the full validation pipeline on the visible, enabled
+// components of every tab. Each tab is validated with its own values as
+// context; fields are reported as "tab.field". When enabled, the JSON Schema
+// check runs on the nested document of all tabs.
+func (t *TabsModel) Validate() []components.ValidationError {
+	var result []components.ValidationError
+	for i := range t.tabs {
+		tab := &t.tabs[i]
+		active := tab.conditions.active(tab.Components)
+		values := tab.conditions.values(tab.Components)
+		ctx := components.ValidationContext{ComponentValues: values}
+		result = append(result, validateComponents(active, ctx, tab.Name)...)
+		if tab.rules != nil {
+			for _, e := range onFields(tab.rules.validationErrors(values), active) {
+				e.Field = tab.Name + "." + e.Field
+				result = append(result, e)
+			}
+		}
+		if tab.commands != nil {
+			for _, e := range tab.commands.validationErrors(valuesOf(active, nil)) {
+				e.Field = tab.Name + "." + e.Field
+				result = append(result, e)
+			}
+		}
+	}
+	if t.schema != nil {
+		result = append(result, t.schema.validationErrors(t.ToMap(), t.schemaFields())...)
+	}
+	return result
+}
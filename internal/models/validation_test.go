@@ -0,0 +1,48 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormModel_Validate(t *testing.T) {
+	cfg := &config.FormConfig{
+		Components: []config.ComponentConfig{
+			{Type: config.TypeText, Name: "intro", Label: "Cadastro"},
+			{Type: config.TypeTextInput, Name: "username", Required: true},
+			{Type: config.TypeTextInput, Name: "code", Options: map[string]interface{}{"min_length": 3}},
+		},
+	}
+	m, err := NewFormModel(cfg, styles.DefaultTheme())
+	require.NoError(t, err)
+
+	errs := m.Validate()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "username", errs[0].Field)
+	assert.Equal(t, "VALIDATION_FAILED", errs[0].Code)
+	assert.Equal(t, "Este campo é obrigatório", errs[0].Message)
+
+	require.NoError(t, m.ApplyValues(map[string]interface{}{"username": "maria", "code": "ab"}))
+	errs = m.Validate()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "code", errs[0].Field)
+
+	require.NoError(t, m.ApplyValues(map[string]interface{}{"code": "abc"}))
+	assert.Empty(t, m.Validate())
+	assert.True(t, m.CanSubmit())
+}
+
+func TestTabsModel_Validate(t *testing.T) {
+	m := setupTabs(t)
+
+	errs := m.Validate()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "terms.accept", errs[0].Field)
+
+	require.NoError(t, m.ApplyValues(map[string]interface{}{"terms.accept": true}))
+	assert.Empty(t, m.Validate())
+}
@@ -0,0 +1,234 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/helton/shantilly/internal/components"
+	"github.com/helton/shantilly/internal/output"
+)
+
+// EnvValuePrefix is prepended to a field's environment name when values are
+// read from the environment, e.g. SHANTILLY_VALUE_USERNAME or
+// SHANTILLY_VALUE_ACCOUNT_EMAIL for the email field of the account tab.
+const EnvValuePrefix = "SHANTILLY_VALUE_"
+
+// valueField is a component addressable by a path: [name] in forms and
+// layouts, [tab, name] in tabs.
+type valueField struct {
+	path []string
+	comp components.Component
+}
+
+// derived reports whether the value of the field is computed from the
+// others rather than set.
+func (f valueField) derived() bool {
+	_, ok := f.comp.(*components.Computed)
+	return ok
+}
+
+// valueFields indexes fields by their dotted path.
+type valueFields map[string]valueField
+
+// newValueFields builds the index for components nested under prefix.
+func newValueFields(fields valueFields, prefix []string, comps []components.Component) valueFields {
+	if fields == nil {
+		fields = make(valueFields)
+	}
+	for _, comp := range comps {
+		path := append(append([]string(nil), prefix...), comp.Name())
+		fields[strings.Join(path, ".")] = valueField{path: path, comp: comp}
+	}
+	return fields
+}
+
+// apply sets values through Component.SetValue. Keys may be nested maps
+// ({tab: {field: value}}) or dotted paths ("tab.field"). Values are coerced
+// to the component type first, so "true" works for a checkbox and "42" for a slider.
+// Values of computed fields are ignored, so the output of a run can be fed
+// back as input; they follow the other fields instead.
+func (f valueFields) apply(values map[string]interface{}) error {
+	flat := make(map[string]interface{})
+	flattenValues(flat, "", values)
+
+	keys := make([]string, 0, len(flat))
+	for k := range flat {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		field, ok := f[key]
+		if !ok {
+			return fmt.Errorf("campo desconhecido: %s", key)
+		}
+		if field.derived() {
+			continue
+		}
+		if err := setFieldValue(field.comp, flat[key]); err != nil {
+			return fmt.Errorf("campo %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// applyEnv sets every field that has a SHANTILLY_VALUE_<NAME> variable.
+// Computed fields are left out. A variable shared by two fields, such as
+// user-name and user_name, is an error rather than set on both.
+func (f valueFields) applyEnv(lookup func(string) (string, bool)) error {
+	keys := make([]string, 0, len(f))
+	for k := range f {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	seen := make(map[string]string)
+	for _, key := range keys {
+		field := f[key]
+		if field.derived() {
+			continue
+		}
+		name := EnvValuePrefix + output.EnvName(strings.Join(field.path, "_"))
+		value, ok := lookup(name)
+		if !ok {
+			continue
+		}
+		if other, ok := seen[name]; ok {
+			return fmt.Errorf("os campos %s e %s leem a mesma variável %s", other, key, name)
+		}
+		seen[name] = key
+		if err := setFieldValue(field.comp, value); err != nil {
+			return fmt.Errorf("campo %s (%s): %w", key, name, err)
+		}
+	}
+	return nil
+}
+
+// flattenValues turns nested maps into dotted keys.
+func flattenValues(dst map[string]interface{}, prefix string, values map[string]interface{}) {
+	for k, v := range values {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if nested, ok := v.(map[string]interface{}); ok {
+			flattenValues(dst, key, nested)
+			continue
+		}
+		dst[key] = v
+	}
+}
+
+// setFieldValue coerces value to the type the component holds and sets it.
+func setFieldValue(comp components.Component, value interface{}) error {
+	coerced, err := coerceValue(comp.Value(), value)
+	if err != nil {
+		return err
+	}
+	return comp.SetValue(coerced)
+}
+
+// coerceValue converts value to the type of current, the component's present value.
+// Strings from flags and environment variables are parsed; numbers and booleans
+// from YAML are formatted for text fields.
+func coerceValue(current, value interface{}) (interface{}, error) {
+	switch current.(type) {
+	case bool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return nil, fmt.Errorf("valor inválido: esperado booleano (true/false), recebido %q", v)
+			}
+			return b, nil
+		}
+		return nil, fmt.Errorf("valor inválido: esperado booleano, recebido %T", value)
+
+	case float64:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case float32:
+			return float64(v), nil
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case uint64:
+			return float64(v), nil
+		case string:
+			f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return nil, fmt.Errorf("valor inválido: esperado número, recebido %q", v)
+			}
+			return f, nil
+		}
+		return nil, fmt.Errorf("valor inválido: esperado número, recebido %T", value)
+
+	case string:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		case int, int64, uint64:
+			return fmt.Sprint(v), nil
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		}
+		return nil, fmt.Errorf("valor inválido: esperado texto, recebido %T", value)
+	}
+
+	// Unknown component types validate the value themselves
+	return value, nil
+}
+
+// ApplyValues presets component values before the form starts.
+func (m *FormModel) ApplyValues(values map[string]interface{}) error {
+	defer m.valuesChanged()
+	return newValueFields(nil, nil, m.components).apply(values)
+}
+
+// ApplyEnvValues presets component values from SHANTILLY_VALUE_<NAME> variables.
+func (m *FormModel) ApplyEnvValues(lookup func(string) (string, bool)) error {
+	defer m.valuesChanged()
+	return newValueFields(nil, nil, m.components).applyEnv(lookup)
+}
+
+// ApplyValues presets component values before the layout starts.
+func (m *LayoutModel) ApplyValues(values map[string]interface{}) error {
+	defer m.valuesChanged()
+	return newValueFields(nil, nil, m.components).apply(values)
+}
+
+// ApplyEnvValues presets component values from SHANTILLY_VALUE_<NAME> variables.
+func (m *LayoutModel) ApplyEnvValues(lookup func(string) (string, bool)) error {
+	defer m.valuesChanged()
+	return newValueFields(nil, nil, m.components).applyEnv(lookup)
+}
+
+// ApplyValues presets component values before the tabs start.
+// Values are nested by tab name ({tab: {field: value}}) or use "tab.field" keys.
+func (t *TabsModel) ApplyValues(values map[string]interface{}) error {
+	defer t.valuesChanged()
+	return t.valueFields().apply(values)
+}
+
+// ApplyEnvValues presets component values from SHANTILLY_VALUE_<TAB>_<NAME> variables.
+func (t *TabsModel) ApplyEnvValues(lookup func(string) (string, bool)) error {
+	defer t.valuesChanged()
+	return t.valueFields().applyEnv(lookup)
+}
+
+// valueFields indexes the components of every tab.
+func (t *TabsModel) valueFields() valueFields {
+	fields := make(valueFields)
+	for _, tab := range t.tabs {
+		newValueFields(fields, []string{tab.Name}, tab.Components)
+	}
+	return fields
+}
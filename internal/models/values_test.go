@@ -0,0 +1,133 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupValuesForm creates a form with one component of each value type.
+func setupValuesForm(t *testing.T) *FormModel {
+	cfg := &config.FormConfig{
+		Components: []config.ComponentConfig{
+			{Name: "name", Type: config.TypeTextInput},
+			{Name: "agree", Type: config.TypeCheckbox},
+			{Name: "port", Type: config.TypeSlider, Options: map[string]interface{}{"min": 0, "max": 65535}},
+			{Name: "plan", Type: config.TypeRadioGroup, Options: map[string]interface{}{
+				"items": []interface{}{
+					map[string]interface{}{"id": "free", "label": "Free"},
+					map[string]interface{}{"id": "pro", "label": "Pro"},
+				},
+			}},
+		},
+	}
+	m, err := NewFormModel(cfg, styles.DefaultTheme())
+	require.NoError(t, err)
+	return m
+}
+
+func TestFormModel_ApplyValues(t *testing.T) {
+	t.Run("typed values", func(t *testing.T) {
+		m := setupValuesForm(t)
+		err := m.ApplyValues(map[string]interface{}{
+			"name":  "maria",
+			"agree": true,
+			"port":  8080, // YAML integers arrive as int
+			"plan":  "pro",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"name": "maria", "agree": true, "port": float64(8080), "plan": "pro",
+		}, m.ToMap())
+	})
+
+	t.Run("strings are coerced", func(t *testing.T) {
+		m := setupValuesForm(t)
+		require.NoError(t, m.ApplyValues(map[string]interface{}{"agree": "true", "port": "22", "name": 42}))
+		assert.Equal(t, true, m.ToMap()["agree"])
+		assert.Equal(t, float64(22), m.ToMap()["port"])
+		assert.Equal(t, "42", m.ToMap()["name"])
+	})
+
+	tests := []struct {
+		name   string
+		values map[string]interface{}
+		errMsg string
+	}{
+		{"unknown field", map[string]interface{}{"nope": "x"}, "campo desconhecido: nope"},
+		{"bad boolean", map[string]interface{}{"agree": "talvez"}, `campo agree: valor inválido: esperado booleano (true/false), recebido "talvez"`},
+		{"bad number", map[string]interface{}{"port": "abc"}, `campo port: valor inválido: esperado número, recebido "abc"`},
+		{"out of range", map[string]interface{}{"port": 70000}, "campo port: valor fora do intervalo"},
+		{"unknown option", map[string]interface{}{"plan": "gold"}, "campo plan: ID não encontrado: gold"},
+		{"list for text", map[string]interface{}{"name": []interface{}{"a"}}, "campo name: valor inválido: esperado texto"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := setupValuesForm(t).ApplyValues(tt.values)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestFormModel_ApplyEnvValues(t *testing.T) {
+	m := setupValuesForm(t)
+	env := map[string]string{
+		"SHANTILLY_VALUE_NAME":  "joão",
+		"SHANTILLY_VALUE_AGREE": "1",
+		"NAME":                  "ignored",
+	}
+	lookup := func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+
+	require.NoError(t, m.ApplyEnvValues(lookup))
+	assert.Equal(t, "joão", m.ToMap()["name"])
+	assert.Equal(t, true, m.ToMap()["agree"])
+
+	env["SHANTILLY_VALUE_PORT"] = "x"
+	err := m.ApplyEnvValues(lookup)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SHANTILLY_VALUE_PORT")
+}
+
+func TestFormModel_ApplyEnvValuesCollision(t *testing.T) {
+	m, err := NewFormModel(&config.FormConfig{
+		Components: []config.ComponentConfig{
+			{Name: "user-name", Type: config.TypeTextInput},
+			{Name: "user_name", Type: config.TypeTextInput},
+		},
+	}, styles.DefaultTheme())
+	require.NoError(t, err)
+
+	require.NoError(t, m.ApplyEnvValues(func(string) (string, bool) { return "", false }))
+
+	env := map[string]string{"SHANTILLY_VALUE_USER_NAME": "ana"}
+	err = m.ApplyEnvValues(func(k string) (string, bool) { v, ok := env[k]; return v, ok })
+	assert.EqualError(t, err, "os campos user-name e user_name leem a mesma variável SHANTILLY_VALUE_USER_NAME")
+}
+
+func TestTabsModel_ApplyValues(t *testing.T) {
+	m := setupTabs(t)
+
+	require.NoError(t, m.ApplyValues(map[string]interface{}{
+		"account":      map[string]interface{}{"username": "maria"},
+		"terms.accept": "true",
+	}))
+
+	data := m.ToMap()
+	assert.Equal(t, "maria", data["account"].(map[string]interface{})["username"])
+	assert.Equal(t, true, data["terms"].(map[string]interface{})["accept"])
+	assert.True(t, m.CanSubmit())
+
+	env := map[string]string{"SHANTILLY_VALUE_TERMS_COMPANY": "ACME"}
+	require.NoError(t, m.ApplyEnvValues(func(k string) (string, bool) { v, ok := env[k]; return v, ok }))
+	assert.Equal(t, "ACME", m.ToMap()["terms"].(map[string]interface{})["company"])
+
+	err := m.ApplyValues(map[string]interface{}{"username": "x"})
+	assert.ErrorContains(t, err, "campo desconhecido: username")
+}
@@ -0,0 +1,288 @@
+// Package output serializes orchestration results (the ToMap of a model)
+// into the formats accepted by the runner commands.
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/helton/shantilly/internal/components"
+	"gopkg.in/yaml.v3"
+)
+
+// Formats lists every format accepted by Encode, in help order.
+var Formats = []components.ExportFormat{
+	components.FormatJSON,
+	components.FormatYAML,
+	components.FormatXML,
+	components.FormatCSV,
+	components.FormatEnv,
+	components.FormatShell,
+}
+
+// ParseFormat validates a format name given on the command line.
+func ParseFormat(name string) (components.ExportFormat, error) {
+	for _, f := range Formats {
+		if string(f) == strings.ToLower(name) {
+			return f, nil
+		}
+	}
+
+	names := make([]string, len(Formats))
+	for i, f := range Formats {
+		names[i] = string(f)
+	}
+	return "", fmt.Errorf("formato de saída inválido: %s (use %s)", name, strings.Join(names, ", "))
+}
+
+// Encode serializes data in the given format.
+// Nested maps (tabs) are flattened with "." in CSV and "_" in env/shell output.
+func Encode(data map[string]interface{}, format components.ExportFormat) ([]byte, error) {
+	switch format {
+	case components.FormatJSON:
+		return json.MarshalIndent(data, "", "  ")
+	case components.FormatYAML:
+		return yaml.Marshal(data)
+	case components.FormatXML:
+		return encodeXML(data)
+	case components.FormatCSV:
+		return encodeCSV(data)
+	case components.FormatEnv:
+		return encodeEnv(data, "")
+	case components.FormatShell:
+		return encodeEnv(data, "export ")
+	default:
+		return nil, fmt.Errorf("formato não suportado: %s", format)
+	}
+}
+
+// EncodeRaw renders a single value the way a shell reads it: text verbatim,
+// booleans as true/false, numbers without exponent and list items one per line.
+func EncodeRaw(value interface{}) []byte {
+	return []byte(valueString(value, "\n") + "\n")
+}
+
+// field is a flattened key path and its scalar or list value.
+type field struct {
+	path  []string
+	value interface{}
+}
+
+// flatten walks nested maps in key order and returns their leaves.
+func flatten(data map[string]interface{}, prefix []string) []field {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var fields []field
+	for _, k := range keys {
+		path := append(append([]string(nil), prefix...), k)
+		if nested, ok := data[k].(map[string]interface{}); ok {
+			fields = append(fields, flatten(nested, path)...)
+			continue
+		}
+		fields = append(fields, field{path: path, value: data[k]})
+	}
+	return fields
+}
+
+// scalarString formats a single value the way a shell user expects to read it.
+func scalarString(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case bool:
+		return strconv.FormatBool(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(val), 'f', -1, 32)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// listStrings returns the elements of a list value, or false if v is not a list.
+func listStrings(v interface{}) ([]string, bool) {
+	switch val := v.(type) {
+	case []string:
+		return val, true
+	case []interface{}:
+		items := make([]string, len(val))
+		for i, item := range val {
+			items[i] = scalarString(item)
+		}
+		return items, true
+	}
+	return nil, false
+}
+
+// valueString formats a value, joining list items with sep.
+func valueString(v interface{}, sep string) string {
+	if items, ok := listStrings(v); ok {
+		return strings.Join(items, sep)
+	}
+	return scalarString(v)
+}
+
+// encodeCSV writes a header row with the field paths and a single data row.
+func encodeCSV(data map[string]interface{}) ([]byte, error) {
+	fields := flatten(data, nil)
+	header := make([]string, len(fields))
+	row := make([]string, len(fields))
+	for i, f := range fields {
+		header[i] = strings.Join(f.path, ".")
+		row[i] = valueString(f.value, ",")
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll([][]string{header, row}); err != nil {
+		return nil, fmt.Errorf("erro ao gerar CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeEnv renders one prefix+KEY='value' line per field.
+// Keys are upper-cased with every non-alphanumeric rune replaced by "_";
+// list items are separated by newlines so `for x in $KEY` iterates them.
+// Fields whose keys collide, such as user-name and user_name, are an error.
+func encodeEnv(data map[string]interface{}, prefix string) ([]byte, error) {
+	var buf bytes.Buffer
+	seen := make(map[string]string)
+	for _, f := range flatten(data, nil) {
+		name := EnvName(strings.Join(f.path, "_"))
+		path := strings.Join(f.path, ".")
+		if other, ok := seen[name]; ok {
+			return nil, fmt.Errorf("os campos %s e %s geram a mesma variável %s", other, path, name)
+		}
+		seen[name] = path
+
+		buf.WriteString(prefix)
+		buf.WriteString(name)
+		buf.WriteByte('=')
+		buf.WriteString(ShellQuote(valueString(f.value, "\n")))
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// EnvName converts a component name into a valid shell variable name.
+func EnvName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(unicode.ToUpper(r))
+		} else {
+			b.WriteByte('_')
+		}
+	}
+
+	result := b.String()
+	if result == "" || unicode.IsDigit(rune(result[0])) {
+		result = "_" + result
+	}
+	return result
+}
+
+// ShellQuote wraps s in single quotes, escaping embedded quotes, so that the
+// shell reads it back verbatim with no expansion.
+func ShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// encodeXML renders the data under a <result> root element.
+// Keys that are not valid XML names become <field name="..."> elements.
+func encodeXML(data map[string]interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString("<result>\n")
+	if err := writeXMLMap(&buf, data, 1); err != nil {
+		return nil, err
+	}
+	buf.WriteString("</result>\n")
+	return buf.Bytes(), nil
+}
+
+// writeXMLMap writes the entries of data in key order at the given depth.
+func writeXMLMap(buf *bytes.Buffer, data map[string]interface{}, depth int) error {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if err := writeXMLElement(buf, k, data[k], depth); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeXMLElement writes a single key/value pair as an element.
+func writeXMLElement(buf *bytes.Buffer, name string, value interface{}, depth int) error {
+	indent := strings.Repeat("  ", depth)
+	open, closing := "<"+name+">", "</"+name+">"
+	if !validXMLName(name) {
+		var attr bytes.Buffer
+		if err := xml.EscapeText(&attr, []byte(name)); err != nil {
+			return fmt.Errorf("erro ao gerar XML: %w", err)
+		}
+		open, closing = `<field name="`+attr.String()+`">`, "</field>"
+	}
+
+	if nested, ok := value.(map[string]interface{}); ok {
+		buf.WriteString(indent + open + "\n")
+		if err := writeXMLMap(buf, nested, depth+1); err != nil {
+			return err
+		}
+		buf.WriteString(indent + closing + "\n")
+		return nil
+	}
+
+	if items, ok := listStrings(value); ok {
+		buf.WriteString(indent + open + "\n")
+		for _, item := range items {
+			if err := writeXMLElement(buf, "item", item, depth+1); err != nil {
+				return err
+			}
+		}
+		buf.WriteString(indent + closing + "\n")
+		return nil
+	}
+
+	buf.WriteString(indent + open)
+	if err := xml.EscapeText(buf, []byte(scalarString(value))); err != nil {
+		return fmt.Errorf("erro ao gerar XML: %w", err)
+	}
+	buf.WriteString(closing + "\n")
+	return nil
+}
+
+// validXMLName reports whether name can be used as an element name as-is.
+func validXMLName(name string) bool {
+	if name == "" || strings.HasPrefix(strings.ToLower(name), "xml") {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_' || r < unicode.MaxASCII && unicode.IsLetter(r):
+		case i > 0 && (r == '-' || r == '.' || r < unicode.MaxASCII && unicode.IsDigit(r)):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,154 @@
+package output
+
+import (
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/helton/shantilly/internal/components"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// sampleData mimics the ToMap of a form with a nested tab.
+func sampleData() map[string]interface{} {
+	return map[string]interface{}{
+		"name":    "O'Brien $HOME `id`",
+		"port":    float64(8080),
+		"agree":   true,
+		"tags":    []string{"a", "b c"},
+		"account": map[string]interface{}{"e-mail": "x@y.z"},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, name := range []string{"json", "yaml", "xml", "csv", "env", "shell", "JSON"} {
+		f, err := ParseFormat(name)
+		assert.NoError(t, err)
+		assert.Equal(t, strings.ToLower(name), string(f))
+	}
+
+	_, err := ParseFormat("toml")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "json, yaml, xml, csv, env, shell")
+}
+
+func TestEncode_Structured(t *testing.T) {
+	data := sampleData()
+
+	t.Run("json", func(t *testing.T) {
+		out, err := Encode(data, components.FormatJSON)
+		require.NoError(t, err)
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal(out, &decoded))
+		assert.Equal(t, float64(8080), decoded["port"])
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		out, err := Encode(data, components.FormatYAML)
+		require.NoError(t, err)
+		var decoded map[string]interface{}
+		require.NoError(t, yaml.Unmarshal(out, &decoded))
+		assert.Equal(t, true, decoded["agree"])
+	})
+
+	t.Run("xml", func(t *testing.T) {
+		out, err := Encode(data, components.FormatXML)
+		require.NoError(t, err)
+		xmlText := string(out)
+		assert.Contains(t, xmlText, "<result>")
+		assert.Contains(t, xmlText, "<port>8080</port>")
+		assert.Contains(t, xmlText, "<name>O&#39;Brien $HOME `id`</name>")
+		assert.Contains(t, xmlText, "<item>b c</item>")
+		assert.Contains(t, xmlText, "<e-mail>x@y.z</e-mail>")
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		out, err := Encode(data, components.FormatCSV)
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+		require.Len(t, lines, 2)
+		assert.Equal(t, "account.e-mail,agree,name,port,tags", lines[0])
+		assert.Equal(t, "x@y.z,true,O'Brien $HOME `id`,8080,\"a,b c\"", lines[1])
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := Encode(data, "toml")
+		assert.Error(t, err)
+	})
+}
+
+func TestEncode_Env(t *testing.T) {
+	out, err := Encode(sampleData(), components.FormatEnv)
+	require.NoError(t, err)
+	assert.Equal(t, `ACCOUNT_E_MAIL='x@y.z'
+AGREE='true'
+NAME='O'\''Brien $HOME `+"`id`"+`'
+PORT='8080'
+TAGS='a
+b c'
+`, string(out))
+
+	shell, err := Encode(sampleData(), components.FormatShell)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(shell), "export ACCOUNT_E_MAIL='x@y.z'\n"))
+}
+
+func TestEncode_EnvCollision(t *testing.T) {
+	data := map[string]interface{}{"user-name": "a", "user_name": "b"}
+	for _, format := range []components.ExportFormat{components.FormatEnv, components.FormatShell} {
+		_, err := Encode(data, format)
+		assert.EqualError(t, err, "os campos user-name e user_name geram a mesma variável USER_NAME", string(format))
+	}
+
+	// Nested keys collide with flat ones too
+	_, err := Encode(map[string]interface{}{"user": map[string]interface{}{"name": "a"}, "user_name": "b"}, components.FormatEnv)
+	assert.EqualError(t, err, "os campos user.name e user_name geram a mesma variável USER_NAME")
+}
+
+func TestEncode_EnvRoundTrip(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not available")
+	}
+
+	out, err := Encode(sampleData(), components.FormatShell)
+	require.NoError(t, err)
+
+	script := string(out) + `printf '%s|%s|%s' "$NAME" "$PORT" "$TAGS"`
+	result, err := exec.Command(sh, "-c", script).Output()
+	require.NoError(t, err)
+	assert.Equal(t, "O'Brien $HOME `id`|8080|a\nb c", string(result))
+}
+
+func TestEncodeRaw(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{"O'Brien $HOME", "O'Brien $HOME\n"},
+		{true, "true\n"},
+		{float64(8080), "8080\n"},
+		{0.5, "0.5\n"},
+		{[]string{"a", "b c"}, "a\nb c\n"},
+		{"", "\n"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, string(EncodeRaw(tt.value)))
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	tests := map[string]string{
+		"username":   "USERNAME",
+		"first-name": "FIRST_NAME",
+		"1st":        "_1ST",
+		"ação":       "A__O",
+		"":           "_",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, EnvName(in), in)
+	}
+}
@@ -0,0 +1,84 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// TemplateFuncs are the helpers available to result templates.
+var TemplateFuncs = template.FuncMap{
+	"quote":   func(v interface{}) string { return ShellQuote(scalarString(v)) },
+	"join":    templateJoin,
+	"default": templateDefault,
+	"upper":   func(v interface{}) string { return strings.ToUpper(scalarString(v)) },
+	"toJson":  templateToJSON,
+}
+
+// ParseTemplate parses a result template with TemplateFuncs available.
+// Referring to a key missing from the values fails the rendering instead of
+// printing "<no value>"; index reads keys that may be absent.
+func ParseTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(TemplateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao analisar template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// LoadTemplate reads and parses a result template file.
+func LoadTemplate(path string) (*template.Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler template: %w", err)
+	}
+	return ParseTemplate(path, string(data))
+}
+
+// Render executes tmpl with data, typically a model's ToMap.
+func Render(tmpl *template.Template, data map[string]interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("erro ao renderizar template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// templateJoin joins list items with sep; scalars are returned as-is.
+// The list comes last so it can be piped: {{ .tags | join ", " }}.
+func templateJoin(sep string, v interface{}) string {
+	return valueString(v, sep)
+}
+
+// templateDefault returns def when v is empty (nil, "", false, 0 or an empty list).
+// The value comes last so it can be piped: {{ .name | default "anônimo" }}.
+func templateDefault(def, v interface{}) interface{} {
+	if v == nil {
+		return def
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map, reflect.String:
+		if rv.Len() == 0 {
+			return def
+		}
+	default:
+		if rv.IsZero() {
+			return def
+		}
+	}
+	return v
+}
+
+// templateToJSON encodes v as compact JSON.
+func templateToJSON(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("erro ao serializar JSON: %w", err)
+	}
+	return string(data), nil
+}
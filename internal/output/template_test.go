@@ -0,0 +1,64 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	data := map[string]interface{}{
+		"name":    "O'Brien",
+		"empty":   "",
+		"port":    float64(8080),
+		"agree":   false,
+		"tags":    []string{"a", "b"},
+		"account": map[string]interface{}{"email": "x@y.z"},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"field", "{{ .name }}", "O'Brien"},
+		{"nested field", "{{ .account.email }}", "x@y.z"},
+		{"quote", "echo {{ .name | quote }}", `echo 'O'\''Brien'`},
+		{"join", `{{ .tags | join ", " }}`, "a, b"},
+		{"default on empty", `{{ .empty | default "none" }}`, "none"},
+		{"default on false", `{{ .agree | default "no" }}`, "no"},
+		{"default keeps value", `{{ .port | default 1 }}`, "8080"},
+		{"default on missing", `{{ index . "missing" | default "x" }}`, "x"},
+		{"upper", "{{ .name | upper }}", "O'BRIEN"},
+		{"toJson", "{{ .tags | toJson }} {{ toJson .account }}", `["a","b"] {"email":"x@y.z"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseTemplate("test", tt.template)
+			require.NoError(t, err)
+			out, err := Render(tmpl, data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(out))
+		})
+	}
+}
+
+func TestRender_MissingKey(t *testing.T) {
+	tmpl, err := ParseTemplate("typo", "user={{ .usrname }}")
+	require.NoError(t, err)
+	_, err = Render(tmpl, map[string]interface{}{"username": "ana"})
+	assert.ErrorContains(t, err, "usrname")
+}
+
+func TestParseTemplate_Errors(t *testing.T) {
+	_, err := ParseTemplate("bad", "{{ .name ")
+	assert.Error(t, err)
+
+	_, err = ParseTemplate("unknown func", "{{ .name | lowerCase }}")
+	assert.Error(t, err)
+
+	_, err = LoadTemplate("/nonexistent/template.txt")
+	assert.Error(t, err)
+}
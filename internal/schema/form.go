@@ -0,0 +1,444 @@
+package schema
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/helton/shantilly/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// textareaMinLength is the maxLength from which a string gets a textarea.
+const textareaMinLength = 200
+
+// fieldKind tells Shape how to turn a component value back into the JSON
+// type declared by the schema.
+type fieldKind int
+
+const (
+	fieldValue   fieldKind = iota // Kept as is (string, boolean, slider number)
+	fieldNumber                   // Text input holding a number
+	fieldInteger                  // Text input or slider holding an integer
+	fieldEnum                     // Radio group whose ids map to enum values
+	fieldList                     // Textarea holding one array item per line
+	fieldSection                  // Section title, left out of the output
+)
+
+// field describes how one component maps to the schema document.
+type field struct {
+	kind     fieldKind
+	required bool
+	enum     map[string]interface{} // Enum values by radio item id
+}
+
+// Form is a form generated from a JSON Schema. Nested objects become
+// sections, whose fields are named "parent.child".
+type Form struct {
+	Config *config.FormConfig
+	fields map[string]field
+}
+
+// FormFromFile reads a JSON Schema file and generates a form from it.
+func FormFromFile(path string) (*Form, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler o schema: %w", err)
+	}
+	form, err := FormFromSchema(data)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gerar formulário a partir de %s: %w", path, err)
+	}
+	return form, nil
+}
+
+// formatValidators maps JSON Schema formats to the validators that check them.
+var formatValidators = map[string]string{
+	"email":    config.ValidatorEmail,
+	"uri":      config.ValidatorURL,
+	"hostname": config.ValidatorHostname,
+	"ipv4":     config.ValidatorIPv4,
+	"ipv6":     config.ValidatorIPv6,
+	"uuid":     config.ValidatorUUID,
+}
+
+// FormFromSchema generates a form from the properties of a JSON Schema
+// object, in declaration order:
+//
+//   - enum becomes a radiogroup
+//   - boolean becomes a checkbox
+//   - number or integer with minimum and maximum becomes a slider,
+//     otherwise a textinput with the int or float validator
+//   - string becomes a textinput, or a textarea with format textarea or a
+//     maxLength of at least 200
+//   - array of strings becomes a textarea with one item per line
+//   - object becomes a section with its own fields
+//
+// required, pattern, minLength and maxLength map to Required and options,
+// and formats such as email or uuid to the validator of the same meaning;
+// title becomes the label and description the help text.
+func FormFromSchema(data []byte) (*Form, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("erro ao analisar o schema: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("o schema deve ser um objeto")
+	}
+
+	b := &formBuilder{root: doc.Content[0], fields: make(map[string]field)}
+	root, err := b.resolve(b.root)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.object(root, ""); err != nil {
+		return nil, err
+	}
+	if len(b.fields) == 0 {
+		return nil, fmt.Errorf("o schema não declara propriedades suportadas")
+	}
+
+	return &Form{
+		Config: &config.FormConfig{
+			Title:       scalar(root, "title"),
+			Description: scalar(root, "description"),
+			Components:  b.components,
+		},
+		fields: b.fields,
+	}, nil
+}
+
+// formBuilder walks the schema, kept as a yaml.Node so the properties
+// keep their declaration order.
+type formBuilder struct {
+	root       *yaml.Node
+	components []config.ComponentConfig
+	fields     map[string]field
+}
+
+// resolve follows $ref until it reaches a schema without one.
+func (b *formBuilder) resolve(n *yaml.Node) (*yaml.Node, error) {
+	for i := 0; i < maxDepth; i++ {
+		ref := scalar(n, "$ref")
+		if ref == "" {
+			return n, nil
+		}
+		if ref != "#" && !strings.HasPrefix(ref, "#/") {
+			return nil, fmt.Errorf("apenas referências locais são suportadas: %s", ref)
+		}
+
+		target := b.root
+		for _, part := range strings.Split(strings.TrimPrefix(strings.TrimPrefix(ref, "#"), "/"), "/") {
+			if part == "" {
+				continue
+			}
+			part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+			if target = child(target, part); target == nil {
+				return nil, fmt.Errorf("referência não encontrada: %s", ref)
+			}
+		}
+		n = target
+	}
+	return nil, fmt.Errorf("referências aninhadas demais no schema")
+}
+
+// object adds the components of the properties of an object schema.
+func (b *formBuilder) object(n *yaml.Node, prefix string) error {
+	required := make(map[string]bool)
+	if list := child(n, "required"); list != nil {
+		for _, item := range list.Content {
+			required[item.Value] = true
+		}
+	}
+
+	props := child(n, "properties")
+	if props == nil || props.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(props.Content); i += 2 {
+		name := props.Content[i].Value
+		prop, err := b.resolve(props.Content[i+1])
+		if err != nil {
+			return fmt.Errorf("propriedade %s: %w", prefix+name, err)
+		}
+		if err := b.property(prefix+name, prop, required[name]); err != nil {
+			return fmt.Errorf("propriedade %s: %w", prefix+name, err)
+		}
+	}
+	return nil
+}
+
+// property adds the component of one property.
+func (b *formBuilder) property(name string, n *yaml.Node, required bool) error {
+	comp := config.ComponentConfig{
+		Name:     name,
+		Label:    scalar(n, "title"),
+		Help:     scalar(n, "description"),
+		Required: required,
+		Options:  map[string]interface{}{},
+	}
+	if comp.Label == "" {
+		comp.Label = name[strings.LastIndex(name, ".")+1:]
+	}
+	if def := child(n, "default"); def != nil {
+		if err := def.Decode(&comp.Default); err != nil {
+			return fmt.Errorf("default inválido: %w", err)
+		}
+	}
+	f := field{kind: fieldValue, required: required}
+
+	typ := schemaType(n)
+	switch {
+	case child(n, "enum") != nil:
+		comp.Type = config.TypeRadioGroup
+		f.kind = fieldEnum
+		f.enum = make(map[string]interface{})
+		var items []interface{}
+		for _, item := range child(n, "enum").Content {
+			var value interface{}
+			if err := item.Decode(&value); err != nil {
+				return fmt.Errorf("enum inválido: %w", err)
+			}
+			if value == nil {
+				continue
+			}
+			id := fmt.Sprint(value)
+			f.enum[id] = value
+			items = append(items, map[string]interface{}{"id": id, "label": id})
+		}
+		comp.Options["items"] = items
+		if comp.Default != nil {
+			comp.Default = fmt.Sprint(comp.Default)
+		}
+
+	case typ == "boolean":
+		comp.Type = config.TypeCheckbox
+		// A required boolean only needs an answer, not a true one
+		comp.Required = false
+
+	case typ == "integer" || typ == "number":
+		if typ == "integer" {
+			f.kind = fieldInteger
+		}
+		min, okMin := numberKeyword(n, "minimum")
+		max, okMax := numberKeyword(n, "maximum")
+		if okMin && okMax && min < max {
+			comp.Type = config.TypeSlider
+			comp.Options["min"] = min
+			comp.Options["max"] = max
+			step := 1.0
+			if m, ok := numberKeyword(n, "multipleOf"); ok && m > 0 {
+				step = m
+			}
+			comp.Options["step"] = step
+			// The slider always has a value, so it always answers
+			comp.Required = false
+			break
+		}
+		comp.Type = config.TypeTextInput
+		validator := config.ValidatorInt
+		if typ == "number" {
+			f.kind = fieldNumber
+			validator = config.ValidatorFloat
+		}
+		if okMin || okMax {
+			validator += ":" + bound(min, okMin) + ".." + bound(max, okMax)
+		}
+		comp.Options["validate"] = validator
+		if comp.Default != nil {
+			comp.Default = fmt.Sprint(comp.Default)
+		}
+
+	case typ == "string":
+		comp.Type = config.TypeTextInput
+		maxLength, okMax := intKeyword(n, "maxLength")
+		if scalar(n, "format") == "textarea" || (okMax && maxLength >= textareaMinLength) {
+			comp.Type = config.TypeTextArea
+		}
+		if pattern := scalar(n, "pattern"); pattern != "" {
+			comp.Options["pattern"] = pattern
+		}
+		if validator, ok := formatValidators[scalar(n, "format")]; ok {
+			comp.Options["validate"] = validator
+		}
+		if minLength, ok := intKeyword(n, "minLength"); ok {
+			comp.Options["min_length"] = minLength
+		}
+		if okMax {
+			comp.Options["max_length"] = maxLength
+		}
+
+	case typ == "array" && schemaType(itemsOf(b, n)) == "string":
+		comp.Type = config.TypeTextArea
+		f.kind = fieldList
+		if comp.Help == "" {
+			comp.Help = "Um item por linha"
+		}
+		if list, ok := comp.Default.([]interface{}); ok {
+			lines := make([]string, len(list))
+			for i, item := range list {
+				lines[i] = fmt.Sprint(item)
+			}
+			comp.Default = strings.Join(lines, "\n")
+		}
+
+	case typ == "object":
+		b.components = append(b.components, config.ComponentConfig{
+			Type:  config.TypeText,
+			Name:  name,
+			Label: comp.Label,
+		})
+		b.fields[name] = field{kind: fieldSection}
+		return b.object(n, name+".")
+
+	default:
+		log.Printf("[DEBUG] Propriedade %s ignorada: tipo %q não suportado no formulário", name, typ)
+		return nil
+	}
+
+	if len(comp.Options) == 0 {
+		comp.Options = nil
+	}
+	b.components = append(b.components, comp)
+	b.fields[name] = f
+	return nil
+}
+
+// Shape converts the flat values of the form into the document described
+// by the schema: "parent.child" names become nested objects, numbers and
+// enum values get their JSON types back, lists are split into lines and
+// empty optional answers are left out.
+func (f *Form) Shape(values map[string]interface{}) map[string]interface{} {
+	doc := make(map[string]interface{})
+	for name, value := range values {
+		fl, ok := f.fields[name]
+		if !ok || fl.kind == fieldSection {
+			continue
+		}
+
+		if s, isString := value.(string); isString && s == "" && !fl.required {
+			continue
+		}
+		value = fl.convert(value)
+
+		parts := strings.Split(name, ".")
+		obj := doc
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := obj[part].(map[string]interface{})
+			if !ok {
+				next = make(map[string]interface{})
+				obj[part] = next
+			}
+			obj = next
+		}
+		obj[parts[len(parts)-1]] = value
+	}
+	return doc
+}
+
+// convert turns a component value into the JSON type of the field.
+func (f field) convert(value interface{}) interface{} {
+	switch f.kind {
+	case fieldNumber:
+		if n, err := strconv.ParseFloat(fmt.Sprint(value), 64); err == nil {
+			return n
+		}
+	case fieldInteger:
+		if n, ok := value.(float64); ok {
+			return int64(n)
+		}
+		if n, err := strconv.ParseInt(fmt.Sprint(value), 10, 64); err == nil {
+			return n
+		}
+	case fieldEnum:
+		if v, ok := f.enum[fmt.Sprint(value)]; ok {
+			return v
+		}
+	case fieldList:
+		var items []string
+		for _, line := range strings.Split(fmt.Sprint(value), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				items = append(items, line)
+			}
+		}
+		if items == nil {
+			items = []string{}
+		}
+		return items
+	}
+	return value
+}
+
+// child returns the value of key in the mapping node n, or nil.
+func child(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// scalar returns the scalar value of key in n, or "".
+func scalar(n *yaml.Node, key string) string {
+	if v := child(n, key); v != nil && v.Kind == yaml.ScalarNode {
+		return v.Value
+	}
+	return ""
+}
+
+// schemaType returns the type of a schema, the first non-null one when it
+// is a list. Without a type, objects are recognized by their properties.
+func schemaType(n *yaml.Node) string {
+	t := child(n, "type")
+	switch {
+	case t == nil:
+		if child(n, "properties") != nil {
+			return "object"
+		}
+		return ""
+	case t.Kind == yaml.SequenceNode:
+		for _, item := range t.Content {
+			if item.Value != "null" {
+				return item.Value
+			}
+		}
+		return ""
+	default:
+		return t.Value
+	}
+}
+
+// itemsOf returns the resolved items schema of an array schema.
+func itemsOf(b *formBuilder, n *yaml.Node) *yaml.Node {
+	items, err := b.resolve(child(n, "items"))
+	if err != nil {
+		return nil
+	}
+	return items
+}
+
+// numberKeyword reads a numeric keyword such as minimum.
+func numberKeyword(n *yaml.Node, key string) (float64, bool) {
+	v, err := strconv.ParseFloat(scalar(n, key), 64)
+	return v, err == nil
+}
+
+// intKeyword reads a non-negative integer keyword such as maxLength.
+func intKeyword(n *yaml.Node, key string) (int, bool) {
+	v, err := strconv.Atoi(scalar(n, key))
+	return v, err == nil && v >= 0
+}
+
+// bound formats one side of a validator range, empty when absent.
+func bound(f float64, ok bool) string {
+	if !ok {
+		return ""
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,181 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/helton/shantilly/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const serviceSchema = `{
+  "title": "Serviço",
+  "description": "Configuração do serviço",
+  "type": "object",
+  "required": ["name", "env", "debug"],
+  "properties": {
+    "name": {"type": "string", "title": "Nome", "description": "Nome do serviço", "pattern": "^[a-z-]+$", "minLength": 3, "maxLength": 30},
+    "env": {"enum": ["dev", "prod"], "default": "dev"},
+    "level": {"type": "integer", "enum": [1, 2]},
+    "replicas": {"type": "integer", "minimum": 1, "maximum": 10, "default": 2},
+    "ratio": {"type": "number"},
+    "workers": {"type": "integer", "minimum": 1},
+    "contact": {"type": "string", "format": "email"},
+    "debug": {"type": "boolean"},
+    "notes": {"type": "string", "format": "textarea"},
+    "bio": {"type": "string", "maxLength": 500},
+    "tags": {"type": "array", "items": {"type": "string"}},
+    "database": {
+      "title": "Banco de dados",
+      "type": "object",
+      "required": ["host"],
+      "properties": {
+        "host": {"type": "string"},
+        "pool": {"$ref": "#/$defs/pool"}
+      }
+    },
+    "servers": {"type": "array", "items": {"type": "object"}}
+  },
+  "$defs": {"pool": {"type": "number", "minimum": 0.5, "maximum": 5, "multipleOf": 0.5}}
+}`
+
+func TestFormFromSchema(t *testing.T) {
+	form, err := FormFromSchema([]byte(serviceSchema))
+	require.NoError(t, err)
+
+	cfg := form.Config
+	assert.Equal(t, "Serviço", cfg.Title)
+	assert.Equal(t, "Configuração do serviço", cfg.Description)
+	require.NoError(t, cfg.Validate())
+
+	var names []string
+	byName := make(map[string]config.ComponentConfig)
+	for _, comp := range cfg.Components {
+		names = append(names, comp.Name)
+		byName[comp.Name] = comp
+	}
+	assert.Equal(t, []string{
+		"name", "env", "level", "replicas", "ratio", "workers", "contact", "debug", "notes", "bio", "tags",
+		"database", "database.host", "database.pool",
+	}, names, "declaration order, unsupported properties left out")
+
+	tests := []struct {
+		name string
+		want config.ComponentConfig
+	}{
+		{"name", config.ComponentConfig{
+			Type: config.TypeTextInput, Name: "name", Label: "Nome", Help: "Nome do serviço", Required: true,
+			Options: map[string]interface{}{"pattern": "^[a-z-]+$", "min_length": 3, "max_length": 30},
+		}},
+		{"env", config.ComponentConfig{
+			Type: config.TypeRadioGroup, Name: "env", Label: "env", Required: true, Default: "dev",
+			Options: map[string]interface{}{"items": []interface{}{
+				map[string]interface{}{"id": "dev", "label": "dev"},
+				map[string]interface{}{"id": "prod", "label": "prod"},
+			}},
+		}},
+		{"replicas", config.ComponentConfig{
+			Type: config.TypeSlider, Name: "replicas", Label: "replicas", Default: 2,
+			Options: map[string]interface{}{"min": 1.0, "max": 10.0, "step": 1.0},
+		}},
+		{"ratio", config.ComponentConfig{
+			Type: config.TypeTextInput, Name: "ratio", Label: "ratio",
+			Options: map[string]interface{}{"validate": "float"},
+		}},
+		{"workers", config.ComponentConfig{
+			Type: config.TypeTextInput, Name: "workers", Label: "workers",
+			Options: map[string]interface{}{"validate": "int:1.."},
+		}},
+		{"contact", config.ComponentConfig{
+			Type: config.TypeTextInput, Name: "contact", Label: "contact",
+			Options: map[string]interface{}{"validate": "email"},
+		}},
+		{"debug", config.ComponentConfig{Type: config.TypeCheckbox, Name: "debug", Label: "debug"}},
+		{"notes", config.ComponentConfig{Type: config.TypeTextArea, Name: "notes", Label: "notes"}},
+		{"bio", config.ComponentConfig{
+			Type: config.TypeTextArea, Name: "bio", Label: "bio",
+			Options: map[string]interface{}{"max_length": 500},
+		}},
+		{"tags", config.ComponentConfig{Type: config.TypeTextArea, Name: "tags", Label: "tags", Help: "Um item por linha"}},
+		{"database", config.ComponentConfig{Type: config.TypeText, Name: "database", Label: "Banco de dados"}},
+		{"database.host", config.ComponentConfig{Type: config.TypeTextInput, Name: "database.host", Label: "host", Required: true}},
+		{"database.pool", config.ComponentConfig{
+			Type: config.TypeSlider, Name: "database.pool", Label: "pool",
+			Options: map[string]interface{}{"min": 0.5, "max": 5.0, "step": 0.5},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, byName[tt.name])
+		})
+	}
+}
+
+func TestForm_Shape(t *testing.T) {
+	form, err := FormFromSchema([]byte(serviceSchema))
+	require.NoError(t, err)
+
+	doc := form.Shape(map[string]interface{}{
+		"name":          "api",
+		"env":           "prod",
+		"level":         "2",
+		"replicas":      3.0,
+		"ratio":         "0.75",
+		"debug":         false,
+		"notes":         "",
+		"bio":           "",
+		"tags":          "web\n\n api \n",
+		"database":      "Banco de dados",
+		"database.host": "db",
+		"database.pool": 1.5,
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"name":     "api",
+		"env":      "prod",
+		"level":    2,
+		"replicas": int64(3),
+		"ratio":    0.75,
+		"debug":    false,
+		"tags":     []string{"web", "api"},
+		"database": map[string]interface{}{"host": "db", "pool": 1.5},
+	}, doc, "sections and empty optional answers are left out")
+
+	s, err := Parse([]byte(serviceSchema), false)
+	require.NoError(t, err)
+	assert.Empty(t, s.Validate(doc), "the shaped document conforms to its schema")
+}
+
+func TestFormFromSchema_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{"not an object", `[1, 2]`},
+		{"no supported properties", `{"properties": {"a": {"type": "null"}}}`},
+		{"missing reference", `{"properties": {"a": {"$ref": "#/$defs/nope"}}}`},
+		{"remote reference", `{"properties": {"a": {"$ref": "other.json"}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FormFromSchema([]byte(tt.schema))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFormFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "service.schema.json")
+	require.NoError(t, os.WriteFile(path, []byte(serviceSchema), 0o600))
+
+	form, err := FormFromFile(path)
+	require.NoError(t, err)
+	assert.NotEmpty(t, form.Config.Components)
+
+	_, err = FormFromFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
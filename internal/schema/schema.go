@@ -0,0 +1,322 @@
+// Package schema generates JSON Schemas (draft 2020-12) for Shantilly
+// configuration files, so editors such as yaml-language-server can
+// autocomplete and validate them.
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/helton/shantilly/internal/components"
+	"github.com/helton/shantilly/internal/config"
+	"github.com/helton/shantilly/internal/styles"
+)
+
+// Draft is the JSON Schema dialect of the generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// KindApp is the application configuration (config.Config).
+const KindApp = "app"
+
+// Kinds lists the configuration kinds accepted by Generate.
+func Kinds() []string {
+	return []string{config.KindForm, config.KindLayout, config.KindTabs, config.KindMenu, KindApp}
+}
+
+// roots maps each kind to the Go type of its document.
+var roots = map[string]reflect.Type{
+	config.KindForm:   reflect.TypeOf(config.FormConfig{}),
+	config.KindLayout: reflect.TypeOf(config.LayoutConfig{}),
+	config.KindTabs:   reflect.TypeOf(config.TabsConfig{}),
+	config.KindMenu:   reflect.TypeOf(config.MenuConfig{}),
+	KindApp:           reflect.TypeOf(config.Config{}),
+}
+
+// required lists the mandatory keys of each configuration struct.
+var required = map[string][]string{
+	"FormConfig":      {"components"},
+	"LayoutConfig":    {"layout", "components"},
+	"TabsConfig":      {"tabs"},
+	"TabConfig":       {"name", "label", "components"},
+	"MenuConfig":      {"items"},
+	"ComponentConfig": {"type", "name"},
+	"Rule":            {"type"},
+}
+
+// enums lists the accepted values of string fields, by "Struct.key".
+var enums = map[string][]string{
+	"FormConfig.display":      {config.DisplayFullscreen, config.DisplayInline},
+	"FormConfig.on_timeout":   {config.TimeoutSubmitDefaults, config.TimeoutCancel},
+	"LayoutConfig.display":    {config.DisplayFullscreen, config.DisplayInline},
+	"LayoutConfig.on_timeout": {config.TimeoutSubmitDefaults, config.TimeoutCancel},
+	"LayoutConfig.layout":     {"horizontal", "vertical"},
+	"MenuConfig.display":      {config.DisplayFullscreen, config.DisplayInline},
+	"TabsConfig.display":      {config.DisplayFullscreen, config.DisplayInline},
+	"Rule.type":               config.RuleTypes(),
+}
+
+// durationPattern matches the strings accepted by time.ParseDuration.
+const durationPattern = `^-?([0-9]+(\.[0-9]*)?(ns|us|µs|ms|s|m|h))+$`
+
+var (
+	durationType      = reflect.TypeOf(time.Duration(0))
+	timeType          = reflect.TypeOf(time.Time{})
+	componentTypeType = reflect.TypeOf(config.ComponentType(""))
+)
+
+// Generate returns the JSON Schema of a configuration kind.
+func Generate(kind string) (map[string]interface{}, error) {
+	root, ok := roots[kind]
+	if !ok {
+		return nil, fmt.Errorf("tipo de configuração inválido: %s (válidos: %s)", kind, strings.Join(Kinds(), ", "))
+	}
+
+	g := &generator{defs: make(map[string]interface{})}
+	s := g.structSchema(root)
+	s["$schema"] = Draft
+	s["title"] = "Shantilly " + kind
+	s["$defs"] = g.defs
+	return s, nil
+}
+
+// generator builds schemas from the yaml tags of the configuration structs.
+// Nested structs are emitted once under $defs and referenced.
+type generator struct {
+	defs map[string]interface{}
+}
+
+// typeSchema returns the schema of a Go type.
+func (g *generator) typeSchema(t reflect.Type) map[string]interface{} {
+	switch t {
+	case durationType:
+		return map[string]interface{}{
+			"type":        "string",
+			"pattern":     durationPattern,
+			"description": "Duração, por exemplo 30s ou 2m",
+		}
+	case timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case componentTypeType:
+		return map[string]interface{}{"enum": componentTypes()}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": g.typeSchema(t.Elem())}
+	case reflect.Ptr:
+		return g.typeSchema(t.Elem())
+	case reflect.Struct:
+		g.define(t)
+		return map[string]interface{}{"$ref": "#/$defs/" + t.Name()}
+	default:
+		// interface{} accepts any value
+		return map[string]interface{}{}
+	}
+}
+
+// define adds the schema of a struct type to $defs once.
+func (g *generator) define(t reflect.Type) {
+	if _, ok := g.defs[t.Name()]; ok {
+		return
+	}
+	// Reserve the name first, so recursive types terminate
+	g.defs[t.Name()] = nil
+	g.defs[t.Name()] = g.structSchema(t)
+}
+
+// structSchema returns the object schema of a struct type, with a property
+// per yaml field and no other keys allowed.
+func (g *generator) structSchema(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{}, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		key := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+		prop := g.typeSchema(t.Field(i).Type)
+		if values, ok := enums[t.Name()+"."+key]; ok {
+			prop = map[string]interface{}{"enum": values}
+		}
+		properties[key] = prop
+	}
+
+	s := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if keys, ok := required[t.Name()]; ok {
+		s["required"] = keys
+	}
+	if t == reflect.TypeOf(config.ComponentConfig{}) {
+		s["allOf"] = componentVariants()
+	}
+	return s
+}
+
+// componentVariants returns one if/then clause per component type, with the
+// description and value schema from the component metadata and the options
+// understood by that type.
+func componentVariants() []interface{} {
+	var variants []interface{}
+	for _, t := range config.ComponentTypes() {
+		then := map[string]interface{}{
+			"properties": map[string]interface{}{
+				"options": optionsSchema(config.ComponentOptions(t)),
+			},
+		}
+		switch t {
+		case config.TypeRadioGroup:
+			then["required"] = []string{"options"}
+		case config.TypeComputed:
+			then["required"] = []string{"compute"}
+		}
+
+		if meta, ok := metadata(t); ok {
+			then["description"] = meta.Description
+			if value := valueSchema(meta); value != nil {
+				then["properties"].(map[string]interface{})["default"] = value
+			}
+		}
+
+		variants = append(variants, map[string]interface{}{
+			"if": map[string]interface{}{
+				"properties": map[string]interface{}{"type": map[string]interface{}{"const": string(t)}},
+				"required":   []string{"type"},
+			},
+			"then": then,
+		})
+	}
+	return variants
+}
+
+// optionsSchema returns the schema of the options map of a component type.
+func optionsSchema(options map[string]config.OptionKind) map[string]interface{} {
+	properties := make(map[string]interface{}, len(options))
+	for key, kind := range options {
+		properties[key] = optionSchema(kind)
+	}
+	s := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if _, ok := options["items"]; ok {
+		s["required"] = []string{"items"}
+	}
+	if output, ok := properties["mask_output"].(map[string]interface{}); ok {
+		output["enum"] = []string{config.MaskOutputMasked, config.MaskOutputRaw}
+	}
+	return s
+}
+
+// optionSchema returns the schema of one option kind.
+func optionSchema(kind config.OptionKind) map[string]interface{} {
+	switch kind {
+	case config.OptionInt:
+		return map[string]interface{}{"type": "integer"}
+	case config.OptionNumber:
+		return map[string]interface{}{"type": "number"}
+	case config.OptionString:
+		return map[string]interface{}{"type": "string"}
+	case config.OptionBool:
+		return map[string]interface{}{"type": "boolean"}
+	case config.OptionItems:
+		return map[string]interface{}{
+			"type":     "array",
+			"minItems": 1,
+			"items": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"id":    map[string]interface{}{"type": "string", "minLength": 1},
+					"label": map[string]interface{}{"type": "string", "minLength": 1},
+				},
+				"required":             []string{"id", "label"},
+				"additionalProperties": false,
+			},
+		}
+	case config.OptionCommand:
+		return map[string]interface{}{
+			"type":        "array",
+			"minItems":    1,
+			"items":       map[string]interface{}{"type": "string"},
+			"description": "Programa e argumentos; " + config.CommandValuePlaceholder + " é substituído pelo valor do campo",
+		}
+	case config.OptionValidators:
+		name := map[string]interface{}{"type": "string", "pattern": validatorPattern()}
+		return map[string]interface{}{
+			"anyOf":       []interface{}{name, map[string]interface{}{"type": "array", "items": name}},
+			"description": "Validadores: " + strings.Join(config.ValidatorNames(), ", ") + "; int e float aceitam intervalo, por exemplo int:1..10",
+		}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// validatorPattern matches the validator names, with an optional range for
+// int and float.
+func validatorPattern() string {
+	var names []string
+	for _, name := range config.ValidatorNames() {
+		if name != config.ValidatorInt && name != config.ValidatorFloat {
+			names = append(names, name)
+		}
+	}
+	return `^(` + strings.Join(names, "|") + `|(int|float)(:(-?[0-9.]+)?\.\.(-?[0-9.]+)?)?)$`
+}
+
+// metadata returns the metadata of a component type, taken from an instance
+// built with a minimal configuration.
+func metadata(t config.ComponentType) (components.ComponentMetadata, bool) {
+	cfg := config.ComponentConfig{Type: t, Name: "schema"}
+	switch t {
+	case config.TypeRadioGroup:
+		cfg.Options = map[string]interface{}{
+			"items": []interface{}{map[string]interface{}{"id": "a", "label": "a"}},
+		}
+	case config.TypeComputed:
+		cfg.Compute = "null"
+	}
+
+	comp, err := components.NewComponent(cfg, styles.DefaultTheme())
+	if err != nil {
+		return components.ComponentMetadata{}, false
+	}
+	return comp.GetMetadata(), true
+}
+
+// valueSchema returns the schema of the component value from its metadata.
+func valueSchema(meta components.ComponentMetadata) map[string]interface{} {
+	properties, ok := meta.Schema["properties"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	value, ok := properties["value"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return value
+}
+
+// componentTypes returns the component types as strings.
+func componentTypes() []string {
+	types := config.ComponentTypes()
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t)
+	}
+	return names
+}
@@ -0,0 +1,148 @@
+package schema
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/helton/shantilly/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// object returns the nested object at path, failing the test if missing.
+func object(t *testing.T, s map[string]interface{}, path ...string) map[string]interface{} {
+	t.Helper()
+	current := s
+	for _, key := range path {
+		next, ok := current[key].(map[string]interface{})
+		require.True(t, ok, "chave ausente: %s", key)
+		current = next
+	}
+	return current
+}
+
+// variant returns the then clause for a component type.
+func variant(t *testing.T, s map[string]interface{}, typ string) map[string]interface{} {
+	t.Helper()
+	comp := object(t, s, "$defs", "ComponentConfig")
+	for _, v := range comp["allOf"].([]interface{}) {
+		clause := v.(map[string]interface{})
+		cond := object(t, clause, "if", "properties", "type")
+		if cond["const"] == typ {
+			return clause["then"].(map[string]interface{})
+		}
+	}
+	t.Fatalf("variante ausente: %s", typ)
+	return nil
+}
+
+func TestGenerate(t *testing.T) {
+	for _, kind := range Kinds() {
+		t.Run(kind, func(t *testing.T) {
+			s, err := Generate(kind)
+			require.NoError(t, err)
+			assert.Equal(t, Draft, s["$schema"])
+			assert.Equal(t, "object", s["type"])
+
+			_, err = json.Marshal(s)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGenerate_InvalidKind(t *testing.T) {
+	_, err := Generate("wizard")
+	assert.Error(t, err)
+}
+
+func TestGenerate_Form(t *testing.T) {
+	s, err := Generate("form")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"components"}, s["required"])
+	assert.Equal(t, false, s["additionalProperties"])
+
+	props := object(t, s, "properties")
+	assert.Equal(t, map[string]interface{}{"$ref": "#/$defs/ComponentConfig"}, object(t, props, "components")["items"])
+	assert.Equal(t, []string{"fullscreen", "inline"}, object(t, props, "display")["enum"])
+	assert.Equal(t, "string", object(t, props, "timeout")["type"])
+
+	comp := object(t, s, "$defs", "ComponentConfig")
+	assert.Equal(t, []string{"type", "name"}, comp["required"])
+	assert.Contains(t, object(t, comp, "properties", "type")["enum"], "slider")
+
+	rule := object(t, s, "$defs", "Rule")
+	assert.Equal(t, []string{"type"}, rule["required"])
+	assert.Equal(t, config.RuleTypes(), object(t, rule, "properties", "type")["enum"])
+}
+
+func TestGenerate_ComponentVariants(t *testing.T) {
+	s, err := Generate("form")
+	require.NoError(t, err)
+
+	tests := []struct {
+		typ      string
+		options  []string
+		valueTyp string
+	}{
+		{"textinput", []string{"mask", "mask_output", "max_length", "min_length", "pattern", "validate", "validate_command", "validate_timeout"}, "string"},
+		{"textarea", []string{"height", "max_length", "min_length", "pattern", "validate", "validate_command", "validate_timeout", "width"}, "string"},
+		{"checkbox", []string{}, "boolean"},
+		{"radiogroup", []string{"items"}, "string"},
+		{"slider", []string{"max", "min", "step", "width"}, "number"},
+		{"filepicker", []string{"filter", "max_history", "preview_mode", "show_hidden"}, "string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			then := variant(t, s, tt.typ)
+			assert.NotEmpty(t, then["description"], "description vem dos metadados")
+
+			options := object(t, then, "properties", "options")
+			assert.Equal(t, false, options["additionalProperties"])
+			var keys []string
+			for key := range object(t, options, "properties") {
+				keys = append(keys, key)
+			}
+			assert.ElementsMatch(t, tt.options, keys)
+
+			assert.Equal(t, tt.valueTyp, object(t, then, "properties", "default")["type"])
+		})
+	}
+
+	validate := object(t, variant(t, s, "textinput"), "properties", "options", "properties", "validate")
+	name := validate["anyOf"].([]interface{})[0].(map[string]interface{})
+	pattern := regexp.MustCompile(name["pattern"].(string))
+	for _, spec := range []string{"email", "path_exists", "int", "int:1..10", "float:..0.5"} {
+		assert.True(t, pattern.MatchString(spec), spec)
+	}
+	for _, spec := range []string{"mail", "port:1..2", "int:1-10"} {
+		assert.False(t, pattern.MatchString(spec), spec)
+	}
+
+	radio := variant(t, s, "radiogroup")
+	assert.Equal(t, []string{"options"}, radio["required"])
+	items := object(t, radio, "properties", "options", "properties", "items")
+	assert.Equal(t, 1, items["minItems"])
+}
+
+func TestGenerate_Tabs(t *testing.T) {
+	s, err := Generate("tabs")
+	require.NoError(t, err)
+
+	tab := object(t, s, "$defs", "TabConfig")
+	assert.Equal(t, []string{"name", "label", "components"}, tab["required"])
+	assert.Contains(t, s["$defs"], "ComponentConfig")
+}
+
+func TestGenerate_App(t *testing.T) {
+	s, err := Generate("app")
+	require.NoError(t, err)
+
+	defs := object(t, s, "$defs")
+	for _, name := range []string{"GlobalConfig", "FormConfig", "ThemeConfig", "ValidationConfig"} {
+		assert.Contains(t, defs, name)
+	}
+	assert.Equal(t, map[string]interface{}{"$ref": "#/$defs/ThemeConfig"}, object(t, s, "properties", "themes")["additionalProperties"])
+}
@@ -0,0 +1,592 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Violation is a value that does not conform to a schema.
+type Violation struct {
+	Field   string // Top-level property involved, empty for the document itself
+	Path    string // Location of the value, e.g. "tags[0]"
+	Message string
+}
+
+// Error formats the violation as "path: message".
+func (v Violation) Error() string {
+	if v.Path == "" {
+		return v.Message
+	}
+	return v.Path + ": " + v.Message
+}
+
+// Schema is a compiled JSON Schema. It implements the draft 2020-12
+// validation vocabulary used for data contracts: type, enum, const,
+// properties, required, additionalProperties, items, length, range and
+// pattern keywords, common formats, the allOf/anyOf/oneOf/not/if
+// combinators and local $ref.
+type Schema struct {
+	root     map[string]interface{}
+	strict   bool
+	patterns map[string]*regexp.Regexp
+}
+
+// keywords lists the keywords understood by Validate. Annotations such as
+// title are accepted and ignored.
+var keywords = map[string]bool{
+	"$schema": true, "$id": true, "$ref": true, "$defs": true, "definitions": true,
+	"$comment": true, "title": true, "description": true, "default": true,
+	"examples": true, "deprecated": true, "readOnly": true, "writeOnly": true,
+	"type": true, "enum": true, "const": true,
+	"properties": true, "required": true, "additionalProperties": true,
+	"minProperties": true, "maxProperties": true,
+	"items": true, "minItems": true, "maxItems": true, "uniqueItems": true,
+	"minLength": true, "maxLength": true, "pattern": true, "format": true,
+	"minimum": true, "maximum": true, "exclusiveMinimum": true,
+	"exclusiveMaximum": true, "multipleOf": true,
+	"allOf": true, "anyOf": true, "oneOf": true, "not": true,
+	"if": true, "then": true, "else": true,
+}
+
+// Load reads and compiles a JSON Schema file.
+func Load(path string, strict bool) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler o schema: %w", err)
+	}
+	s, err := Parse(data, strict)
+	if err != nil {
+		return nil, fmt.Errorf("erro no schema %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Parse compiles a JSON Schema document. In strict mode, keywords that
+// Validate does not implement are rejected instead of ignored, and Validate
+// reports document properties that the schema does not declare.
+func Parse(data []byte, strict bool) (*Schema, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("erro ao analisar o JSON do schema: %w", err)
+	}
+
+	s := &Schema{root: root, strict: strict, patterns: make(map[string]*regexp.Regexp)}
+	if err := s.compile(root, ""); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// compile checks the regular expressions, references and, in strict mode,
+// the keywords of a subschema.
+func (s *Schema) compile(node map[string]interface{}, path string) error {
+	for key, value := range node {
+		at := path + "/" + key
+		if s.strict && !keywords[key] {
+			return fmt.Errorf("%s: palavra-chave não suportada: %s", at, key)
+		}
+
+		switch key {
+		case "pattern":
+			pattern, _ := value.(string)
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Errorf("%s: regex inválida: %w", at, err)
+			}
+			s.patterns[pattern] = re
+		case "$ref":
+			ref, _ := value.(string)
+			if _, err := s.resolve(ref); err != nil {
+				return fmt.Errorf("%s: %w", at, err)
+			}
+		case "properties", "$defs", "definitions":
+			children, _ := value.(map[string]interface{})
+			for name, child := range children {
+				if sub, ok := child.(map[string]interface{}); ok {
+					if err := s.compile(sub, at+"/"+name); err != nil {
+						return err
+					}
+				}
+			}
+		case "allOf", "anyOf", "oneOf":
+			children, _ := value.([]interface{})
+			for i, child := range children {
+				if sub, ok := child.(map[string]interface{}); ok {
+					if err := s.compile(sub, at+"/"+strconv.Itoa(i)); err != nil {
+						return err
+					}
+				}
+			}
+		case "items", "additionalProperties", "not", "if", "then", "else":
+			if sub, ok := value.(map[string]interface{}); ok {
+				if err := s.compile(sub, at); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// resolve follows a local reference such as "#/$defs/address".
+func (s *Schema) resolve(ref string) (map[string]interface{}, error) {
+	if ref != "#" && !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("apenas referências locais são suportadas: %s", ref)
+	}
+
+	var node interface{} = s.root
+	for _, part := range strings.Split(strings.TrimPrefix(strings.TrimPrefix(ref, "#"), "/"), "/") {
+		if part == "" {
+			continue
+		}
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("referência não encontrada: %s", ref)
+		}
+		if node, ok = m[part]; !ok {
+			return nil, fmt.Errorf("referência não encontrada: %s", ref)
+		}
+	}
+
+	sub, ok := node.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("referência não aponta para um schema: %s", ref)
+	}
+	return sub, nil
+}
+
+// Validate checks a document, such as the map of submitted values, and
+// returns every violation found.
+func (s *Schema) Validate(value interface{}) []Violation {
+	doc, err := normalize(value)
+	if err != nil {
+		return []Violation{{Message: fmt.Sprintf("valor não serializável: %v", err)}}
+	}
+
+	var result []Violation
+	s.validate(s.root, doc, nil, &result, 0)
+
+	if s.strict {
+		s.undeclared(doc, &result)
+	}
+	return result
+}
+
+// undeclared reports top-level properties of doc that the root schema does
+// not list in properties.
+func (s *Schema) undeclared(doc interface{}, result *[]Violation) {
+	obj, ok := doc.(map[string]interface{})
+	if !ok {
+		return
+	}
+	declared, _ := s.root["properties"].(map[string]interface{})
+	for _, name := range sortedKeys(obj) {
+		if _, ok := declared[name]; !ok {
+			*result = append(*result, Violation{Field: name, Path: name, Message: "campo não declarado no schema"})
+		}
+	}
+}
+
+// maxDepth bounds $ref expansion, so recursive schemas can't loop forever.
+const maxDepth = 64
+
+// validate checks value against a subschema. path holds the location of
+// value as property names and indexes.
+func (s *Schema) validate(node map[string]interface{}, value interface{}, path []string, result *[]Violation, depth int) {
+	report := func(format string, args ...interface{}) {
+		*result = append(*result, violation(path, fmt.Sprintf(format, args...)))
+	}
+
+	if depth > maxDepth {
+		report("referências aninhadas demais no schema")
+		return
+	}
+
+	if ref, ok := node["$ref"].(string); ok {
+		if sub, err := s.resolve(ref); err == nil {
+			s.validate(sub, value, path, result, depth+1)
+		}
+	}
+
+	if t, ok := node["type"]; ok && !matchesType(value, t) {
+		report("deve ser do tipo %s", typeNames(t))
+		// The remaining keywords assume the right type
+		return
+	}
+
+	if enum, ok := node["enum"].([]interface{}); ok {
+		found := false
+		for _, candidate := range enum {
+			if equal(candidate, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			report("deve ser um de: %s", formatValues(enum))
+		}
+	}
+	if c, ok := node["const"]; ok && !equal(c, value) {
+		report("deve ser igual a %s", formatValue(c))
+	}
+
+	switch v := value.(type) {
+	case string:
+		s.validateString(node, v, report)
+	case float64:
+		validateNumber(node, v, report)
+	case []interface{}:
+		s.validateArray(node, v, path, result, depth, report)
+	case map[string]interface{}:
+		s.validateObject(node, v, path, result, depth, report)
+	}
+
+	s.validateCombinators(node, value, path, result, depth, report)
+}
+
+// validateString checks the string keywords.
+func (s *Schema) validateString(node map[string]interface{}, v string, report func(string, ...interface{})) {
+	length := utf8.RuneCountInString(v)
+	if min, ok := number(node["minLength"]); ok && float64(length) < min {
+		report("deve ter no mínimo %s caracteres", formatNumber(min))
+	}
+	if max, ok := number(node["maxLength"]); ok && float64(length) > max {
+		report("deve ter no máximo %s caracteres", formatNumber(max))
+	}
+	if pattern, ok := node["pattern"].(string); ok {
+		if re := s.patterns[pattern]; re != nil && !re.MatchString(v) {
+			report("não corresponde ao padrão %s", pattern)
+		}
+	}
+	if format, ok := node["format"].(string); ok && !matchesFormat(format, v) {
+		report("formato %s inválido", format)
+	}
+}
+
+// validateNumber checks the numeric keywords.
+func validateNumber(node map[string]interface{}, v float64, report func(string, ...interface{})) {
+	if min, ok := number(node["minimum"]); ok && v < min {
+		report("deve ser maior ou igual a %s", formatNumber(min))
+	}
+	if max, ok := number(node["maximum"]); ok && v > max {
+		report("deve ser menor ou igual a %s", formatNumber(max))
+	}
+	if min, ok := number(node["exclusiveMinimum"]); ok && v <= min {
+		report("deve ser maior que %s", formatNumber(min))
+	}
+	if max, ok := number(node["exclusiveMaximum"]); ok && v >= max {
+		report("deve ser menor que %s", formatNumber(max))
+	}
+	if m, ok := number(node["multipleOf"]); ok && m > 0 {
+		if q := v / m; math.Abs(q-math.Round(q)) > 1e-9 {
+			report("deve ser múltiplo de %s", formatNumber(m))
+		}
+	}
+}
+
+// validateArray checks the array keywords and the items.
+func (s *Schema) validateArray(node map[string]interface{}, v []interface{}, path []string, result *[]Violation, depth int, report func(string, ...interface{})) {
+	if min, ok := number(node["minItems"]); ok && float64(len(v)) < min {
+		report("deve ter no mínimo %s itens", formatNumber(min))
+	}
+	if max, ok := number(node["maxItems"]); ok && float64(len(v)) > max {
+		report("deve ter no máximo %s itens", formatNumber(max))
+	}
+	if unique, _ := node["uniqueItems"].(bool); unique {
+		for i := range v {
+			for j := i + 1; j < len(v); j++ {
+				if equal(v[i], v[j]) {
+					report("itens devem ser únicos: %s se repete", formatValue(v[i]))
+				}
+			}
+		}
+	}
+	if items, ok := node["items"].(map[string]interface{}); ok {
+		for i, item := range v {
+			s.validate(items, item, appendPath(path, "["+strconv.Itoa(i)+"]"), result, depth+1)
+		}
+	}
+}
+
+// validateObject checks the object keywords and the properties.
+func (s *Schema) validateObject(node map[string]interface{}, v map[string]interface{}, path []string, result *[]Violation, depth int, report func(string, ...interface{})) {
+	if required, ok := node["required"].([]interface{}); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, present := v[name]; !present {
+				*result = append(*result, violation(appendPath(path, name), "campo obrigatório ausente"))
+			}
+		}
+	}
+	if min, ok := number(node["minProperties"]); ok && float64(len(v)) < min {
+		report("deve ter no mínimo %s campos", formatNumber(min))
+	}
+	if max, ok := number(node["maxProperties"]); ok && float64(len(v)) > max {
+		report("deve ter no máximo %s campos", formatNumber(max))
+	}
+
+	properties, _ := node["properties"].(map[string]interface{})
+	for _, name := range sortedKeys(v) {
+		at := appendPath(path, name)
+		if sub, ok := properties[name].(map[string]interface{}); ok {
+			s.validate(sub, v[name], at, result, depth+1)
+			continue
+		}
+		switch additional := node["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				*result = append(*result, violation(at, "campo não permitido pelo schema"))
+			}
+		case map[string]interface{}:
+			s.validate(additional, v[name], at, result, depth+1)
+		}
+	}
+}
+
+// validateCombinators checks allOf, anyOf, oneOf, not and if/then/else.
+func (s *Schema) validateCombinators(node map[string]interface{}, value interface{}, path []string, result *[]Violation, depth int, report func(string, ...interface{})) {
+	for _, sub := range subschemas(node["allOf"]) {
+		s.validate(sub, value, path, result, depth+1)
+	}
+
+	if anyOf := subschemas(node["anyOf"]); len(anyOf) > 0 {
+		matched := false
+		for _, sub := range anyOf {
+			if s.matches(sub, value, path, depth) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			report("não corresponde a nenhuma das alternativas do schema")
+		}
+	}
+
+	if oneOf := subschemas(node["oneOf"]); len(oneOf) > 0 {
+		count := 0
+		for _, sub := range oneOf {
+			if s.matches(sub, value, path, depth) {
+				count++
+			}
+		}
+		if count != 1 {
+			report("deve corresponder a exatamente uma das alternativas do schema (corresponde a %d)", count)
+		}
+	}
+
+	if not, ok := node["not"].(map[string]interface{}); ok && s.matches(not, value, path, depth) {
+		report("não deve corresponder ao schema em 'not'")
+	}
+
+	if cond, ok := node["if"].(map[string]interface{}); ok {
+		branch := "else"
+		if s.matches(cond, value, path, depth) {
+			branch = "then"
+		}
+		if sub, ok := node[branch].(map[string]interface{}); ok {
+			s.validate(sub, value, path, result, depth+1)
+		}
+	}
+}
+
+// matches reports whether value conforms to sub.
+func (s *Schema) matches(sub map[string]interface{}, value interface{}, path []string, depth int) bool {
+	var found []Violation
+	s.validate(sub, value, path, &found, depth+1)
+	return len(found) == 0
+}
+
+// normalize converts Go values into the JSON data model (maps, slices,
+// float64, string, bool and nil) by a JSON round trip.
+func normalize(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// matchesType checks the type keyword, a name or a list of names.
+func matchesType(value interface{}, t interface{}) bool {
+	switch t := t.(type) {
+	case string:
+		return isType(value, t)
+	case []interface{}:
+		for _, name := range t {
+			if n, ok := name.(string); ok && isType(value, n) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// isType reports whether value has the JSON type name.
+func isType(value interface{}, name string) bool {
+	switch name {
+	case "null":
+		return value == nil
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		f, ok := value.(float64)
+		return ok && f == math.Trunc(f)
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	default:
+		return false
+	}
+}
+
+// typeNames formats the type keyword for messages.
+func typeNames(t interface{}) string {
+	if list, ok := t.([]interface{}); ok {
+		names := make([]string, len(list))
+		for i, name := range list {
+			names[i] = fmt.Sprint(name)
+		}
+		return strings.Join(names, " ou ")
+	}
+	return fmt.Sprint(t)
+}
+
+// uuidRe matches the textual form of a UUID.
+var uuidRe = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// matchesFormat asserts the common formats. Unknown formats are annotations
+// and always match.
+func matchesFormat(format, v string) bool {
+	switch format {
+	case "email":
+		addr, err := mail.ParseAddress(v)
+		return err == nil && addr.Address == v
+	case "uri":
+		u, err := url.Parse(v)
+		return err == nil && u.Scheme != ""
+	case "date":
+		_, err := time.Parse("2006-01-02", v)
+		return err == nil
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, v)
+		return err == nil
+	case "time":
+		_, err := time.Parse("15:04:05Z07:00", v)
+		return err == nil
+	case "ipv4":
+		ip := net.ParseIP(v)
+		return ip != nil && ip.To4() != nil && !strings.Contains(v, ":")
+	case "ipv6":
+		ip := net.ParseIP(v)
+		return ip != nil && strings.Contains(v, ":")
+	case "uuid":
+		return uuidRe.MatchString(v)
+	case "regex":
+		_, err := regexp.Compile(v)
+		return err == nil
+	default:
+		return true
+	}
+}
+
+// equal compares two JSON values.
+func equal(a, b interface{}) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+// number reads a numeric keyword.
+func number(v interface{}) (float64, bool) {
+	f, ok := v.(float64)
+	return f, ok
+}
+
+// subschemas reads the list of a combinator keyword.
+func subschemas(v interface{}) []map[string]interface{} {
+	list, _ := v.([]interface{})
+	var result []map[string]interface{}
+	for _, item := range list {
+		if sub, ok := item.(map[string]interface{}); ok {
+			result = append(result, sub)
+		}
+	}
+	return result
+}
+
+// violation builds a violation at path.
+func violation(path []string, message string) Violation {
+	v := Violation{Path: strings.Join(path, "."), Message: message}
+	v.Path = strings.ReplaceAll(v.Path, ".[", "[")
+	if len(path) > 0 {
+		v.Field = path[0]
+	}
+	return v
+}
+
+// appendPath returns a copy of path with one more element.
+func appendPath(path []string, elem string) []string {
+	next := make([]string, len(path), len(path)+1)
+	copy(next, path)
+	return append(next, elem)
+}
+
+// sortedKeys returns the keys of an object in a stable order.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatValue formats a JSON value for messages.
+func formatValue(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// formatValues formats a list of JSON values for messages.
+func formatValues(list []interface{}) string {
+	values := make([]string, len(list))
+	for i, v := range list {
+		values[i] = formatValue(v)
+	}
+	return strings.Join(values, ", ")
+}
+
+// formatNumber formats a number without trailing zeros.
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,216 @@
+package schema
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSchema_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		value  interface{}
+		want   []Violation
+	}{
+		{
+			name:   "type mismatch",
+			schema: `{"properties": {"age": {"type": "integer"}}}`,
+			value:  map[string]interface{}{"age": 1.5},
+			want:   []Violation{{Field: "age", Path: "age", Message: "deve ser do tipo integer"}},
+		},
+		{
+			name:   "integer accepts whole floats",
+			schema: `{"properties": {"age": {"type": ["integer", "null"]}}}`,
+			value:  map[string]interface{}{"age": float64(30)},
+		},
+		{
+			name:   "required",
+			schema: `{"required": ["email"]}`,
+			value:  map[string]interface{}{},
+			want:   []Violation{{Field: "email", Path: "email", Message: "campo obrigatório ausente"}},
+		},
+		{
+			name:   "enum and const",
+			schema: `{"properties": {"plan": {"enum": ["pro", "free"]}, "terms": {"const": true}}}`,
+			value:  map[string]interface{}{"plan": "gold", "terms": false},
+			want: []Violation{
+				{Field: "plan", Path: "plan", Message: `deve ser um de: "pro", "free"`},
+				{Field: "terms", Path: "terms", Message: "deve ser igual a true"},
+			},
+		},
+		{
+			name:   "string keywords",
+			schema: `{"properties": {"user": {"minLength": 3, "maxLength": 4, "pattern": "^[a-z]+$"}}}`,
+			value:  map[string]interface{}{"user": "A1"},
+			want: []Violation{
+				{Field: "user", Path: "user", Message: "deve ter no mínimo 3 caracteres"},
+				{Field: "user", Path: "user", Message: "não corresponde ao padrão ^[a-z]+$"},
+			},
+		},
+		{
+			name:   "length counts runes",
+			schema: `{"properties": {"city": {"maxLength": 5}}}`,
+			value:  map[string]interface{}{"city": "Goiás"},
+		},
+		{
+			name:   "numeric keywords",
+			schema: `{"properties": {"n": {"minimum": 1, "exclusiveMaximum": 10, "multipleOf": 0.5}}}`,
+			value:  map[string]interface{}{"n": 10.25},
+			want: []Violation{
+				{Field: "n", Path: "n", Message: "deve ser menor que 10"},
+				{Field: "n", Path: "n", Message: "deve ser múltiplo de 0.5"},
+			},
+		},
+		{
+			name:   "formats",
+			schema: `{"properties": {"email": {"format": "email"}, "day": {"format": "date"}, "id": {"format": "uuid"}, "x": {"format": "custom"}}}`,
+			value:  map[string]interface{}{"email": "maria@", "day": "2024-02-30", "id": "123e4567-e89b-12d3-a456-426614174000", "x": "?"},
+			want: []Violation{
+				{Field: "day", Path: "day", Message: "formato date inválido"},
+				{Field: "email", Path: "email", Message: "formato email inválido"},
+			},
+		},
+		{
+			name:   "arrays",
+			schema: `{"properties": {"tags": {"type": "array", "minItems": 1, "uniqueItems": true, "items": {"type": "string", "minLength": 2}}}}`,
+			value:  map[string]interface{}{"tags": []string{"go", "x", "go"}},
+			want: []Violation{
+				{Field: "tags", Path: "tags", Message: `itens devem ser únicos: "go" se repete`},
+				{Field: "tags", Path: "tags[1]", Message: "deve ter no mínimo 2 caracteres"},
+			},
+		},
+		{
+			name:   "additional properties",
+			schema: `{"properties": {"a": {}}, "additionalProperties": false}`,
+			value:  map[string]interface{}{"a": 1, "b": 2},
+			want:   []Violation{{Field: "b", Path: "b", Message: "campo não permitido pelo schema"}},
+		},
+		{
+			name:   "local reference",
+			schema: `{"$defs": {"port": {"type": "integer", "maximum": 65535}}, "properties": {"port": {"$ref": "#/$defs/port"}}}`,
+			value:  map[string]interface{}{"port": 70000},
+			want:   []Violation{{Field: "port", Path: "port", Message: "deve ser menor ou igual a 65535"}},
+		},
+		{
+			name:   "if then",
+			schema: `{"if": {"properties": {"plan": {"const": "pro"}}}, "then": {"required": ["card"]}}`,
+			value:  map[string]interface{}{"plan": "pro"},
+			want:   []Violation{{Field: "card", Path: "card", Message: "campo obrigatório ausente"}},
+		},
+		{
+			name:   "anyOf and oneOf",
+			schema: `{"anyOf": [{"required": ["email"]}, {"required": ["phone"]}], "oneOf": [{"type": "object"}, {"required": ["a"]}]}`,
+			value:  map[string]interface{}{"a": 1},
+			want: []Violation{
+				{Message: "não corresponde a nenhuma das alternativas do schema"},
+				{Message: "deve corresponder a exatamente uma das alternativas do schema (corresponde a 2)"},
+			},
+		},
+		{
+			name:   "not",
+			schema: `{"properties": {"user": {"not": {"const": "root"}}}}`,
+			value:  map[string]interface{}{"user": "root"},
+			want:   []Violation{{Field: "user", Path: "user", Message: "não deve corresponder ao schema em 'not'"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse([]byte(tt.schema), false)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s.Validate(tt.value))
+		})
+	}
+}
+
+func TestSchema_Strict(t *testing.T) {
+	s, err := Parse([]byte(`{"properties": {"a": {"type": "string"}}}`), true)
+	require.NoError(t, err)
+	assert.Equal(t, []Violation{{Field: "b", Path: "b", Message: "campo não declarado no schema"}},
+		s.Validate(map[string]interface{}{"a": "x", "b": true}))
+
+	_, err = Parse([]byte(`{"properties": {"a": {"contains": {}}}}`), true)
+	assert.Error(t, err, "palavra-chave não suportada")
+
+	_, err = Parse([]byte(`{"properties": {"a": {"contains": {}}}}`), false)
+	assert.NoError(t, err, "fora do modo estrito, palavras-chave desconhecidas são ignoradas")
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{"invalid JSON", `{`},
+		{"invalid pattern", `{"properties": {"a": {"pattern": "[a-"}}}`},
+		{"missing reference", `{"properties": {"a": {"$ref": "#/$defs/nope"}}}`},
+		{"remote reference", `{"$ref": "https://example.com/schema.json"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.schema), false)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "contract.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"required": ["a"]}`), 0o600))
+
+	s, err := Load(path, false)
+	require.NoError(t, err)
+	assert.Len(t, s.Validate(map[string]interface{}{}), 1)
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.json"), false)
+	assert.Error(t, err)
+}
+
+func TestSchema_ValidatesGeneratedSchemaShape(t *testing.T) {
+	// The schemas emitted by Generate only use supported keywords
+	for _, kind := range Kinds() {
+		generated, err := Generate(kind)
+		require.NoError(t, err)
+		data, err := json.Marshal(generated)
+		require.NoError(t, err)
+		_, err = Parse(data, true)
+		assert.NoError(t, err, kind)
+	}
+}
+
+func TestGenerate_AcceptsExamples(t *testing.T) {
+	examples := map[string]string{
+		"simple-form.yaml":       "form",
+		"horizontal-layout.yaml": "layout",
+		"menu.yaml":              "menu",
+		"tabs-wizard.yaml":       "tabs",
+	}
+
+	for file, kind := range examples {
+		t.Run(file, func(t *testing.T) {
+			generated, err := Generate(kind)
+			require.NoError(t, err)
+			data, err := json.Marshal(generated)
+			require.NoError(t, err)
+			s, err := Parse(data, false)
+			require.NoError(t, err)
+
+			raw, err := os.ReadFile(filepath.Join("..", "..", "docs", "examples", file))
+			require.NoError(t, err)
+			var doc map[string]interface{}
+			require.NoError(t, yaml.Unmarshal(raw, &doc))
+
+			assert.Empty(t, s.Validate(doc))
+
+			doc["colour"] = "red"
+			assert.Equal(t, []Violation{{Field: "colour", Path: "colour", Message: "campo não permitido pelo schema"}}, s.Validate(doc))
+		})
+	}
+}
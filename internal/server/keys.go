@@ -0,0 +1,97 @@
+package server
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// LoadOrCreateHostKey reads the SSH host key at path.
+// If the file does not exist, a new ed25519 key is generated and saved with mode 0600.
+func LoadOrCreateHostKey(path string) (ssh.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		signer, err := ssh.ParsePrivateKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao analisar chave do host %s: %w", path, err)
+		}
+		return signer, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("erro ao ler chave do host %s: %w", path, err)
+	}
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gerar chave do host: %w", err)
+	}
+
+	block, err := ssh.MarshalPrivateKey(priv, "shantilly host key")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao codificar chave do host: %w", err)
+	}
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, fmt.Errorf("erro ao criar diretório da chave do host: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+		return nil, fmt.Errorf("erro ao salvar chave do host %s: %w", path, err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar assinador da chave do host: %w", err)
+	}
+	return signer, nil
+}
+
+// AuthorizedKeys is an allowlist of public keys in authorized_keys format.
+type AuthorizedKeys struct {
+	keys map[string]string // Wire-format key -> comment
+}
+
+// LoadAuthorizedKeys parses an authorized_keys file.
+// Empty lines and comments are ignored; options before the key are accepted but not enforced.
+func LoadAuthorizedKeys(path string) (*AuthorizedKeys, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler arquivo de chaves autorizadas: %w", err)
+	}
+
+	ak := &AuthorizedKeys{keys: make(map[string]string)}
+	rest := bytes.TrimSpace(data)
+	for len(rest) > 0 {
+		var pub ssh.PublicKey
+		var comment string
+		pub, comment, _, rest, err = ssh.ParseAuthorizedKey(rest)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao analisar chaves autorizadas em %s: %w", path, err)
+		}
+		ak.keys[string(pub.Marshal())] = comment
+	}
+
+	if len(ak.keys) == 0 {
+		return nil, fmt.Errorf("o arquivo de chaves autorizadas %s não contém chaves", path)
+	}
+
+	return ak, nil
+}
+
+// Len returns the number of keys in the allowlist.
+func (ak *AuthorizedKeys) Len() int {
+	return len(ak.keys)
+}
+
+// Allowed reports whether key is in the allowlist.
+func (ak *AuthorizedKeys) Allowed(key ssh.PublicKey) bool {
+	_, ok := ak.keys[string(key.Marshal())]
+	return ok
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// newTestKey generates an ed25519 signer for tests.
+func newTestKey(t *testing.T) ssh.Signer {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(priv)
+	require.NoError(t, err)
+	return signer
+}
+
+func TestLoadOrCreateHostKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys", "host_ed25519")
+
+	created, err := LoadOrCreateHostKey(path)
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	loaded, err := LoadOrCreateHostKey(path)
+	require.NoError(t, err)
+	assert.Equal(t, created.PublicKey().Marshal(), loaded.PublicKey().Marshal(), "existing key must be reused")
+
+	t.Run("invalid key file", func(t *testing.T) {
+		bad := filepath.Join(t.TempDir(), "bad")
+		require.NoError(t, os.WriteFile(bad, []byte("not a key"), 0o600))
+		_, err := LoadOrCreateHostKey(bad)
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadAuthorizedKeys(t *testing.T) {
+	allowed := newTestKey(t)
+	other := newTestKey(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "authorized_keys")
+	content := "# team keys\n\n" + string(ssh.MarshalAuthorizedKey(allowed.PublicKey()))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	ak, err := LoadAuthorizedKeys(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, ak.Len())
+	assert.True(t, ak.Allowed(allowed.PublicKey()))
+	assert.False(t, ak.Allowed(other.PublicKey()))
+
+	t.Run("empty file", func(t *testing.T) {
+		empty := filepath.Join(dir, "empty")
+		require.NoError(t, os.WriteFile(empty, []byte("# nothing\n"), 0o600))
+		_, err := LoadAuthorizedKeys(empty)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadAuthorizedKeys(filepath.Join(dir, "missing"))
+		assert.Error(t, err)
+	})
+}
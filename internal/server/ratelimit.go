@@ -0,0 +1,80 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter limits how many connections each remote host may open per window.
+// It keeps a sliding window of connection timestamps per host, and forgets
+// hosts once their timestamps have all left the window.
+type RateLimiter struct {
+	limit  int
+	window time.Duration
+	now    func() time.Time
+
+	mu    sync.Mutex
+	hits  map[string][]time.Time
+	swept time.Time // Last sweep of hosts without recent connections
+}
+
+// NewRateLimiter creates a limiter allowing limit connections per window.
+// A limit of zero or less disables limiting.
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limit:  limit,
+		window: window,
+		now:    time.Now,
+		hits:   make(map[string][]time.Time),
+	}
+}
+
+// Allow records a connection attempt from host and reports whether it is within the limit.
+func (r *RateLimiter) Allow(host string) bool {
+	if r.limit <= 0 {
+		return true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	cutoff := now.Add(-r.window)
+	if now.Sub(r.swept) >= r.window {
+		r.sweep(cutoff)
+		r.swept = now
+	}
+
+	recent := r.recent(host, cutoff)
+
+	if len(recent) >= r.limit {
+		r.hits[host] = recent
+		return false
+	}
+
+	r.hits[host] = append(recent, now)
+	return true
+}
+
+// recent drops the timestamps of host that left the window and returns the others.
+func (r *RateLimiter) recent(host string, cutoff time.Time) []time.Time {
+	recent := r.hits[host][:0]
+	for _, t := range r.hits[host] {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+	return recent
+}
+
+// sweep forgets the hosts with no connection after cutoff, so the map
+// doesn't grow with every address that ever connected.
+func (r *RateLimiter) sweep(cutoff time.Time) {
+	for host := range r.hits {
+		if recent := r.recent(host, cutoff); len(recent) == 0 {
+			delete(r.hits, host)
+		} else {
+			r.hits[host] = recent
+		}
+	}
+}
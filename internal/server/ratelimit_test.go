@@ -0,0 +1,35 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(2, time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	assert.True(t, limiter.Allow("10.0.0.1"))
+	assert.True(t, limiter.Allow("10.0.0.1"))
+	assert.False(t, limiter.Allow("10.0.0.1"), "third connection within the window is rejected")
+	assert.True(t, limiter.Allow("10.0.0.2"), "limits are per host")
+
+	now = now.Add(61 * time.Second)
+	assert.True(t, limiter.Allow("10.0.0.1"), "window slides")
+
+	t.Run("forgets idle hosts", func(t *testing.T) {
+		now = now.Add(61 * time.Second)
+		assert.True(t, limiter.Allow("10.0.0.3"))
+		assert.Len(t, limiter.hits, 1, "hosts without connections in the window are dropped")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		unlimited := NewRateLimiter(0, time.Minute)
+		for i := 0; i < 100; i++ {
+			assert.True(t, unlimited.Allow("10.0.0.1"))
+		}
+	})
+}
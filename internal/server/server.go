@@ -0,0 +1,326 @@
+// Package server implements the SSH transport used by `shantilly serve`.
+// It accepts connections, authenticates them, negotiates a PTY and hands each
+// interactive session to a Handler. It knows nothing about Bubble Tea models.
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Window is the terminal size reported by the client.
+type Window struct {
+	Width  int
+	Height int
+}
+
+// Session is an interactive SSH session with a PTY.
+// Reads return the client keystrokes and writes go to the client terminal.
+type Session struct {
+	ID         string
+	User       string
+	RemoteAddr net.Addr
+	Term       string
+	Window     Window
+	Environ    []string // Variables sent by the client, plus TERM
+
+	channel ssh.Channel
+	resize  chan Window
+}
+
+// Read implements io.Reader.
+func (s *Session) Read(p []byte) (int, error) {
+	return s.channel.Read(p)
+}
+
+// Write implements io.Writer.
+func (s *Session) Write(p []byte) (int, error) {
+	return s.channel.Write(p)
+}
+
+// Resize delivers window size changes sent by the client.
+func (s *Session) Resize() <-chan Window {
+	return s.resize
+}
+
+// Handler runs an interactive session and returns its exit status.
+// The context is cancelled when the client disconnects or the server stops.
+type Handler func(ctx context.Context, s *Session) int
+
+// Options configures a Server.
+type Options struct {
+	HostKey        ssh.Signer
+	AuthorizedKeys *AuthorizedKeys // Keys allowed to connect
+	NoClientAuth   bool            // Accept any client when AuthorizedKeys is nil
+	RateLimit      int             // Connections per minute per remote host, 0 disables
+	Handler        Handler
+}
+
+// Server is an SSH server that runs a Handler for each interactive session.
+type Server struct {
+	config  *ssh.ServerConfig
+	limiter *RateLimiter
+	handler Handler
+	wg      sync.WaitGroup
+}
+
+// New creates a Server from options.
+func New(opts Options) (*Server, error) {
+	if opts.HostKey == nil {
+		return nil, fmt.Errorf("chave do host é obrigatória")
+	}
+	if opts.Handler == nil {
+		return nil, fmt.Errorf("handler de sessão é obrigatório")
+	}
+	if opts.AuthorizedKeys == nil && !opts.NoClientAuth {
+		return nil, fmt.Errorf("chaves autorizadas são obrigatórias sem NoClientAuth")
+	}
+
+	cfg := &ssh.ServerConfig{}
+	if opts.AuthorizedKeys != nil {
+		cfg.PublicKeyCallback = func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if opts.AuthorizedKeys.Allowed(key) {
+				return &ssh.Permissions{Extensions: map[string]string{"pubkey-fp": ssh.FingerprintSHA256(key)}}, nil
+			}
+			return nil, fmt.Errorf("chave não autorizada para %s", conn.User())
+		}
+	} else {
+		cfg.NoClientAuth = true
+	}
+	cfg.AddHostKey(opts.HostKey)
+
+	return &Server{
+		config:  cfg,
+		limiter: NewRateLimiter(opts.RateLimit, time.Minute),
+		handler: opts.Handler,
+	}, nil
+}
+
+// Serve accepts connections on ln until ctx is cancelled.
+// It waits for running sessions to finish before returning.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		ln.Close()
+	}()
+
+	defer s.wg.Wait()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("erro ao aceitar conexão: %w", err)
+		}
+
+		host, _, _ := net.SplitHostPort(conn.RemoteAddr().String())
+		if !s.limiter.Allow(host) {
+			log.Printf("[WARN] Limite de conexões excedido para %s", host)
+			conn.Close()
+			continue
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handleConn(ctx, conn)
+		}()
+	}
+}
+
+// handleConn performs the SSH handshake and serves the session channels of a connection.
+func (s *Server) handleConn(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	// Slow or stalled handshakes must not hold the connection forever
+	_ = conn.SetDeadline(time.Now().Add(30 * time.Second))
+	sshConn, chans, reqs, err := ssh.NewServerConn(conn, s.config)
+	if err != nil {
+		log.Printf("[WARN] Falha no handshake SSH com %s: %v", conn.RemoteAddr(), err)
+		return
+	}
+	_ = conn.SetDeadline(time.Time{})
+	defer sshConn.Close()
+
+	log.Printf("[INFO] Conexão de %s@%s", sshConn.User(), sshConn.RemoteAddr())
+	go ssh.DiscardRequests(reqs)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		sshConn.Close()
+	}()
+
+	var sessions sync.WaitGroup
+	defer sessions.Wait()
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "apenas canais de sessão são suportados")
+			continue
+		}
+
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			log.Printf("[WARN] Falha ao aceitar canal de %s: %v", sshConn.RemoteAddr(), err)
+			continue
+		}
+
+		sess := &Session{
+			ID:         newSessionID(),
+			User:       sshConn.User(),
+			RemoteAddr: sshConn.RemoteAddr(),
+			channel:    channel,
+			resize:     make(chan Window, 1),
+		}
+		sessions.Add(1)
+		go func() {
+			defer sessions.Done()
+			s.serveSession(ctx, sess, requests)
+		}()
+	}
+}
+
+// serveSession processes channel requests and runs the handler once a shell is requested.
+func (s *Server) serveSession(ctx context.Context, sess *Session, requests <-chan *ssh.Request) {
+	defer sess.channel.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	hasPTY := false
+	done := make(chan int, 1)
+	started := false
+
+	for {
+		select {
+		case status := <-done:
+			sendExitStatus(sess.channel, status)
+			go ssh.DiscardRequests(requests)
+			return
+
+		case req, ok := <-requests:
+			if !ok {
+				// Client went away; stop the handler and wait for it
+				cancel()
+				if started {
+					<-done
+				}
+				return
+			}
+
+			switch req.Type {
+			case "pty-req":
+				term, win, ok := parsePTYRequest(req.Payload)
+				if ok && !started {
+					hasPTY = true
+					sess.Term = term
+					sess.Window = win
+					sess.Environ = append(sess.Environ, "TERM="+term)
+				}
+				reply(req, ok)
+
+			case "window-change":
+				win, ok := parseWindow(req.Payload)
+				if ok && started {
+					// Keep only the latest size if the handler is slow to consume it
+					select {
+					case <-sess.resize:
+					default:
+					}
+					sess.resize <- win
+				}
+				reply(req, ok)
+
+			case "env":
+				var kv struct{ Name, Value string }
+				ok := ssh.Unmarshal(req.Payload, &kv) == nil && !started
+				if ok {
+					sess.Environ = append(sess.Environ, kv.Name+"="+kv.Value)
+				}
+				reply(req, ok)
+
+			case "shell":
+				if started || !hasPTY {
+					reply(req, false)
+					if !hasPTY {
+						fmt.Fprint(sess.channel, "Shantilly requer um terminal interativo (use ssh -t).\r\n")
+						sendExitStatus(sess.channel, 1)
+						return
+					}
+					continue
+				}
+				reply(req, true)
+				started = true
+				log.Printf("[INFO] Sessão %s iniciada para %s@%s (%s %dx%d)",
+					sess.ID, sess.User, sess.RemoteAddr, sess.Term, sess.Window.Width, sess.Window.Height)
+				go func() {
+					done <- s.handler(ctx, sess)
+				}()
+
+			default:
+				// exec, subsystem, x11 and agent forwarding are not supported
+				reply(req, false)
+			}
+		}
+	}
+}
+
+// reply answers a request when the client asked for a reply.
+func reply(req *ssh.Request, ok bool) {
+	if req.WantReply {
+		_ = req.Reply(ok, nil)
+	}
+}
+
+// sendExitStatus reports the exit status of the session to the client.
+func sendExitStatus(channel ssh.Channel, status int) {
+	payload := ssh.Marshal(struct{ Status uint32 }{uint32(status)})
+	_, _ = channel.SendRequest("exit-status", false, payload)
+}
+
+// parsePTYRequest decodes a pty-req payload (RFC 4254, section 6.2).
+func parsePTYRequest(payload []byte) (string, Window, bool) {
+	var req struct {
+		Term          string
+		Columns, Rows uint32
+		Width, Height uint32
+		Modes         string
+	}
+	if err := ssh.Unmarshal(payload, &req); err != nil {
+		return "", Window{}, false
+	}
+	return req.Term, Window{Width: int(req.Columns), Height: int(req.Rows)}, true
+}
+
+// parseWindow decodes a window-change payload (RFC 4254, section 6.7).
+func parseWindow(payload []byte) (Window, bool) {
+	if len(payload) < 8 {
+		return Window{}, false
+	}
+	return Window{
+		Width:  int(binary.BigEndian.Uint32(payload)),
+		Height: int(binary.BigEndian.Uint32(payload[4:])),
+	}, true
+}
+
+// newSessionID returns a short random identifier for logs and output files.
+func newSessionID() string {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// startServer runs a Server on a random local port and returns its address.
+func startServer(t *testing.T, opts Options) string {
+	opts.HostKey = newTestKey(t)
+	srv, err := New(opts)
+	require.NoError(t, err)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		_ = srv.Serve(ctx, ln)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	return ln.Addr().String()
+}
+
+// dial opens an SSH client connection authenticating with signer.
+func dial(addr string, signer ssh.Signer) (*ssh.Client, error) {
+	return ssh.Dial("tcp", addr, &ssh.ClientConfig{
+		User:            "tester",
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	})
+}
+
+func TestServer_Session(t *testing.T) {
+	addr := startServer(t, Options{
+		NoClientAuth: true,
+		Handler: func(ctx context.Context, s *Session) int {
+			fmt.Fprintf(s, "%s %s %dx%d", s.User, s.Term, s.Window.Width, s.Window.Height)
+			return 3
+		},
+	})
+
+	client, err := dial(addr, newTestKey(t))
+	require.NoError(t, err)
+	defer client.Close()
+
+	session, err := client.NewSession()
+	require.NoError(t, err)
+	defer session.Close()
+
+	require.NoError(t, session.RequestPty("xterm-256color", 30, 100, ssh.TerminalModes{}))
+	stdout, err := session.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, session.Shell())
+
+	output, err := io.ReadAll(stdout)
+	require.NoError(t, err)
+	assert.Equal(t, "tester xterm-256color 100x30", string(output))
+
+	var exitErr *ssh.ExitError
+	require.True(t, errors.As(session.Wait(), &exitErr))
+	assert.Equal(t, 3, exitErr.ExitStatus())
+}
+
+func TestServer_RequiresPTY(t *testing.T) {
+	called := false
+	addr := startServer(t, Options{
+		NoClientAuth: true,
+		Handler: func(ctx context.Context, s *Session) int {
+			called = true
+			return 0
+		},
+	})
+
+	client, err := dial(addr, newTestKey(t))
+	require.NoError(t, err)
+	defer client.Close()
+
+	session, err := client.NewSession()
+	require.NoError(t, err)
+	defer session.Close()
+
+	assert.Error(t, session.Shell())
+	assert.False(t, called)
+}
+
+func TestServer_AuthorizedKeys(t *testing.T) {
+	allowed := newTestKey(t)
+	addr := startServer(t, Options{
+		AuthorizedKeys: &AuthorizedKeys{keys: map[string]string{string(allowed.PublicKey().Marshal()): ""}},
+		Handler:        func(ctx context.Context, s *Session) int { return 0 },
+	})
+
+	client, err := dial(addr, allowed)
+	require.NoError(t, err)
+	client.Close()
+
+	_, err = dial(addr, newTestKey(t))
+	assert.Error(t, err, "keys outside the allowlist are rejected")
+}
+
+func TestNew_RequiresClientAuth(t *testing.T) {
+	_, err := New(Options{
+		HostKey: newTestKey(t),
+		Handler: func(ctx context.Context, s *Session) int { return 0 },
+	})
+	assert.Error(t, err, "open access must be asked for explicitly")
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	addr := startServer(t, Options{
+		NoClientAuth: true,
+		RateLimit:    1,
+		Handler:      func(ctx context.Context, s *Session) int { return 0 },
+	})
+
+	client, err := dial(addr, newTestKey(t))
+	require.NoError(t, err)
+	client.Close()
+
+	_, err = dial(addr, newTestKey(t))
+	assert.Error(t, err, "second connection within a minute is dropped")
+}
+
+func TestParseWindow(t *testing.T) {
+	payload := ssh.Marshal(struct{ Columns, Rows, Width, Height uint32 }{120, 40, 0, 0})
+	win, ok := parseWindow(payload)
+	assert.True(t, ok)
+	assert.Equal(t, Window{Width: 120, Height: 40}, win)
+
+	_, ok = parseWindow([]byte{1, 2})
+	assert.False(t, ok)
+}